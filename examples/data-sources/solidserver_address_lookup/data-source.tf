@@ -0,0 +1,16 @@
+data "solidserver_address_lookup" "byMAC" {
+  mac = "00:50:56:aa:bb:cc"
+}
+
+data "solidserver_address_lookup" "webServers" {
+  space  = "${solidserver_ip_space.myFirstSpace.name}"
+  family = "ipv4"
+  name   = "web-*"
+  class_parameters = {
+    environment = "production"
+  }
+}
+
+output "webServerSubnets" {
+  value = [for a in data.solidserver_address_lookup.webServers.addresses : "${a.space}/${a.subnet}/${a.address}"]
+}
@@ -0,0 +1,9 @@
+data "solidserver_ip6_addresses" "myWebServers" {
+  space  = "myFirstSpace"
+  subnet = "myFirstIP6Subnet"
+  name   = "web-*"
+}
+
+output "myWebServersAddresses" {
+  value = [for address in data.solidserver_ip6_addresses.myWebServers.addresses : address.address]
+}
@@ -0,0 +1,9 @@
+data "solidserver_ip6_pools" "myLoadBalancerPools" {
+  space  = "myFirstSpace"
+  subnet = "myFirstIP6Subnet"
+  name   = "lb-*"
+}
+
+output "myLoadBalancerRanges" {
+  value = { for pool in data.solidserver_ip6_pools.myLoadBalancerPools.pools : pool.name => "${pool.start}-${pool.end}" }
+}
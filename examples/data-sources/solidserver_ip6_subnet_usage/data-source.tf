@@ -0,0 +1,11 @@
+data "solidserver_ip6_subnet_usage" "myFirstIP6BlockUsage" {
+  space = "${solidserver_ip_space.myFirstSpace.name}"
+  name  = "myFirstIP6Block"
+}
+
+check "ip6_block_capacity" {
+  assert {
+    condition     = data.solidserver_ip6_subnet_usage.myFirstIP6BlockUsage.largest_free_prefix_size != 0 && data.solidserver_ip6_subnet_usage.myFirstIP6BlockUsage.largest_free_prefix_size <= 64
+    error_message = "The block myFirstIP6Block has no free /64 left."
+  }
+}
@@ -0,0 +1,12 @@
+data "solidserver_ip6_subnets" "myProdSubnets" {
+  space = "myFirstSpace"
+  block = "myFirstIP6Block"
+  name  = "prod-*"
+  class_parameters = {
+    vnid = "12666"
+  }
+}
+
+output "myProdPrefixes" {
+  value = [for subnet in data.solidserver_ip6_subnets.myProdSubnets.subnets : subnet.prefix]
+}
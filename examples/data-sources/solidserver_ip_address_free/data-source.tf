@@ -0,0 +1,11 @@
+data "solidserver_ip_address_free" "myNextIPAddresses" {
+  space               = "${solidserver_ip_space.myFirstSpace.name}"
+  subnet              = "myFirstIPSubnet"
+  allocation_strategy = "lowest"
+  skip_first          = 10
+  limit               = 4
+}
+
+output "next_ip_addresses" {
+  value = data.solidserver_ip_address_free.myNextIPAddresses.addresses
+}
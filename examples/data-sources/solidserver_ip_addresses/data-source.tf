@@ -0,0 +1,15 @@
+data "solidserver_ip_addresses" "myWebServers" {
+  space  = "myFirstSpace"
+  subnet = "myFirstIPSubnet"
+  name   = "web-*"
+}
+
+resource "solidserver_dns_rr" "myWebServersRecords" {
+  for_each = { for address in data.solidserver_ip_addresses.myWebServers.addresses : address.name => address }
+
+  dnsserver = "ns.mycompany.priv"
+  dnsview   = "Internal"
+  name      = "${each.key}.mycompany.priv"
+  type      = "A"
+  value     = each.value.address
+}
@@ -0,0 +1,11 @@
+data "solidserver_ip_pools" "myLoadBalancerPools" {
+  space = "myFirstSpace"
+  name  = "lb-*"
+  class_parameters = {
+    owner = "network-team"
+  }
+}
+
+output "myLoadBalancerRanges" {
+  value = { for pool in data.solidserver_ip_pools.myLoadBalancerPools.pools : pool.name => "${pool.start}-${pool.end}" }
+}
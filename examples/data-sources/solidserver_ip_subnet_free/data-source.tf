@@ -0,0 +1,11 @@
+data "solidserver_ip_subnet_free" "myNextIPSubnets" {
+  space               = "${solidserver_ip_space.myFirstSpace.name}"
+  blocks              = ["myEuropeIPBlock", "myAmericaIPBlock"]
+  prefix_size         = 26
+  allocation_strategy = "best-fit"
+  limit               = 2
+}
+
+output "next_ip_subnets" {
+  value = data.solidserver_ip_subnet_free.myNextIPSubnets.prefixes
+}
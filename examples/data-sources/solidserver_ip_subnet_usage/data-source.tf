@@ -0,0 +1,17 @@
+data "solidserver_ip_subnet_usage" "myFirstIPSubnetUsage" {
+  space = "${solidserver_ip_space.myFirstSpace.name}"
+  name  = "myFirstIPSubnet"
+}
+
+resource "solidserver_ip_address" "myFirstIPAddress" {
+  space  = "${solidserver_ip_space.myFirstSpace.name}"
+  subnet = "myFirstIPSubnet"
+  name   = "myfirstipaddress"
+
+  lifecycle {
+    precondition {
+      condition     = data.solidserver_ip_subnet_usage.myFirstIPSubnetUsage.percent_used < 90
+      error_message = "The subnet myFirstIPSubnet is more than 90% used."
+    }
+  }
+}
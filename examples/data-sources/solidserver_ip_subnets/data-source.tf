@@ -0,0 +1,13 @@
+data "solidserver_ip_subnets" "myProdSubnets" {
+  space = "myFirstSpace"
+  block = "myFirstIPBlock"
+  name  = "prod-*"
+  class = "VIRTUAL_NETWORK"
+  class_parameters = {
+    vnid = "12666"
+  }
+}
+
+output "myProdPrefixes" {
+  value = [for subnet in data.solidserver_ip_subnets.myProdSubnets.subnets : subnet.prefix]
+}
@@ -0,0 +1,18 @@
+data "solidserver_rest_query" "myFirstRESTQuery" {
+  service = "ip_address_list"
+  query   = "site_name='myFirstSpace' AND tag_ip_owner='team-a'"
+  tags    = "ip.owner"
+  orderby = "ip_addr"
+  limit   = 500
+  fields  = ["ip_id", "name", "hostaddr", "mac_addr"]
+}
+
+resource "solidserver_dns_rr" "myFirstRESTQueryRecords" {
+  for_each = { for row in data.solidserver_rest_query.myFirstRESTQuery.rows : row.name => row }
+
+  dnsserver = "ns.mycompany.priv"
+  dnsview   = "Internal"
+  name      = each.key
+  type      = "A"
+  value     = each.value.hostaddr
+}
@@ -0,0 +1,8 @@
+data "solidserver_vlan_free" "myNextVLANs" {
+  vlan_domain = "${solidserver_vlan_domain.myFirstVxlanDomain.name}"
+  limit       = 3
+}
+
+output "next_vlan_ids" {
+  value = data.solidserver_vlan_free.myNextVLANs.vlan_ids
+}
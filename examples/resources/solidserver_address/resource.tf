@@ -0,0 +1,12 @@
+resource "solidserver_address" "myFirstAddress" {
+  space  = "${solidserver_ip_space.myFirstSpace.name}"
+  subnet = "${solidserver_subnet.myFirstSubnet.name}"
+  name   = "myfirstaddress"
+}
+
+resource "solidserver_address" "myFirstIP6Address" {
+  space      = "${solidserver_ip_space.myFirstSpace.name}"
+  subnet     = "${solidserver_subnet.myFirstSubnet.name}"
+  request_ip = "2001:db8:0:1::10"
+  name       = "myfirstip6address"
+}
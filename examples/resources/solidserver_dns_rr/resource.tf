@@ -20,4 +20,56 @@ resource "solidserver_dns_rr" "aaRecord" {
   name      = "${solidserver_ip_ptr.myFirstIPPTR.dname}"
   type      = "PTR"
   value     = "myapp.mycompany.priv"
-}
\ No newline at end of file
+}
+
+resource "solidserver_dns_rr" "mxRecord" {
+  dnsserver = "ns.mycompany.priv"
+  dnsview   = "Internal"
+  dnszone   = "mycompany.priv"
+  name      = "mycompany.priv"
+  type      = "MX"
+
+  mx {
+    priority = 10
+    target   = "mail.mycompany.priv"
+  }
+}
+
+resource "solidserver_dns_rr" "srvRecord" {
+  dnsserver = "ns.mycompany.priv"
+  dnsview   = "Internal"
+  dnszone   = "mycompany.priv"
+  name      = "_sip._tcp.mycompany.priv"
+  type      = "SRV"
+
+  srv {
+    priority = 10
+    weight   = 5
+    port     = 5060
+    target   = "sip.mycompany.priv"
+  }
+}
+
+resource "solidserver_dns_rr" "caaRecord" {
+  dnsserver = "ns.mycompany.priv"
+  dnsview   = "Internal"
+  dnszone   = "mycompany.priv"
+  name      = "mycompany.priv"
+  type      = "CAA"
+
+  caa {
+    flags = 0
+    tag   = "issue"
+    value = "letsencrypt.org"
+  }
+}
+
+// TXT values longer than 255 characters are split into several strings
+resource "solidserver_dns_rr" "dkimRecord" {
+  dnsserver = "ns.mycompany.priv"
+  dnsview   = "Internal"
+  dnszone   = "mycompany.priv"
+  name      = "selector._domainkey.mycompany.priv"
+  type      = "TXT"
+  value     = "v=DKIM1; k=rsa; p=${var.dkim_public_key}"
+}
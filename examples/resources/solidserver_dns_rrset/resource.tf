@@ -0,0 +1,18 @@
+resource "solidserver_dns_rrset" "webRoundRobin" {
+  dnsserver = "ns.mycompany.priv"
+  dnsview   = "Internal"
+  dnszone   = "mycompany.priv"
+  name      = "www.mycompany.priv"
+  type      = "A"
+  ttl       = 300
+  values    = ["10.0.0.10", "10.0.0.11", "10.0.0.12"]
+}
+
+resource "solidserver_dns_rrset" "mailExchangers" {
+  dnsserver = "ns.mycompany.priv"
+  dnsview   = "Internal"
+  dnszone   = "mycompany.priv"
+  name      = "mycompany.priv"
+  type      = "MX"
+  values    = ["10 mx1.mycompany.priv", "20 mx2.mycompany.priv"]
+}
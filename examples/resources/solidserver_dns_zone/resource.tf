@@ -4,4 +4,29 @@ resource "solidserver_dns_zone" "myFirstZone" {
   type      = "master"
   space     = "${solidserver_ip_space.myFirstSpace.name}"
   createptr = false
-}
\ No newline at end of file
+}
+resource "solidserver_dns_zone" "mySecondaryZone" {
+  dnsserver = "ns.priv"
+  name      = "partner.com"
+  type      = "slave"
+
+  masters {
+    address = "192.0.2.53"
+  }
+
+  masters {
+    address  = "192.0.2.54"
+    port     = 5353
+    tsig_key = "partner-transfer"
+  }
+}
+
+resource "solidserver_dns_zone" "myStubZone" {
+  dnsserver = "ns.priv"
+  name      = "corp.partner.com"
+  type      = "stub"
+
+  masters {
+    address = "192.0.2.53"
+  }
+}
@@ -0,0 +1,38 @@
+resource "solidserver_dns_zone_records" "myFirstZoneContent" {
+  dnsserver = "ns.mycompany.priv"
+  dnsview   = "Internal"
+  dnszone   = "mycompany.priv"
+
+  record {
+    name  = "@"
+    type  = "MX"
+    value = "10 mx1.mycompany.priv"
+  }
+
+  record {
+    name  = "www"
+    type  = "A"
+    value = "10.0.0.10"
+    ttl   = 300
+  }
+
+  record {
+    name  = "mail"
+    type  = "CNAME"
+    value = "mx1.mycompany.priv"
+  }
+
+  ignore {
+    type = "TXT"
+    name = "_acme-challenge.*"
+  }
+}
+
+resource "solidserver_dns_zone_records" "migratedZoneContent" {
+  dnsserver = "ns.mycompany.priv"
+  dnsview   = "Internal"
+  dnszone   = "legacy.mycompany.priv"
+  zone_file = file("${path.module}/db.legacy.mycompany.priv")
+
+  delete_records = true
+}
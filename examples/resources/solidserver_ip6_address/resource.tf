@@ -17,4 +17,23 @@ resource "solidserver_ip6_address" "myFirstIP6Address" {
   lifecycle {
     ignore_changes = ["mac"]
   }
-}
\ No newline at end of file
+}
+
+resource "solidserver_ip6_address" "myHighestIP6Address" {
+  space               = "${solidserver_ip_space.myFirstSpace.name}"
+  subnet              = "${solidserver_ip6_subnet.myFirstIP6Subnet.name}"
+  name                = "myhighestip6address"
+  allocation_strategy = "highest"
+  skip_first          = 10
+  skip_last           = 3
+  exclude             = ["2001:db8::100-2001:db8::1ff"]
+}
+
+resource "solidserver_ip6_address" "myDNSIP6Address" {
+  space    = "${solidserver_ip_space.myFirstSpace.name}"
+  subnet   = "${solidserver_ip6_subnet.myFirstIP6Subnet.name}"
+  name     = "mydnsip6address"
+  dns_zone = "mycompany.priv"
+  dns_name = "www"
+  ttl      = 300
+}
@@ -0,0 +1,8 @@
+resource "solidserver_ip6_delegated_prefix" "myFirstDelegatedPrefix" {
+  space = "${solidserver_ip_space.myFirstSpace.name}"
+  pool  = "${solidserver_ip6_delegation_pool.myFirstDelegationPool.name}"
+  name  = "cpe-0001"
+  class_parameters = {
+    duid = "00:03:00:01:00:50:56:aa:bb:cc"
+  }
+}
@@ -0,0 +1,7 @@
+resource "solidserver_ip6_delegation_pool" "myFirstDelegationPool" {
+  space                 = "${solidserver_ip_space.myFirstSpace.name}"
+  block                 = "${solidserver_ip6_subnet.myFirstIP6Block.name}"
+  prefix_size           = 48
+  delegated_prefix_size = 56
+  name                  = "myfirstdelegationpool"
+}
@@ -4,4 +4,12 @@ resource "solidserver_ip6_pool" "myFirstIPPool" {
   name             = "myFirstIP6Pool"
   start            = "${solidserver_ip6_subnet.mySecondIP6Subnet.address}"
   size             = 2
-}
\ No newline at end of file
+}
+
+resource "solidserver_ip6_pool" "myCidrIP6Pool" {
+  space     = "${solidserver_ip_space.myFirstSpace.name}"
+  subnet    = "${solidserver_ip6_subnet.mySecondIP6Subnet.name}"
+  name      = "myCidrIP6Pool"
+  cidr      = "2a00:2381:126d:1::/120"
+  read_only = true
+}
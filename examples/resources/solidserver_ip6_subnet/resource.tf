@@ -16,4 +16,30 @@ resource "solidserver_ip6_subnet" "myFirstIP6Subnet" {
   class_parameters = {
     vnid = "12666"
   }
-}
\ No newline at end of file
+}
+
+resource "solidserver_ip6_subnet" "mySpreadIP6Subnet" {
+  space               = "${solidserver_ip_space.myFirstSpace.name}"
+  blocks              = ["myEuropeIP6Block", "myAmericaIP6Block"]
+  allocation_strategy = "random"
+  prefix_size         = 64
+  name                = "mySpreadIP6Subnet"
+}
+resource "solidserver_ip6_subnet" "myRoutedIP6Subnet" {
+  space       = "${solidserver_ip_space.myFirstSpace.name}"
+  block       = "${solidserver_ip6_subnet.myFirstIP6Block.name}"
+  prefix_size = 64
+  name        = "myRoutedIP6Subnet"
+
+  reserved_addresses {
+    offset = 1
+    name   = "vip.myRoutedIP6Subnet"
+    role   = "hsrp"
+  }
+
+  reserved_addresses {
+    ip   = "2a00:2381:126d:0:0:0:0:53"
+    name = "relay.myRoutedIP6Subnet"
+    role = "dhcp-relay"
+  }
+}
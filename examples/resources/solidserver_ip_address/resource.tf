@@ -17,4 +17,23 @@ resource "solidserver_ip_address" "myFirstIPAddress" {
   lifecycle {
     ignore_changes = ["mac"]
   }
-}
\ No newline at end of file
+}
+
+resource "solidserver_ip_address" "myHighestIPAddress" {
+  space               = "${solidserver_ip_space.myFirstSpace.name}"
+  subnet              = "${solidserver_ip_subnet.myFirstIPSubnet.name}"
+  name                = "myhighestipaddress"
+  allocation_strategy = "highest"
+  skip_first          = 10
+  skip_last           = 3
+  exclude             = ["10.0.0.100-10.0.0.120", "10.0.0.200/29"]
+}
+
+resource "solidserver_ip_address" "myDNSIPAddress" {
+  space    = "${solidserver_ip_space.myFirstSpace.name}"
+  subnet   = "${solidserver_ip_subnet.myFirstIPSubnet.name}"
+  name     = "mydnsipaddress"
+  dns_zone = "mycompany.priv"
+  dns_name = "www"
+  ttl      = 300
+}
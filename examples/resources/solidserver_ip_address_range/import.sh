@@ -0,0 +1,2 @@
+# Import using <space>/<subnet>/<pool>/<first>/<name_prefix>
+terraform import solidserver_ip_address_range.myK8sNodePool mySpace/myFirstIPSubnet//10.0.0.10/k8s-node-
@@ -0,0 +1,9 @@
+resource "solidserver_ip_address_range" "myK8sNodePool" {
+  space       = "${solidserver_ip_space.myFirstSpace.name}"
+  subnet      = "${solidserver_ip_subnet.myFirstIPSubnet.name}"
+  size        = 8
+  name_prefix = "k8s-node-"
+  class_parameters = {
+    cluster = "prod"
+  }
+}
@@ -4,4 +4,20 @@ resource "solidserver_ip_pool" "myFirstIPPool" {
   name             = "myFirstIPPool"
   start            = "${solidserver_ip_subnet.mySecondIPSubnet.address}"
   size             = 2
-}
\ No newline at end of file
+}
+
+resource "solidserver_ip_pool" "myRangeIPPool" {
+  space     = "${solidserver_ip_space.myFirstSpace.name}"
+  subnet    = "${solidserver_ip_subnet.mySecondIPSubnet.name}"
+  name      = "myRangeIPPool"
+  start     = "10.0.1.100"
+  end       = "10.0.1.149"
+  read_only = true
+}
+
+resource "solidserver_ip_pool" "myCidrIPPool" {
+  space  = "${solidserver_ip_space.myFirstSpace.name}"
+  subnet = "${solidserver_ip_subnet.mySecondIPSubnet.name}"
+  name   = "myCidrIPPool"
+  cidr   = "10.0.1.192/26"
+}
@@ -4,4 +4,9 @@ resource "solidserver_ip_space" "myFirstSpace" {
   class_parameters = {
     LOCATION = "PARIS"
   }
-}
\ No newline at end of file
+}
+
+resource "solidserver_ip_space" "myTenantSpace" {
+  name         = "myTenantSpace"
+  parent_space = "${solidserver_ip_space.myFirstSpace.name}"
+}
@@ -16,4 +16,58 @@ resource "solidserver_ip_subnet" "myFirstIPSubnet" {
   class_parameters = {
     vnid = "12666"
   }
-}
\ No newline at end of file
+}
+
+resource "solidserver_ip_subnet" "mySpreadIPSubnet" {
+  space               = "${solidserver_ip_space.myFirstSpace.name}"
+  blocks              = ["myEuropeIPBlock", "myAmericaIPBlock"]
+  allocation_strategy = "best-fit"
+  prefix_size         = 26
+  name                = "mySpreadIPSubnet"
+}
+
+resource "solidserver_ip_subnet" "myQueriedIPSubnet" {
+  space               = "${solidserver_ip_space.myFirstSpace.name}"
+  block_query         = "subnet_class_name='region-eu'"
+  allocation_strategy = "last-fit"
+  prefix_size         = 27
+  name                = "myQueriedIPSubnet"
+}
+resource "solidserver_ip_subnet" "myTenantIPBlock" {
+  space         = "${solidserver_ip_space.myTenantSpace.name}"
+  parent_subnet = "${solidserver_ip_subnet.myFirstIPSubnet.name}"
+  prefix_size   = 24
+  name          = "myTenantIPBlock"
+  terminal      = false
+}
+
+resource "solidserver_ip_subnet" "myRoutedIPSubnet" {
+  space       = "${solidserver_ip_space.myFirstSpace.name}"
+  block       = "${solidserver_ip_subnet.myFirstIPBlock.name}"
+  prefix_size = 24
+  name        = "myRoutedIPSubnet"
+
+  reserved_addresses {
+    offset = 1
+    name   = "vip.myRoutedIPSubnet"
+    role   = "vrrp"
+  }
+
+  reserved_addresses {
+    offset = 2
+    name   = "rtr-a.myRoutedIPSubnet"
+    role   = "router"
+  }
+
+  reserved_addresses {
+    offset = 3
+    name   = "rtr-b.myRoutedIPSubnet"
+    role   = "router"
+  }
+
+  reserved_addresses {
+    offset = -1
+    name   = "broadcast.myRoutedIPSubnet"
+    role   = "broadcast"
+  }
+}
@@ -0,0 +1,5 @@
+resource "solidserver_ip_subnet_merge" "myMergedIPSubnet" {
+  space   = "${solidserver_ip_space.myFirstSpace.name}"
+  subnets = ["myFrontIPSubnet", "myBackIPSubnet"]
+  name    = "myMergedIPSubnet"
+}
@@ -0,0 +1,6 @@
+resource "solidserver_ip_subnet_split" "mySplitIPSubnet" {
+  space       = "${solidserver_ip_space.myFirstSpace.name}"
+  subnet      = "myFirstIPSubnet"
+  prefix_size = 26
+  names       = ["myFrontIPSubnet", "myBackIPSubnet", "myAdminIPSubnet", "mySpareIPSubnet"]
+}
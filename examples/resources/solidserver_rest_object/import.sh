@@ -0,0 +1,5 @@
+# Import using <add_service>:<info_service>:<delete_service>:<id_parameter>:<oid>
+terraform import solidserver_rest_object.myFirstDHCPScope dhcp_scope_add:dhcp_scope_info:dhcp_scope_delete:dhcpscope_id:42
+
+# Import using <add_service>:<info_service>:<delete_service>:<id_parameter>:<oid>:<parameter>=<field>,... to populate the parameters
+terraform import solidserver_rest_object.myFirstDHCPScope dhcp_scope_add:dhcp_scope_info:dhcp_scope_delete:dhcpscope_id:42:dhcpscope_name=dhcpscope_name
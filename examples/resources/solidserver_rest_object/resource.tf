@@ -0,0 +1,15 @@
+resource "solidserver_rest_object" "myFirstDHCPScope" {
+  add_service    = "dhcp_scope_add"
+  info_service   = "dhcp_scope_info"
+  delete_service = "dhcp_scope_delete"
+  id_parameter   = "dhcpscope_id"
+  parameters = {
+    dhcpscope_name     = "myfirstdhcpscope"
+    dhcpscope_net_addr = "10.0.0.0"
+    dhcpscope_net_mask = "255.255.255.0"
+    dhcp_name          = "dhcp.local"
+  }
+  read_fields = {
+    dhcpscope_name = "dhcpscope_name"
+  }
+}
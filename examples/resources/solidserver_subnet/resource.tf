@@ -0,0 +1,26 @@
+resource "solidserver_subnet" "myFirstSubnet" {
+  space            = "${solidserver_ip_space.myFirstSpace.name}"
+  block            = "myFirstBlock"
+  prefix_size      = 24
+  name             = "myfirstsubnet"
+  gateway_offset   = -1
+  vlan_domain      = "${solidserver_vlan_domain.myFirstVxlanDomain.name}"
+  vlan_id          = 100
+  class            = "VIRTUAL"
+  class_parameters = {
+    vnid = "12666"
+  }
+
+  dual_stack {
+    block       = "myFirstIP6Block"
+    prefix_size = 64
+  }
+}
+
+resource "solidserver_subnet" "myFirstIP6Subnet" {
+  space       = "${solidserver_ip_space.myFirstSpace.name}"
+  family      = "ipv6"
+  block       = "myFirstIP6Block"
+  prefix_size = 64
+  name        = "myfirstip6subnet"
+}
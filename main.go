@@ -1,11 +1,22 @@
 package main
 
 import (
+	"os"
+
 	"github.com/EfficientIP-Labs/terraform-provider-solidserver/solidserver"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "export":
+			os.Exit(solidserver.Export(os.Args[2:], os.Stdout, os.Stderr))
+		case "doctor":
+			os.Exit(solidserver.Doctor(os.Args[2:], os.Stdout, os.Stderr))
+		}
+	}
+
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: solidserver.Provider,
 	})
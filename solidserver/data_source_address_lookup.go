@@ -0,0 +1,254 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+func dataSourceaddresslookup() *schema.Resource {
+	filters := []string{"mac", "name", "device", "class", "class_parameters"}
+
+	return &schema.Resource{
+		ReadContext: dataSourceaddresslookupRead,
+
+		Description: heredoc.Doc(`
+			Address lookup data-source allows to find where a MAC address, a hostname or a device lives,
+			searching the IPv4 and IPv6 addresses of one or all spaces.
+			It returns every matching address along with its space, subnet and pool.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space into which looking for the addresses (Default: all spaces).",
+				Optional:    true,
+			},
+			"subnet": {
+				Type:        schema.TypeString,
+				Description: "The name of the subnet into which looking for the addresses (Default: all subnets).",
+				Optional:    true,
+			},
+			"family": {
+				Type:         schema.TypeString,
+				Description:  "The IP family of the addresses, either ipv4 or ipv6 (Default: both).",
+				ValidateFunc: validation.StringInSlice([]string{"ipv4", "ipv6"}, false),
+				Optional:     true,
+			},
+			"mac": {
+				Type:         schema.TypeString,
+				Description:  "The MAC address of the addresses.",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile("^([0-9A-Fa-f]{2}[:-]){5}([0-9A-Fa-f]{2})$"), "Unsupported MAC address format."),
+				Optional:     true,
+				AtLeastOneOf: filters,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Description:  "The short name or FQDN of the addresses, supporting glob patterns (ex: 'web-*').",
+				Optional:     true,
+				AtLeastOneOf: filters,
+			},
+			"device": {
+				Type:         schema.TypeString,
+				Description:  "The name of the device associated to the addresses.",
+				Optional:     true,
+				AtLeastOneOf: filters,
+			},
+			"class": {
+				Type:         schema.TypeString,
+				Description:  "The class associated to the addresses.",
+				Optional:     true,
+				AtLeastOneOf: filters,
+			},
+			"class_parameters": {
+				Type:             schema.TypeMap,
+				Description:      "The class parameters values the addresses must match.",
+				ValidateDiagFunc: validation.MapKeyMatch(classParameterFilterRegexp, "Unsupported class parameter name."),
+				Optional:         true,
+				AtLeastOneOf:     filters,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Description:  "The maximum number of addresses to retrieve. Default is 0 (No limit).",
+				ValidateFunc: validation.IntAtLeast(0),
+				Optional:     true,
+				Default:      0,
+			},
+			"addresses": {
+				Type:        schema.TypeList,
+				Description: "The addresses matching the filters, IPv4 ones first.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Description: "The ID of the address.",
+							Computed:    true,
+						},
+						"family": {
+							Type:        schema.TypeString,
+							Description: "The IP family of the address.",
+							Computed:    true,
+						},
+						"space": {
+							Type:        schema.TypeString,
+							Description: "The space of the address.",
+							Computed:    true,
+						},
+						"subnet": {
+							Type:        schema.TypeString,
+							Description: "The subnet of the address.",
+							Computed:    true,
+						},
+						"pool": {
+							Type:        schema.TypeString,
+							Description: "The pool of the address.",
+							Computed:    true,
+						},
+						"address": {
+							Type:        schema.TypeString,
+							Description: "The IP address.",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The short name or FQDN of the address.",
+							Computed:    true,
+						},
+						"mac": {
+							Type:        schema.TypeString,
+							Description: "The MAC address of the address.",
+							Computed:    true,
+						},
+						"device": {
+							Type:        schema.TypeString,
+							Description: "The device associated to the address.",
+							Computed:    true,
+						},
+						"class": {
+							Type:        schema.TypeString,
+							Description: "The class associated to the address.",
+							Computed:    true,
+						},
+						"class_parameters": {
+							Type:        schema.TypeMap,
+							Description: "The class parameters associated to the address.",
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Build the parameters of an address lookup for a family
+func dataSourceaddresslookupparameters(d *schema.ResourceData, ipv6 bool) url.Values {
+	subnetField, nameField, classField, macField, tagPrefix := "subnet_name", "name", "ip_class_name", "mac_addr", "ip"
+
+	if ipv6 {
+		subnetField, nameField, classField, macField, tagPrefix = "subnet6_name", "ip6_name", "ip6_class_name", "ip6_mac_addr", "ip6"
+	}
+
+	parameters := ipamfilterparameters(d, "subnet", subnetField, nameField, classField, tagPrefix)
+	clauses := []string{}
+
+	if where := parameters.Get("WHERE"); len(where) > 0 {
+		clauses = append(clauses, where)
+	}
+
+	// MAC addresses are stored lowercase, colon separated and possibly prefixed by their type
+	if mac, macExist := d.GetOk("mac"); macExist {
+		clauses = append(clauses, macField+" LIKE '%"+strings.ToLower(strings.ReplaceAll(mac.(string), "-", ":"))+"'")
+	}
+
+	if device, deviceExist := d.GetOk("device"); deviceExist {
+		clauses = append(clauses, "hostdev_name='"+wherequote(device.(string))+"'")
+	}
+
+	if len(clauses) > 0 {
+		parameters.Set("WHERE", strings.Join(clauses, " AND "))
+	}
+
+	return parameters
+}
+
+func dataSourceaddresslookupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	addresses := []interface{}{}
+	queries := []string{}
+	limit := d.Get("limit").(int)
+
+	for _, ipv6 := range []bool{false, true} {
+		family, service, flatten := "ipv4", "ip_address_list", dataSourceipaddressesflatten
+
+		if ipv6 {
+			family, service, flatten = "ipv6", "ip6_address6_list", dataSourceip6addressesflatten
+		}
+
+		if f := d.Get("family").(string); len(f) > 0 && f != family {
+			continue
+		}
+
+		// The limit applies to the addresses of both families
+		familyLimit := 0
+
+		if limit > 0 {
+			if familyLimit = limit - len(addresses); familyLimit <= 0 {
+				break
+			}
+		}
+
+		// Building parameters
+		parameters := dataSourceaddresslookupparameters(d, ipv6)
+		queries = append(queries, service+"?"+parameters.Encode())
+
+		// Sending the read request(s)
+		buf, err := solidserverlist(service, parameters, familyLimit, meta)
+
+		if err != nil {
+			// Reporting a failure
+			return diag.FromErr(err)
+		}
+
+		for _, entry := range buf {
+			attributes := flatten(entry)
+
+			addresses = append(addresses, map[string]interface{}{
+				"id":               attributes["id"],
+				"family":           family,
+				"space":            attributes["space"],
+				"subnet":           attributes["subnet"],
+				"pool":             attributes["pool"],
+				"address":          attributes["address"],
+				"name":             attributes["name"],
+				"mac":              attributes["mac"],
+				"device":           attributes["device"],
+				"class":            attributes["class"],
+				"class_parameters": attributes["class_parameters"],
+			})
+		}
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Found %d address(es)\n", len(addresses)))
+
+	d.SetId(strconv.Itoa(schema.HashString(strings.Join(queries, "&"))))
+	d.Set("addresses", addresses)
+
+	return nil
+}
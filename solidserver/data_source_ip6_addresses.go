@@ -0,0 +1,139 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/url"
+	"regexp"
+	"strconv"
+)
+
+func dataSourceip6addresses() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceip6addressesRead,
+
+		Description: heredoc.Doc(`
+			IPv6 addresses data-source allows to retrieve information about every reserved IPv6 address matching given filters,
+			including their meta-data.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space of the IPv6 addresses.",
+				Optional:    true,
+			},
+			"subnet": {
+				Type:        schema.TypeString,
+				Description: "The name of the subnet of the IPv6 addresses.",
+				Optional:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The short name or FQDN of the IPv6 addresses, supporting glob patterns (ex: 'web-*').",
+				Optional:    true,
+			},
+			"class": {
+				Type:        schema.TypeString,
+				Description: "The class associated to the IPv6 addresses.",
+				Optional:    true,
+			},
+			"class_parameters": {
+				Type:             schema.TypeMap,
+				Description:      "The class parameters values the IPv6 addresses must match.",
+				ValidateDiagFunc: validation.MapKeyMatch(classParameterFilterRegexp, "Unsupported class parameter name."),
+				Optional:         true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Description:  "The maximum number of IPv6 addresses to retrieve. Default is 0 (No limit).",
+				ValidateFunc: validation.IntAtLeast(0),
+				Optional:     true,
+				Default:      0,
+			},
+			"addresses": {
+				Type:        schema.TypeList,
+				Description: "The IPv6 addresses matching the filters.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: dataSourcecomputedschema(dataSourceip6address().Schema),
+				},
+			},
+		},
+	}
+}
+
+// Convert an entry of rest/ip6_address6_list into a map of attributes
+func dataSourceip6addressesflatten(entry map[string]interface{}) map[string]interface{} {
+	attributes := restobjectattributes(entry)
+	res := make(map[string]interface{})
+
+	prefixLength, _ := strconv.Atoi(attributes["subnet6_prefix"])
+
+	res["id"] = attributes["ip6_id"]
+	res["space"] = attributes["site_name"]
+	res["subnet"] = attributes["subnet6_name"]
+	res["pool"] = attributes["pool6_name"]
+	res["address"] = hexip6toip6(attributes["ip6_addr"])
+	res["name"] = attributes["ip6_name"]
+	res["device"] = attributes["hostdev_name"]
+	res["prefix"] = hexip6toip6(attributes["subnet6_start_ip6_addr"]) + "/" + strconv.Itoa(prefixLength)
+	res["prefix_size"] = prefixLength
+	res["class"] = attributes["ip6_class_name"]
+
+	if macIgnore, _ := regexp.MatchString("^EIP:", attributes["ip6_mac_addr"]); !macIgnore {
+		res["mac"] = attributes["ip6_mac_addr"]
+	} else {
+		res["mac"] = ""
+	}
+
+	// Setting class_parameters
+	retrievedClassParameters, _ := url.ParseQuery(attributes["ip6_class_parameters"])
+	computedClassParameters := map[string]interface{}{}
+
+	for ck := range retrievedClassParameters {
+		if ck != "gateway" {
+			computedClassParameters[ck] = retrievedClassParameters[ck][0]
+		}
+	}
+
+	res["class_parameters"] = computedClassParameters
+
+	return res
+}
+
+func dataSourceip6addressesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	// Building parameters
+	parameters := ipamfilterparameters(d, "subnet", "subnet6_name", "ip6_name", "ip6_class_name", "ip6")
+
+	// Sending the read request(s)
+	buf, err := solidserverlist("ip6_address6_list", parameters, d.Get("limit").(int), meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	addresses := make([]interface{}, 0, len(buf))
+
+	for _, entry := range buf {
+		addresses = append(addresses, dataSourceip6addressesflatten(entry))
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Retrieved %d IPv6 address(es)\n", len(addresses)))
+
+	d.SetId(strconv.Itoa(schema.HashString("ip6_address6_list?" + parameters.Encode())))
+	d.Set("addresses", addresses)
+
+	return nil
+}
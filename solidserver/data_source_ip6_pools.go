@@ -0,0 +1,137 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/url"
+	"strconv"
+)
+
+func dataSourceip6pools() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceip6poolsRead,
+
+		Description: heredoc.Doc(`
+			IPv6 pools data-source allows to retrieve information about every IPv6 pool matching given filters,
+			including their meta-data.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space of the IPv6 pools.",
+				Optional:    true,
+			},
+			"subnet": {
+				Type:        schema.TypeString,
+				Description: "The name of the parent subnet of the IPv6 pools.",
+				Optional:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the IPv6 pools, supporting glob patterns (ex: 'dhcp-*').",
+				Optional:    true,
+			},
+			"class": {
+				Type:        schema.TypeString,
+				Description: "The class associated to the IPv6 pools.",
+				Optional:    true,
+			},
+			"class_parameters": {
+				Type:             schema.TypeMap,
+				Description:      "The class parameters values the IPv6 pools must match.",
+				ValidateDiagFunc: validation.MapKeyMatch(classParameterFilterRegexp, "Unsupported class parameter name."),
+				Optional:         true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Description:  "The maximum number of IPv6 pools to retrieve. Default is 0 (No limit).",
+				ValidateFunc: validation.IntAtLeast(0),
+				Optional:     true,
+				Default:      0,
+			},
+			"pools": {
+				Type:        schema.TypeList,
+				Description: "The IPv6 pools matching the filters.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: dataSourcecomputedschema(dataSourceip6pool().Schema),
+				},
+			},
+		},
+	}
+}
+
+// Convert an entry of rest/ip6_pool6_list into a map of attributes
+func dataSourceip6poolsflatten(entry map[string]interface{}) map[string]interface{} {
+	attributes := restobjectattributes(entry)
+	res := make(map[string]interface{})
+
+	prefixLength, _ := strconv.Atoi(attributes["subnet6_prefix"])
+
+	res["id"] = attributes["pool6_id"]
+	res["name"] = attributes["pool6_name"]
+	res["space"] = attributes["site_name"]
+	res["subnet"] = attributes["subnet6_name"]
+	res["start"] = hexip6toip6(attributes["start_ip6_addr"])
+	res["end"] = hexip6toip6(attributes["end_ip6_addr"])
+
+	if start, startExist := attributes["pool6_start_ip6_addr"]; startExist {
+		res["start"] = hexip6toip6(start)
+	}
+
+	if end, endExist := attributes["pool6_end_ip6_addr"]; endExist {
+		res["end"] = hexip6toip6(end)
+	}
+	res["prefix"] = hexip6toip6(attributes["subnet6_start_ip6_addr"]) + "/" + strconv.Itoa(prefixLength)
+	res["prefix_size"] = prefixLength
+	res["class"] = attributes["pool6_class_name"]
+
+	// Setting class_parameters
+	retrievedClassParameters, _ := url.ParseQuery(attributes["pool6_class_parameters"])
+	computedClassParameters := map[string]interface{}{}
+
+	for item, value := range retrievedClassParameters {
+		computedClassParameters[item] = value[0]
+	}
+
+	res["class_parameters"] = computedClassParameters
+
+	return res
+}
+
+func dataSourceip6poolsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	// Building parameters
+	parameters := ipamfilterparameters(d, "subnet", "subnet6_name", "pool6_name", "pool6_class_name", "pool6")
+
+	// Sending the read request(s)
+	buf, err := solidserverlist("ip6_pool6_list", parameters, d.Get("limit").(int), meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	pools := make([]interface{}, 0, len(buf))
+
+	for _, entry := range buf {
+		pools = append(pools, dataSourceip6poolsflatten(entry))
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Retrieved %d IPv6 pool(s)\n", len(pools)))
+
+	d.SetId(strconv.Itoa(schema.HashString("ip6_pool6_list?" + parameters.Encode())))
+	d.Set("pools", pools)
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package solidserver
+
+import (
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceip6subnetusage() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceipsubnetusageread(true),
+
+		Description: heredoc.Doc(`
+			IPv6 subnet usage data-source allows to retrieve the utilization of an IPv6 subnet or block.
+			For terminal subnets, the usage is computed from the IPv6 addresses, for blocks from the subnets they contain.
+			Counters are decimal strings as they may exceed 64 bits integers.
+		`),
+
+		Schema: dataSourceipsubnetusageschema(true),
+	}
+}
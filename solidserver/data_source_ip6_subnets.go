@@ -0,0 +1,149 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/url"
+	"strconv"
+)
+
+func dataSourceip6subnets() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceip6subnetsRead,
+
+		Description: heredoc.Doc(`
+			IPv6 subnets data-source allows to retrieve information about every IPv6 block or subnet matching given filters,
+			including their meta-data.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space of the IPv6 subnets.",
+				Optional:    true,
+			},
+			"block": {
+				Type:        schema.TypeString,
+				Description: "The name of the parent IPv6 block/subnet of the IPv6 subnets.",
+				Optional:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the IPv6 subnets, supporting glob patterns (ex: 'prod-*').",
+				Optional:    true,
+			},
+			"class": {
+				Type:        schema.TypeString,
+				Description: "The class associated to the IPv6 subnets.",
+				Optional:    true,
+			},
+			"class_parameters": {
+				Type:             schema.TypeMap,
+				Description:      "The class parameters values the IPv6 subnets must match.",
+				ValidateDiagFunc: validation.MapKeyMatch(classParameterFilterRegexp, "Unsupported class parameter name."),
+				Optional:         true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Description:  "The maximum number of IPv6 subnets to retrieve. Default is 0 (No limit).",
+				ValidateFunc: validation.IntAtLeast(0),
+				Optional:     true,
+				Default:      0,
+			},
+			"subnets": {
+				Type:        schema.TypeList,
+				Description: "The IPv6 subnets matching the filters.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: dataSourcecomputedschema(dataSourceip6subnet().Schema),
+				},
+			},
+		},
+	}
+}
+
+// Convert an entry of rest/ip6_block6_subnet6_list into a map of attributes
+func dataSourceip6subnetsflatten(entry map[string]interface{}) map[string]interface{} {
+	attributes := restobjectattributes(entry)
+	res := make(map[string]interface{})
+
+	address := hexip6toip6(attributes["start_ip6_addr"])
+	prefixLength, _ := strconv.Atoi(attributes["subnet6_prefix"])
+
+	res["id"] = attributes["subnet6_id"]
+	res["name"] = attributes["subnet6_name"]
+	res["space"] = attributes["site_name"]
+	res["address"] = address
+	res["prefix"] = address + "/" + strconv.Itoa(prefixLength)
+	res["prefix_size"] = prefixLength
+	res["terminal"] = attributes["is_terminal"] == "1"
+	res["class"] = attributes["subnet6_class_name"]
+
+	if vlanDomain := attributes["vlmdomain_name"]; vlanDomain != "#" {
+		res["vlan_domain"] = vlanDomain
+	}
+
+	if vlanRange := attributes["vlmrange_name"]; vlanRange != "#" {
+		res["vlan_range"] = vlanRange
+	}
+
+	if vlanID, vlanIDErr := strconv.Atoi(attributes["vlmvlan_vlan_id"]); vlanIDErr == nil {
+		res["vlan_id"] = vlanID
+	}
+
+	res["vlan_name"] = attributes["vlmvlan_name"]
+
+	// Setting class_parameters
+	retrievedClassParameters, _ := url.ParseQuery(attributes["subnet6_class_parameters"])
+	computedClassParameters := map[string]interface{}{}
+
+	if gateway, gatewayExist := retrievedClassParameters["gateway"]; gatewayExist {
+		res["gateway"] = gateway[0]
+	}
+
+	for ck := range retrievedClassParameters {
+		if ck != "gateway" {
+			computedClassParameters[ck] = retrievedClassParameters[ck][0]
+		}
+	}
+
+	res["class_parameters"] = computedClassParameters
+
+	return res
+}
+
+func dataSourceip6subnetsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	// Building parameters
+	parameters := ipamfilterparameters(d, "block", "parent_subnet6_name", "subnet6_name", "subnet6_class_name", "network6")
+
+	// Sending the read request(s)
+	buf, err := solidserverlist("ip6_block6_subnet6_list", parameters, d.Get("limit").(int), meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	subnets := make([]interface{}, 0, len(buf))
+
+	for _, entry := range buf {
+		subnets = append(subnets, dataSourceip6subnetsflatten(entry))
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Retrieved %d IPv6 subnet(s)\n", len(subnets)))
+
+	d.SetId(strconv.Itoa(schema.HashString("ip6_block6_subnet6_list?" + parameters.Encode())))
+	d.Set("subnets", subnets)
+
+	return nil
+}
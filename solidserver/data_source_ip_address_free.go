@@ -0,0 +1,138 @@
+package solidserver
+
+import (
+	"context"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceipaddressfree() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceipaddressfreeRead,
+
+		Description: heredoc.Doc(`
+			IP address free data-source allows to preview the next free IPv4 addresses of a subnet or pool
+			matching the same constraints as the IP address resource, without reserving anything.
+			The returned addresses may be allocated by someone else before being used.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space of the subnet.",
+				Required:    true,
+			},
+			"subnet": {
+				Type:        schema.TypeString,
+				Description: "The name of the subnet into which looking for free IP addresses.",
+				Required:    true,
+			},
+			"pool": {
+				Type:        schema.TypeString,
+				Description: "The name of the pool into which looking for free IP addresses.",
+				Optional:    true,
+				Default:     "",
+			},
+			"allocation_strategy": {
+				Type:         schema.TypeString,
+				Description:  "The strategy used to pick the free IP addresses, either lowest, highest or random (Default: lowest).",
+				ValidateFunc: validation.StringInSlice([]string{"lowest", "highest", "random"}, false),
+				Optional:     true,
+				Default:      "lowest",
+			},
+			"skip_first": {
+				Type:         schema.TypeInt,
+				Description:  "The number of addresses at the beginning of the subnet (or pool) never returned (Default: 0).",
+				ValidateFunc: validation.IntAtLeast(0),
+				Optional:     true,
+				Default:      0,
+			},
+			"skip_last": {
+				Type:         schema.TypeInt,
+				Description:  "The number of addresses at the end of the subnet (or pool) never returned (Default: 0).",
+				ValidateFunc: validation.IntAtLeast(0),
+				Optional:     true,
+				Default:      0,
+			},
+			"exclude": {
+				Type:        schema.TypeList,
+				Description: "The addresses, prefixes or ranges (ex: 10.0.0.10-10.0.0.20) never returned.",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateiprange(false),
+				},
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Description:  "The maximum number of free IP addresses to return (Default: 1).",
+				ValidateFunc: validation.IntBetween(1, 32),
+				Optional:     true,
+				Default:      1,
+			},
+			"addresses": {
+				Type:        schema.TypeList,
+				Description: "The next free IP addresses, ordered by preference.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceipaddressfreeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var poolID string = ""
+
+	// Gather required ID(s) from provided information
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+	if siteErr != nil {
+		// Reporting a failure
+		return diag.FromErr(siteErr)
+	}
+
+	subnetID, subnetErr := ipsubnetidbyname(siteID, d.Get("subnet").(string), true, meta)
+
+	if subnetErr != nil {
+		// Reporting a failure
+		return diag.FromErr(subnetErr)
+	}
+
+	if len(d.Get("pool").(string)) > 0 {
+		poolInfo, poolErr := ippoolinfobyname(siteID, d.Get("pool").(string), d.Get("subnet").(string), meta)
+
+		if poolErr != nil {
+			// Reporting a failure
+			return diag.FromErr(poolErr)
+		}
+
+		poolID = poolInfo["id"].(string)
+	}
+
+	policy, policyErr := ipaddresspolicyfromresource(d, false)
+
+	if policyErr != nil {
+		// Reporting a failure
+		return diag.FromErr(policyErr)
+	}
+
+	addresses, err := ipaddressfindfree(subnetID, poolID, policy, meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	if len(addresses) > d.Get("limit").(int) {
+		addresses = addresses[:d.Get("limit").(int)]
+	}
+
+	d.SetId(subnetID)
+	d.Set("addresses", addresses)
+
+	return nil
+}
@@ -0,0 +1,141 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/url"
+	"regexp"
+	"strconv"
+)
+
+func dataSourceipaddresses() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceipaddressesRead,
+
+		Description: heredoc.Doc(`
+			IP addresses data-source allows to retrieve information about every reserved IPv4 address matching given filters,
+			including their meta-data.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space of the IP addresses.",
+				Optional:    true,
+			},
+			"subnet": {
+				Type:        schema.TypeString,
+				Description: "The name of the subnet of the IP addresses.",
+				Optional:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The short name or FQDN of the IP addresses, supporting glob patterns (ex: 'web-*').",
+				Optional:    true,
+			},
+			"class": {
+				Type:        schema.TypeString,
+				Description: "The class associated to the IP addresses.",
+				Optional:    true,
+			},
+			"class_parameters": {
+				Type:             schema.TypeMap,
+				Description:      "The class parameters values the IP addresses must match.",
+				ValidateDiagFunc: validation.MapKeyMatch(classParameterFilterRegexp, "Unsupported class parameter name."),
+				Optional:         true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Description:  "The maximum number of IP addresses to retrieve. Default is 0 (No limit).",
+				ValidateFunc: validation.IntAtLeast(0),
+				Optional:     true,
+				Default:      0,
+			},
+			"addresses": {
+				Type:        schema.TypeList,
+				Description: "The IP addresses matching the filters.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: dataSourcecomputedschema(dataSourceipaddress().Schema),
+				},
+			},
+		},
+	}
+}
+
+// Convert an entry of rest/ip_address_list into a map of attributes
+func dataSourceipaddressesflatten(entry map[string]interface{}) map[string]interface{} {
+	attributes := restobjectattributes(entry)
+	res := make(map[string]interface{})
+
+	subnetSize, _ := strconv.Atoi(attributes["subnet_size"])
+	prefixLength := sizetoprefixlength(subnetSize)
+
+	res["id"] = attributes["ip_id"]
+	res["space"] = attributes["site_name"]
+	res["subnet"] = attributes["subnet_name"]
+	res["pool"] = attributes["pool_name"]
+	res["address"] = hexiptoip(attributes["ip_addr"])
+	res["name"] = attributes["name"]
+	res["device"] = attributes["hostdev_name"]
+	res["prefix"] = hexiptoip(attributes["subnet_start_ip_addr"]) + "/" + strconv.Itoa(prefixLength)
+	res["prefix_size"] = prefixLength
+	res["netmask"] = prefixlengthtohexip(prefixLength)
+	res["class"] = attributes["ip_class_name"]
+
+	if macIgnore, _ := regexp.MatchString("^EIP:", attributes["mac_addr"]); !macIgnore {
+		res["mac"] = attributes["mac_addr"]
+	} else {
+		res["mac"] = ""
+	}
+
+	// Setting class_parameters
+	retrievedClassParameters, _ := url.ParseQuery(attributes["ip_class_parameters"])
+	computedClassParameters := map[string]interface{}{}
+
+	for ck := range retrievedClassParameters {
+		if ck != "gateway" {
+			computedClassParameters[ck] = retrievedClassParameters[ck][0]
+		}
+	}
+
+	res["class_parameters"] = computedClassParameters
+
+	return res
+}
+
+func dataSourceipaddressesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	// Building parameters
+	parameters := ipamfilterparameters(d, "subnet", "subnet_name", "name", "ip_class_name", "ip")
+
+	// Sending the read request(s)
+	buf, err := solidserverlist("ip_address_list", parameters, d.Get("limit").(int), meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	addresses := make([]interface{}, 0, len(buf))
+
+	for _, entry := range buf {
+		addresses = append(addresses, dataSourceipaddressesflatten(entry))
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Retrieved %d IP address(es)\n", len(addresses)))
+
+	d.SetId(strconv.Itoa(schema.HashString("ip_address_list?" + parameters.Encode())))
+	d.Set("addresses", addresses)
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/url"
+	"strconv"
+)
+
+func dataSourceippools() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceippoolsRead,
+
+		Description: heredoc.Doc(`
+			IP pools data-source allows to retrieve information about every IPv4 pool matching given filters,
+			including their meta-data.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space of the IP pools.",
+				Optional:    true,
+			},
+			"subnet": {
+				Type:        schema.TypeString,
+				Description: "The name of the parent subnet of the IP pools.",
+				Optional:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the IP pools, supporting glob patterns (ex: 'dhcp-*').",
+				Optional:    true,
+			},
+			"class": {
+				Type:        schema.TypeString,
+				Description: "The class associated to the IP pools.",
+				Optional:    true,
+			},
+			"class_parameters": {
+				Type:             schema.TypeMap,
+				Description:      "The class parameters values the IP pools must match.",
+				ValidateDiagFunc: validation.MapKeyMatch(classParameterFilterRegexp, "Unsupported class parameter name."),
+				Optional:         true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Description:  "The maximum number of IP pools to retrieve. Default is 0 (No limit).",
+				ValidateFunc: validation.IntAtLeast(0),
+				Optional:     true,
+				Default:      0,
+			},
+			"pools": {
+				Type:        schema.TypeList,
+				Description: "The IP pools matching the filters.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: dataSourcecomputedschema(dataSourceippool().Schema),
+				},
+			},
+		},
+	}
+}
+
+// Convert an entry of rest/ip_pool_list into a map of attributes
+func dataSourceippoolsflatten(entry map[string]interface{}) map[string]interface{} {
+	attributes := restobjectattributes(entry)
+	res := make(map[string]interface{})
+
+	subnetSize, _ := strconv.Atoi(attributes["subnet_size"])
+	prefixLength := sizetoprefixlength(subnetSize)
+
+	res["id"] = attributes["pool_id"]
+	res["name"] = attributes["pool_name"]
+	res["space"] = attributes["site_name"]
+	res["subnet"] = attributes["subnet_name"]
+	res["start"] = hexiptoip(attributes["start_ip_addr"])
+	res["end"] = hexiptoip(attributes["end_ip_addr"])
+	res["size"] = attributes["pool_size"]
+	res["prefix"] = hexiptoip(attributes["subnet_start_ip_addr"]) + "/" + strconv.Itoa(prefixLength)
+	res["prefix_size"] = prefixLength
+	res["class"] = attributes["pool_class_name"]
+
+	// Setting class_parameters
+	retrievedClassParameters, _ := url.ParseQuery(attributes["pool_class_parameters"])
+	computedClassParameters := map[string]interface{}{}
+
+	for item, value := range retrievedClassParameters {
+		computedClassParameters[item] = value[0]
+	}
+
+	res["class_parameters"] = computedClassParameters
+
+	return res
+}
+
+func dataSourceippoolsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	// Building parameters
+	parameters := ipamfilterparameters(d, "subnet", "subnet_name", "pool_name", "pool_class_name", "pool")
+
+	// Sending the read request(s)
+	buf, err := solidserverlist("ip_pool_list", parameters, d.Get("limit").(int), meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	pools := make([]interface{}, 0, len(buf))
+
+	for _, entry := range buf {
+		pools = append(pools, dataSourceippoolsflatten(entry))
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Retrieved %d IP pool(s)\n", len(pools)))
+
+	d.SetId(strconv.Itoa(schema.HashString("ip_pool_list?" + parameters.Encode())))
+	d.Set("pools", pools)
+
+	return nil
+}
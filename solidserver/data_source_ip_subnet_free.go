@@ -0,0 +1,152 @@
+package solidserver
+
+import (
+	"context"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"strconv"
+)
+
+func dataSourceipsubnetfree() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceipsubnetfreeRead,
+
+		Description: heredoc.Doc(`
+			IP subnet free data-source allows to preview the next free IPv4 prefixes of one or several blocks
+			matching the same constraints as the IP subnet resource, without reserving anything.
+			The returned prefixes may be allocated by someone else before being used.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space into which looking for free IP subnets.",
+				Required:    true,
+			},
+			"block": {
+				Type:          schema.TypeString,
+				Description:   "The name of the block into which looking for free IP subnets.",
+				Optional:      true,
+				ConflictsWith: []string{"blocks", "block_query"},
+			},
+			"blocks": {
+				Type:          schema.TypeList,
+				Description:   "The names of the candidate blocks into which looking for free IP subnets, tried in order.",
+				Optional:      true,
+				ConflictsWith: []string{"block", "block_query"},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"block_query": {
+				Type:          schema.TypeString,
+				Description:   "The SQL WHERE clause selecting the candidate blocks within the space (ex: \"subnet_class_name='region-eu'\").",
+				Optional:      true,
+				ConflictsWith: []string{"block", "blocks"},
+			},
+			"prefix_size": {
+				Type:         schema.TypeInt,
+				Description:  "The expected IP subnet's prefix length (ex: 24 for a '/24').",
+				ValidateFunc: validation.IntBetween(1, 32),
+				Required:     true,
+			},
+			"allocation_strategy": {
+				Type:         schema.TypeString,
+				Description:  "The strategy used to pick the free IP subnets, supported values: first-fit, best-fit, last-fit and random (Default: first-fit).",
+				ValidateFunc: validation.StringInSlice([]string{"first-fit", "best-fit", "last-fit", "random"}, false),
+				Optional:     true,
+				Default:      "first-fit",
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Description:  "The maximum number of free IP subnets to return (Default: 1).",
+				ValidateFunc: validation.IntBetween(1, 16),
+				Optional:     true,
+				Default:      1,
+			},
+			"prefixes": {
+				Type:        schema.TypeList,
+				Description: "The next free IP prefixes, ordered by preference.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"candidates": {
+				Type:        schema.TypeList,
+				Description: "The next free IP subnets, ordered by preference.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"block": {
+							Type:        schema.TypeString,
+							Description: "The name of the block containing the free IP subnet (empty for a new block).",
+							Computed:    true,
+						},
+						"address": {
+							Type:        schema.TypeString,
+							Description: "The free IP subnet address.",
+							Computed:    true,
+						},
+						"prefix": {
+							Type:        schema.TypeString,
+							Description: "The free IP subnet prefix.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceipsubnetfreeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Gather required ID(s) from provided information
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+	if siteErr != nil {
+		// Reporting a failure
+		return diag.FromErr(siteErr)
+	}
+
+	blocks, blocksErr := ipsubnetcandidateblocks(siteID, d, false, meta)
+
+	if blocksErr != nil {
+		// Reporting a failure
+		return diag.FromErr(blocksErr)
+	}
+
+	candidates, err := ipsubnetallocationcandidates(siteID, blocks, "", d.Get("prefix_size").(int), d.Get("allocation_strategy").(string), false, meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	prefixes := []string{}
+	computedCandidates := []interface{}{}
+
+	for _, candidate := range candidates {
+		if len(prefixes) >= d.Get("limit").(int) {
+			break
+		}
+
+		blockName, _ := candidate.Block["name"].(string)
+		prefix := hexiptoip(candidate.Address) + "/" + strconv.Itoa(d.Get("prefix_size").(int))
+		prefixes = append(prefixes, prefix)
+
+		computedCandidates = append(computedCandidates, map[string]interface{}{
+			"block":   blockName,
+			"address": hexiptoip(candidate.Address),
+			"prefix":  prefix,
+		})
+	}
+
+	d.SetId(siteID + "/" + strconv.Itoa(d.Get("prefix_size").(int)))
+	d.Set("prefixes", prefixes)
+	d.Set("candidates", computedCandidates)
+
+	return nil
+}
@@ -0,0 +1,231 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"math/big"
+	"strconv"
+)
+
+func dataSourceipsubnetusage() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceipsubnetusageread(false),
+
+		Description: heredoc.Doc(`
+			IP subnet usage data-source allows to retrieve the utilization of an IPv4 subnet or block.
+			For terminal subnets, the usage is computed from the IP addresses, for blocks from the subnets they contain.
+			It is typically used within precondition or check blocks to stop an apply before running out of addresses.
+		`),
+
+		Schema: dataSourceipsubnetusageschema(false),
+	}
+}
+
+// Return the schema of the IPv4 and IPv6 subnet usage data-sources
+// IPv6 counters are strings as they may exceed 64 bits integers
+func dataSourceipsubnetusageschema(ipv6 bool) map[string]*schema.Schema {
+	counterType, counterSuffix := schema.TypeInt, ""
+
+	if ipv6 {
+		counterType, counterSuffix = schema.TypeString, " (decimal string)"
+	}
+
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Description: "The name of the IP subnet or block.",
+			Required:    true,
+		},
+		"space": {
+			Type:        schema.TypeString,
+			Description: "The space associated to the IP subnet or block.",
+			Required:    true,
+		},
+		"terminal": {
+			Type:        schema.TypeBool,
+			Description: "The terminal property of the IP subnet (false for blocks).",
+			Computed:    true,
+		},
+		"prefix": {
+			Type:        schema.TypeString,
+			Description: "The IP subnet prefix.",
+			Computed:    true,
+		},
+		"total": {
+			Type:        counterType,
+			Description: "The number of usable addresses" + counterSuffix + ".",
+			Computed:    true,
+		},
+		"used": {
+			Type:        counterType,
+			Description: "The number of used addresses" + counterSuffix + ".",
+			Computed:    true,
+		},
+		"free": {
+			Type:        counterType,
+			Description: "The number of free addresses" + counterSuffix + ".",
+			Computed:    true,
+		},
+		"percent_used": {
+			Type:        schema.TypeFloat,
+			Description: "The percentage of used addresses.",
+			Computed:    true,
+		},
+		"free_ranges": {
+			Type:        schema.TypeList,
+			Description: "The free ranges of the IP subnet or block ordered by address.",
+			Computed:    true,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"first": {
+						Type:        schema.TypeString,
+						Description: "The first address of the free range.",
+						Computed:    true,
+					},
+					"last": {
+						Type:        schema.TypeString,
+						Description: "The last address of the free range.",
+						Computed:    true,
+					},
+					"size": {
+						Type:        counterType,
+						Description: "The number of addresses of the free range" + counterSuffix + ".",
+						Computed:    true,
+					},
+				},
+			},
+		},
+		"largest_free_prefix": {
+			Type:        schema.TypeString,
+			Description: "The largest free prefix of the block (empty for terminal subnets or full blocks).",
+			Computed:    true,
+		},
+		"largest_free_prefix_size": {
+			Type:        schema.TypeInt,
+			Description: "The prefix length of the largest free prefix of the block (0 if none).",
+			Computed:    true,
+		},
+	}
+}
+
+func dataSourceipsubnetusageread(ipv6 bool) schema.ReadContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		var subnetInfo map[string]interface{} = nil
+		var subnetErr error = nil
+
+		one := big.NewInt(1)
+
+		siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+		if siteErr != nil {
+			// Reporting a failure
+			return diag.FromErr(siteErr)
+		}
+
+		// Looking for a terminal subnet first, then for a block
+		for _, terminal := range []bool{true, false} {
+			if ipv6 {
+				subnetInfo, subnetErr = ip6subnetinfobyname(siteID, d.Get("name").(string), terminal, meta)
+			} else {
+				subnetInfo, subnetErr = ipsubnetinfobyname(siteID, d.Get("name").(string), terminal, meta)
+			}
+
+			if subnetErr == nil && subnetInfo != nil {
+				break
+			}
+		}
+
+		if subnetErr != nil || subnetInfo == nil {
+			// Log the error
+			tflog.Debug(ctx, fmt.Sprintf("Unable to find IP subnet: %s\n", d.Get("name").(string)))
+
+			// Reporting a failure
+			return diag.Errorf("Unable to find IP subnet: %s\n", d.Get("name").(string))
+		}
+
+		startHex, _ := subnetInfo["start_hex_addr"].(string)
+		endHex, _ := subnetInfo["end_hex_addr"].(string)
+		start, end := hexiptobig(startHex), hexiptobig(endHex)
+
+		if start == nil || end == nil {
+			return diag.Errorf("Unable to compute the boundaries of IP subnet: %s\n", d.Get("name").(string))
+		}
+
+		terminal := subnetInfo["terminal"] == "1"
+		total := new(big.Int).Add(new(big.Int).Sub(end, start), one)
+		size := new(big.Int).Set(total)
+		free := []ipRange{}
+
+		if terminal {
+			var freeErr error = nil
+
+			free, freeErr = ipaddressfreeranges(subnetInfo["id"].(string), "", ipAddressPolicy{}, ipv6, meta)
+
+			if freeErr != nil {
+				// Reporting a failure
+				return diag.FromErr(freeErr)
+			}
+
+			// The network and broadcast addresses of IPv4 subnets are not usable
+			if !ipv6 && total.Cmp(big.NewInt(2)) > 0 {
+				total.Sub(total, big.NewInt(2))
+			}
+		} else {
+			holes, holesErr := ipsubnetholes(subnetInfo, ipv6, meta)
+
+			if holesErr != nil {
+				// Reporting a failure
+				return diag.FromErr(holesErr)
+			}
+
+			for _, hole := range holes {
+				free = append(free, hole.Range)
+			}
+		}
+
+		freeCount := big.NewInt(0)
+		freeRanges := []interface{}{}
+
+		for _, r := range free {
+			rangeSize := new(big.Int).Add(new(big.Int).Sub(r.End, r.Start), one)
+			freeCount.Add(freeCount, rangeSize)
+
+			freeRanges = append(freeRanges, map[string]interface{}{
+				"first": ipfrombig(r.Start, ipv6),
+				"last":  ipfrombig(r.End, ipv6),
+				"size":  ipcounter(rangeSize, ipv6),
+			})
+		}
+
+		used := new(big.Int).Sub(total, freeCount)
+		percent := 0.0
+
+		if total.Sign() > 0 {
+			percent, _ = new(big.Float).Quo(new(big.Float).Mul(new(big.Float).SetInt(used), big.NewFloat(100)), new(big.Float).SetInt(total)).Float64()
+		}
+
+		d.SetId(subnetInfo["id"].(string))
+		d.Set("terminal", terminal)
+		d.Set("prefix", ipfrombig(start, ipv6)+"/"+strconv.Itoa(bitsprefixlength(size, ipv6)))
+		d.Set("total", ipcounter(total, ipv6))
+		d.Set("used", ipcounter(used, ipv6))
+		d.Set("free", ipcounter(freeCount, ipv6))
+		d.Set("percent_used", percent)
+		d.Set("free_ranges", freeRanges)
+		d.Set("largest_free_prefix", "")
+		d.Set("largest_free_prefix_size", 0)
+
+		if !terminal {
+			if address, length := iplargestfreeprefix(free, ipv6); address != nil {
+				d.Set("largest_free_prefix", ipfrombig(address, ipv6)+"/"+strconv.Itoa(length))
+				d.Set("largest_free_prefix_size", length)
+			}
+		}
+
+		return nil
+	}
+}
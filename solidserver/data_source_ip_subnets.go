@@ -0,0 +1,151 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/url"
+	"strconv"
+)
+
+func dataSourceipsubnets() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceipsubnetsRead,
+
+		Description: heredoc.Doc(`
+			IP subnets data-source allows to retrieve information about every IPv4 block or subnet matching given filters,
+			including their meta-data.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space of the IP subnets.",
+				Optional:    true,
+			},
+			"block": {
+				Type:        schema.TypeString,
+				Description: "The name of the parent IP block/subnet of the IP subnets.",
+				Optional:    true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the IP subnets, supporting glob patterns (ex: 'prod-*').",
+				Optional:    true,
+			},
+			"class": {
+				Type:        schema.TypeString,
+				Description: "The class associated to the IP subnets.",
+				Optional:    true,
+			},
+			"class_parameters": {
+				Type:             schema.TypeMap,
+				Description:      "The class parameters values the IP subnets must match.",
+				ValidateDiagFunc: validation.MapKeyMatch(classParameterFilterRegexp, "Unsupported class parameter name."),
+				Optional:         true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Description:  "The maximum number of IP subnets to retrieve. Default is 0 (No limit).",
+				ValidateFunc: validation.IntAtLeast(0),
+				Optional:     true,
+				Default:      0,
+			},
+			"subnets": {
+				Type:        schema.TypeList,
+				Description: "The IP subnets matching the filters.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: dataSourcecomputedschema(dataSourceipsubnet().Schema),
+				},
+			},
+		},
+	}
+}
+
+// Convert an entry of rest/ip_block_subnet_list into a map of attributes
+func dataSourceipsubnetsflatten(entry map[string]interface{}) map[string]interface{} {
+	attributes := restobjectattributes(entry)
+	res := make(map[string]interface{})
+
+	address := hexiptoip(attributes["start_ip_addr"])
+	subnetSize, _ := strconv.Atoi(attributes["subnet_size"])
+	prefixLength := sizetoprefixlength(subnetSize)
+
+	res["id"] = attributes["subnet_id"]
+	res["name"] = attributes["subnet_name"]
+	res["space"] = attributes["site_name"]
+	res["address"] = address
+	res["prefix"] = address + "/" + strconv.Itoa(prefixLength)
+	res["prefix_size"] = prefixLength
+	res["netmask"] = prefixlengthtohexip(prefixLength)
+	res["terminal"] = attributes["is_terminal"] == "1"
+	res["class"] = attributes["subnet_class_name"]
+
+	if vlanDomain := attributes["vlmdomain_name"]; vlanDomain != "#" {
+		res["vlan_domain"] = vlanDomain
+	}
+
+	if vlanRange := attributes["vlmrange_name"]; vlanRange != "#" {
+		res["vlan_range"] = vlanRange
+	}
+
+	if vlanID, vlanIDErr := strconv.Atoi(attributes["vlmvlan_vlan_id"]); vlanIDErr == nil {
+		res["vlan_id"] = vlanID
+	}
+
+	res["vlan_name"] = attributes["vlmvlan_name"]
+
+	// Setting class_parameters
+	retrievedClassParameters, _ := url.ParseQuery(attributes["subnet_class_parameters"])
+	computedClassParameters := map[string]interface{}{}
+
+	if gateway, gatewayExist := retrievedClassParameters["gateway"]; gatewayExist {
+		res["gateway"] = gateway[0]
+	}
+
+	for ck := range retrievedClassParameters {
+		if ck != "gateway" {
+			computedClassParameters[ck] = retrievedClassParameters[ck][0]
+		}
+	}
+
+	res["class_parameters"] = computedClassParameters
+
+	return res
+}
+
+func dataSourceipsubnetsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	// Building parameters
+	parameters := ipamfilterparameters(d, "block", "parent_subnet_name", "subnet_name", "subnet_class_name", "network")
+
+	// Sending the read request(s)
+	buf, err := solidserverlist("ip_block_subnet_list", parameters, d.Get("limit").(int), meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	subnets := make([]interface{}, 0, len(buf))
+
+	for _, entry := range buf {
+		subnets = append(subnets, dataSourceipsubnetsflatten(entry))
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Retrieved %d IP subnet(s)\n", len(subnets)))
+
+	d.SetId(strconv.Itoa(schema.HashString("ip_block_subnet_list?" + parameters.Encode())))
+	d.Set("subnets", subnets)
+
+	return nil
+}
@@ -0,0 +1,134 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/url"
+	"strconv"
+)
+
+func dataSourcerestquery() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcerestqueryRead,
+
+		Description: heredoc.Doc(`
+			REST query data-source allows to retrieve every object matching given criterias from any SOLIDserver list service.
+			Each row is returned as a map of strings, pagination being handled by the provider.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"service": {
+				Type:         schema.TypeString,
+				Description:  "The name of the list service to query (ex: ip_address_list).",
+				ValidateFunc: validation.StringIsNotEmpty,
+				Required:     true,
+			},
+			"query": {
+				Type:        schema.TypeString,
+				Description: "The query (WHERE clause) used to filter the objects.",
+				Optional:    true,
+				Default:     "",
+			},
+			"tags": {
+				Type:        schema.TypeString,
+				Description: "The tags to be used to filter the objects in the query.",
+				Optional:    true,
+				Default:     "",
+			},
+			"orderby": {
+				Type:        schema.TypeString,
+				Description: "The ORDERBY clause used to sort the objects.",
+				Optional:    true,
+				Default:     "",
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Description:  "The maximum number of objects to retrieve. Default is 0 (No limit).",
+				ValidateFunc: validation.IntAtLeast(0),
+				Optional:     true,
+				Default:      0,
+			},
+			"fields": {
+				Type:        schema.TypeList,
+				Description: "The fields to return for each object. Default is all the fields returned by the service.",
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"rows": {
+				Type:        schema.TypeList,
+				Description: "The objects matching the query, as maps of strings.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcerestqueryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	d.SetId("")
+
+	// Building parameters
+	parameters := url.Values{}
+
+	if d.Get("query").(string) != "" {
+		parameters.Add("WHERE", d.Get("query").(string))
+	}
+
+	if d.Get("tags").(string) != "" {
+		parameters.Add("TAGS", d.Get("tags").(string))
+	}
+
+	if d.Get("orderby").(string) != "" {
+		parameters.Add("ORDERBY", d.Get("orderby").(string))
+	}
+
+	// Sending the read request(s)
+	buf, err := solidserverlist(d.Get("service").(string), parameters, d.Get("limit").(int), meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	fields := toStringArray(d.Get("fields").([]interface{}))
+	rows := make([]interface{}, 0, len(buf))
+
+	for _, entry := range buf {
+		attributes := restobjectattributes(entry)
+
+		if len(fields) > 0 {
+			projection := map[string]string{}
+
+			for _, field := range fields {
+				if value, valueExist := attributes[field]; valueExist {
+					projection[field] = value
+				} else {
+					projection[field] = ""
+				}
+			}
+
+			attributes = projection
+		}
+
+		rows = append(rows, attributes)
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Retrieved %d object(s) using service: %s\n", len(rows), d.Get("service").(string)))
+
+	d.SetId(strconv.Itoa(schema.HashString(d.Get("service").(string) + "?" + parameters.Encode())))
+	d.Set("rows", rows)
+
+	return nil
+}
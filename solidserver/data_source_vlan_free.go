@@ -0,0 +1,77 @@
+package solidserver
+
+import (
+	"context"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"strconv"
+)
+
+func dataSourcevlanfree() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourcevlanfreeRead,
+
+		Description: heredoc.Doc(`
+			VLAN free data-source allows to preview the next free VLAN IDs of a VLAN domain, without reserving anything.
+			The returned VLAN IDs may be allocated by someone else before being used.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"vlan_domain": {
+				Type:        schema.TypeString,
+				Description: "The name of the VLAN domain into which looking for free VLAN IDs.",
+				Required:    true,
+			},
+			"limit": {
+				Type:         schema.TypeInt,
+				Description:  "The maximum number of free VLAN IDs to return (Default: 1).",
+				ValidateFunc: validation.IntBetween(1, 16),
+				Optional:     true,
+				Default:      1,
+			},
+			"vlan_ids": {
+				Type:        schema.TypeList,
+				Description: "The next free VLAN IDs.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+		},
+	}
+}
+
+func dataSourcevlanfreeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vlmdomainID, vlmdomainErr := vlandomainidbyname(d.Get("vlan_domain").(string), meta)
+
+	if vlmdomainErr != nil {
+		// Reporting a failure
+		return diag.FromErr(vlmdomainErr)
+	}
+
+	vlanIDs, err := vlanidfindfree(d.Get("vlan_domain").(string), meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	res := []int{}
+
+	for _, vlanID := range vlanIDs {
+		if len(res) >= d.Get("limit").(int) {
+			break
+		}
+
+		if vnid, convErr := strconv.Atoi(vlanID); convErr == nil {
+			res = append(res, vnid)
+		}
+	}
+
+	d.SetId(vlmdomainID)
+	d.Set("vlan_ids", res)
+
+	return nil
+}
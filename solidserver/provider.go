@@ -84,12 +84,18 @@ func Provider() *schema.Provider {
 			"solidserver_ip_space":         dataSourceipspace(),
 			"solidserver_ip_subnet":        dataSourceipsubnet(),
 			"solidserver_ip_subnet_query":  dataSourceipsubnetquery(),
+			"solidserver_ip_subnets":       dataSourceipsubnets(),
 			"solidserver_ip6_subnet":       dataSourceip6subnet(),
 			"solidserver_ip6_subnet_query": dataSourceip6subnetquery(),
+			"solidserver_ip6_subnets":      dataSourceip6subnets(),
 			"solidserver_ip_pool":          dataSourceippool(),
+			"solidserver_ip_pools":         dataSourceippools(),
 			"solidserver_ip6_pool":         dataSourceip6pool(),
+			"solidserver_ip6_pools":        dataSourceip6pools(),
 			"solidserver_ip_address":       dataSourceipaddress(),
+			"solidserver_ip_addresses":     dataSourceipaddresses(),
 			"solidserver_ip6_address":      dataSourceip6address(),
+			"solidserver_ip6_addresses":    dataSourceip6addresses(),
 			"solidserver_ip_ptr":           dataSourceipptr(),
 			"solidserver_ip6_ptr":          dataSourceip6ptr(),
 			"solidserver_dns_smart":        dataSourcednssmart(),
@@ -102,37 +108,54 @@ func Provider() *schema.Provider {
 			"solidserver_usergroup":        dataSourceusergroup(),
 			"solidserver_cdb":              dataSourcecdb(),
 			"solidserver_cdb_data":         dataSourcecdbdata(),
+			"solidserver_rest_query":       dataSourcerestquery(),
+			"solidserver_ip_subnet_usage":  dataSourceipsubnetusage(),
+			"solidserver_ip6_subnet_usage": dataSourceip6subnetusage(),
+			"solidserver_ip_address_free":  dataSourceipaddressfree(),
+			"solidserver_ip_subnet_free":   dataSourceipsubnetfree(),
+			"solidserver_vlan_free":        dataSourcevlanfree(),
+			"solidserver_address_lookup":   dataSourceaddresslookup(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"solidserver_ip_space":         resourceipspace(),
-			"solidserver_ip_subnet":        resourceipsubnet(),
-			"solidserver_ip6_subnet":       resourceip6subnet(),
-			"solidserver_ip_pool":          resourceippool(),
-			"solidserver_ip6_pool":         resourceip6pool(),
-			"solidserver_ip_address":       resourceipaddress(),
-			"solidserver_ip6_address":      resourceip6address(),
-			"solidserver_ip_alias":         resourceipalias(),
-			"solidserver_ip6_alias":        resourceip6alias(),
-			"solidserver_ip_mac":           resourceipmac(),
-			"solidserver_ip6_mac":          resourceip6mac(),
-			"solidserver_device":           resourcedevice(),
-			"solidserver_vlan_domain":      resourcevlandomain(),
-			"solidserver_vlan_range":       resourcevlanrange(),
-			"solidserver_vlan":             resourcevlan(),
-			"solidserver_dns_smart":        resourcednssmart(),
-			"solidserver_dns_server":       resourcednsserver(),
-			"solidserver_dns_view":         resourcednsview(),
-			"solidserver_dns_zone":         resourcednszone(),
-			"solidserver_dns_forward_zone": resourcednsforwardzone(),
-			"solidserver_dns_rr":           resourcednsrr(),
-			"solidserver_app_application":  resourceapplication(),
-			"solidserver_app_pool":         resourceapplicationpool(),
-			"solidserver_app_node":         resourceapplicationnode(),
-			"solidserver_user":             resourceuser(),
-			"solidserver_usergroup":        resourceusergroup(),
-			"solidserver_cdb":              resourcecdb(),
-			"solidserver_cdb_data":         resourcecdbdata(),
+			"solidserver_ip_space":             resourceipspace(),
+			"solidserver_ip_subnet":            resourceipsubnet(),
+			"solidserver_ip6_subnet":           resourceip6subnet(),
+			"solidserver_subnet":               resourcesubnet(),
+			"solidserver_ip_subnet_split":      resourceipsubnetsplit(),
+			"solidserver_ip_subnet_merge":      resourceipsubnetmerge(),
+			"solidserver_ip_pool":              resourceippool(),
+			"solidserver_ip6_pool":             resourceip6pool(),
+			"solidserver_ip6_delegation_pool":  resourceip6delegationpool(),
+			"solidserver_ip6_delegated_prefix": resourceip6delegatedprefix(),
+			"solidserver_ip_address":           resourceipaddress(),
+			"solidserver_ip6_address":          resourceip6address(),
+			"solidserver_address":              resourceaddress(),
+			"solidserver_ip_address_range":     resourceipaddressrange(),
+			"solidserver_ip_alias":             resourceipalias(),
+			"solidserver_ip6_alias":            resourceip6alias(),
+			"solidserver_ip_mac":               resourceipmac(),
+			"solidserver_ip6_mac":              resourceip6mac(),
+			"solidserver_device":               resourcedevice(),
+			"solidserver_vlan_domain":          resourcevlandomain(),
+			"solidserver_vlan_range":           resourcevlanrange(),
+			"solidserver_vlan":                 resourcevlan(),
+			"solidserver_dns_smart":            resourcednssmart(),
+			"solidserver_dns_server":           resourcednsserver(),
+			"solidserver_dns_view":             resourcednsview(),
+			"solidserver_dns_zone":             resourcednszone(),
+			"solidserver_dns_forward_zone":     resourcednsforwardzone(),
+			"solidserver_dns_rr":               resourcednsrr(),
+			"solidserver_dns_rrset":            resourcednsrrset(),
+			"solidserver_dns_zone_records":     resourcednszonerecords(),
+			"solidserver_app_application":      resourceapplication(),
+			"solidserver_app_pool":             resourceapplicationpool(),
+			"solidserver_app_node":             resourceapplicationnode(),
+			"solidserver_user":                 resourceuser(),
+			"solidserver_usergroup":            resourceusergroup(),
+			"solidserver_cdb":                  resourcecdb(),
+			"solidserver_cdb_data":             resourcecdbdata(),
+			"solidserver_rest_object":          resourcerestobject(),
 		},
 		ConfigureContextFunc: ProviderConfigure,
 	}
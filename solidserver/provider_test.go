@@ -7,11 +7,10 @@ import (
 	"testing"
 
 	"github.com/hashicorp/go-cty/cty"
-	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
-var testProviders map[string]terraform.ResourceProvider
+var testProviders map[string]*schema.Provider
 var testProvider *schema.Provider
 
 func testAccPreCheck(t *testing.T) {
@@ -38,12 +37,18 @@ func init() {
 		fmt.Println("[WARN] use SOLIDServer_SSLVERIFY=false to bypass certificate validation")
 	}
 
-	testProvider = Provider().(*schema.Provider)
-	testProviders = map[string]terraform.ResourceProvider{
+	testProvider = Provider()
+	testProviders = map[string]*schema.Provider{
 		"solidserver": testProvider,
 	}
 }
 
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
 func TestValidateProxyURLValue(t *testing.T) {
 
 	type testCase struct {
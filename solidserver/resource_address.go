@@ -0,0 +1,113 @@
+package solidserver
+
+import (
+	"context"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceaddress() *schema.Resource {
+	resource := &schema.Resource{
+		CreateContext: resourceaddressCreate,
+		ReadContext:   resourceaddressRead,
+		UpdateContext: resourceaddressUpdate,
+		DeleteContext: resourceaddressDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceaddressImportState,
+		},
+		CustomizeDiff: customdiff.All(
+			resourceipfamilydiff,
+			resourceipaddressmovediff,
+		),
+
+		Description: heredoc.Doc(`
+			Address resource allows to create and manage reserved IPv4 or IPv6 addresses from a single resource type.
+			The family is picked from the requested address or the subnet, then the IPv4 or IPv6 endpoints are used accordingly.
+			Imported addresses are identified by <family>:<oid> (ex: ipv6:42), the family defaulting to ipv4.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"family": ipfamilyschema("IP address"),
+		},
+	}
+
+	// Sharing the attributes of the IPv4 and IPv6 address resources
+	for k, v := range resourceipaddress().Schema {
+		resource.Schema[k] = v
+	}
+
+	resource.Schema["exclude"].Elem = &schema.Schema{
+		Type:         schema.TypeString,
+		ValidateFunc: validateiprangeanyfamily(),
+	}
+
+	return resource
+}
+
+// Return the IPv4 or IPv6 address resource implementing a family
+func resourceaddressfamily(family string) *schema.Resource {
+	if family == "ipv6" {
+		return resourceip6address()
+	}
+
+	return resourceipaddress()
+}
+
+func resourceaddressCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	family := d.Get("family").(string)
+
+	// Guessing the family from the requested address or the subnet
+	if family == "" {
+		family = ipfamilyofaddress(d.Get("request_ip").(string))
+	}
+
+	if family == "" {
+		siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+		if siteErr != nil {
+			// Reporting a failure
+			return diag.FromErr(siteErr)
+		}
+
+		var familyErr error = nil
+
+		family, familyErr = ipfamilyofsubnet(siteID, d.Get("subnet").(string), true, meta)
+
+		if familyErr != nil {
+			// Reporting a failure
+			return diag.Errorf("Unable to create IP address: %s, unable to find requested network\n", d.Get("name").(string))
+		}
+	}
+
+	d.Set("family", family)
+
+	return resourceaddressfamily(family).CreateContext(ctx, d, meta)
+}
+
+func resourceaddressUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceaddressfamily(d.Get("family").(string)).UpdateContext(ctx, d, meta)
+}
+
+func resourceaddressDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceaddressfamily(d.Get("family").(string)).DeleteContext(ctx, d, meta)
+}
+
+func resourceaddressRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceaddressfamily(d.Get("family").(string)).ReadContext(ctx, d, meta)
+}
+
+func resourceaddressImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	family, oid, err := ipfamilyimportid(d.Id())
+
+	if err != nil {
+		// Reporting a failure
+		return nil, err
+	}
+
+	d.SetId(oid)
+	d.Set("family", family)
+
+	return resourceaddressfamily(family).Importer.StateContext(ctx, d, meta)
+}
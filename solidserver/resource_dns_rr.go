@@ -14,17 +14,20 @@ import (
 )
 
 func resourcednsrr() *schema.Resource {
-	return &schema.Resource{
+	resource := &schema.Resource{
 		CreateContext: resourcednsrrCreate,
 		ReadContext:   resourcednsrrRead,
 		UpdateContext: resourcednsrrUpdate,
 		DeleteContext: resourcednsrrDelete,
+		CustomizeDiff: resourcednsrrdiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourcednsrrImportState,
 		},
 
 		Description: heredoc.Doc(`
-			DNS RR resource allows to create and manage DNS resource records of type A, AAAA, PTR, CNAME, DNAME, NS.
+			DNS RR resource allows to create and manage DNS resource records of type A, AAAA, PTR, CNAME, DNAME, TXT, NS,
+			MX, SRV, CAA, NAPTR, SSHFP, TLSA and HINFO. The values of the multi-value types are set through their typed
+			attribute block (ex: mx), mapped to the value1 to value7 of the RR. Long TXT values are split into 255 characters strings.
 		`),
 
 		Schema: map[string]*schema.Schema{
@@ -56,17 +59,18 @@ func resourcednsrr() *schema.Resource {
 			},
 			"type": {
 				Type:         schema.TypeString,
-				Description:  "The type of the RR to create (Supported: A, AAAA, PTR, CNAME, DNAME and NS).",
+				Description:  "The type of the RR to create (Supported: A, AAAA, PTR, CNAME, DNAME, TXT, NS, MX, SRV, CAA, NAPTR, SSHFP, TLSA and HINFO).",
 				ValidateFunc: resourcednsrrvalidatetype,
 				Required:     true,
 				ForceNew:     true,
 			},
 			"value": {
 				Type:             schema.TypeString,
-				Description:      "The value od the RR to create.",
+				Description:      "The value of the RR to create, for the single-value types (A, AAAA, PTR, CNAME, DNAME, TXT and NS).",
 				Computed:         false,
-				Required:         true,
+				Optional:         true,
 				ForceNew:         true,
+				ExactlyOneOf:     dnsrrvalueattributes(),
 				DiffSuppressFunc: resourcediffsuppressIPv6Format,
 			},
 			"ttl": {
@@ -93,27 +97,22 @@ func resourcednsrr() *schema.Resource {
 			},
 		},
 	}
+
+	for k, v := range dnsrrtypedschema() {
+		resource.Schema[k] = v
+	}
+
+	return resource
 }
 
 func resourcednsrrvalidatetype(v interface{}, _ string) ([]string, []error) {
-	switch strings.ToUpper(v.(string)) {
-	case "A":
-		return nil, nil
-	case "AAAA":
-		return nil, nil
-	case "PTR":
-		return nil, nil
-	case "CNAME":
-		return nil, nil
-	case "DNAME":
-		return nil, nil
-	case "TXT":
-		return nil, nil
-	case "NS":
-		return nil, nil
-	default:
-		return nil, []error{fmt.Errorf("Unsupported RR type.")}
+	for _, rrType := range dnsrrtypes() {
+		if strings.ToUpper(v.(string)) == rrType {
+			return nil, nil
+		}
 	}
+
+	return nil, []error{fmt.Errorf("Unsupported RR type.")}
 }
 
 func resourcednsrrCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -125,9 +124,20 @@ func resourcednsrrCreate(ctx context.Context, d *schema.ResourceData, meta inter
 	parameters.Add("dns_name", d.Get("dnsserver").(string))
 	parameters.Add("rr_name", d.Get("name").(string))
 	parameters.Add("rr_type", strings.ToUpper(d.Get("type").(string)))
-	parameters.Add("value1", d.Get("value").(string))
 	parameters.Add("rr_ttl", strconv.Itoa(d.Get("ttl").(int)))
 
+	// Add the value(s) of the RR
+	values, valuesErr := dnsrrvalues(d)
+
+	if valuesErr != nil {
+		// Reporting a failure
+		return diag.FromErr(valuesErr)
+	}
+
+	for i, value := range values {
+		parameters.Add("value"+strconv.Itoa(i+1), value)
+	}
+
 	// Add dnsview parameter if it is supplied
 	// If no view is specified and server has some configured, trigger an error
 	if len(d.Get("dnsview").(string)) > 0 {
@@ -144,7 +154,7 @@ func resourcednsrrCreate(ctx context.Context, d *schema.ResourceData, meta inter
 	}
 
 	if s.Version < 800 {
-		tflog.Info(ctx, fmt.Sprintf("RR class parameters are not supported in SOLIDserver Version (%i)", s.Version))
+		tflog.Info(ctx, fmt.Sprintf("RR class parameters are not supported in SOLIDserver Version (%d)", s.Version))
 	} else {
 		parameters.Add("rr_class_name", d.Get("class").(string))
 		parameters.Add("rr_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
@@ -190,9 +200,20 @@ func resourcednsrrUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 	parameters.Add("dns_name", d.Get("dnsserver").(string))
 	parameters.Add("rr_name", d.Get("name").(string))
 	parameters.Add("rr_type", strings.ToUpper(d.Get("type").(string)))
-	parameters.Add("value1", d.Get("value").(string))
 	parameters.Add("rr_ttl", strconv.Itoa(d.Get("ttl").(int)))
 
+	// Add the value(s) of the RR
+	values, valuesErr := dnsrrvalues(d)
+
+	if valuesErr != nil {
+		// Reporting a failure
+		return diag.FromErr(valuesErr)
+	}
+
+	for i, value := range values {
+		parameters.Add("value"+strconv.Itoa(i+1), value)
+	}
+
 	// Add dnsview parameter if it is supplied
 	if len(d.Get("dnsview").(string)) != 0 {
 		parameters.Add("dnsview_name", d.Get("dnsview").(string))
@@ -204,7 +225,7 @@ func resourcednsrrUpdate(ctx context.Context, d *schema.ResourceData, meta inter
 	}
 
 	if s.Version < 800 {
-		tflog.Info(ctx, fmt.Sprintf("RR class parameters are not supported in SOLIDserver Version (%i)", s.Version))
+		tflog.Info(ctx, fmt.Sprintf("RR class parameters are not supported in SOLIDserver Version (%d)", s.Version))
 	} else {
 		parameters.Add("rr_class_name", d.Get("class").(string))
 		parameters.Add("rr_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
@@ -295,14 +316,15 @@ func resourcednsrrRead(ctx context.Context, d *schema.ResourceData, meta interfa
 	// We do not rely on the ID that may change due to DNS behavior
 	whereClause := "dns_name='" + d.Get("dnsserver").(string) + "' AND rr_full_name='" + d.Get("name").(string) + "' AND rr_type='" + strings.ToUpper(d.Get("type").(string))
 
-	if strings.ToUpper(d.Get("type").(string)) == "AAAA" {
-		value := shortip6tolongip6(d.Get("value").(string))
-		tflog.Debug(ctx, fmt.Sprintf("Using Expanded IPv6 format: %s\n", value))
-		whereClause += "' AND value1='" + value + "' "
-	} else {
-		whereClause += "' AND value1='" + d.Get("value").(string) + "' "
+	values, valuesErr := dnsrrvalues(d)
+
+	if valuesErr != nil {
+		// Reporting a failure
+		return diag.FromErr(valuesErr)
 	}
 
+	whereClause += "' AND " + dnsrrvalueswhere(d.Get("type").(string), values) + " "
+
 	// Handle dnsview parameter
 	if len(d.Get("dnsview").(string)) != 0 {
 		whereClause += "AND dnsview_name='" + d.Get("dnsview").(string) + "' "
@@ -334,11 +356,7 @@ func resourcednsrrRead(ctx context.Context, d *schema.ResourceData, meta interfa
 			d.Set("name", buf[0]["rr_full_name"].(string))
 			d.Set("type", buf[0]["rr_type"].(string))
 
-			if strings.ToUpper(buf[0]["rr_type"].(string)) == "AAAA" {
-				d.Set("value", longip6toshortip6(buf[0]["value1"].(string)))
-			} else {
-				d.Set("value", buf[0]["value1"].(string))
-			}
+			dnsrrsetvalues(d, buf[0]["rr_type"].(string), dnsrrentryvalues(buf[0]))
 
 			d.Set("ttl", ttl)
 
@@ -347,7 +365,7 @@ func resourcednsrrRead(ctx context.Context, d *schema.ResourceData, meta interfa
 			}
 
 			if s.Version < 800 {
-				tflog.Info(ctx, fmt.Sprintf("RR class parameters are not supported in SOLIDserver Version (%i)", s.Version))
+				tflog.Info(ctx, fmt.Sprintf("RR class parameters are not supported in SOLIDserver Version (%d)", s.Version))
 			} else {
 				d.Set("class", buf[0]["rr_class_name"].(string))
 
@@ -411,11 +429,7 @@ func resourcednsrrImportState(ctx context.Context, d *schema.ResourceData, meta
 			d.Set("name", buf[0]["rr_full_name"].(string))
 			d.Set("type", buf[0]["rr_type"].(string))
 
-			if strings.ToUpper(buf[0]["rr_type"].(string)) == "AAAA" {
-				d.Set("value", longip6toshortip6(buf[0]["value1"].(string)))
-			} else {
-				d.Set("value", buf[0]["value1"].(string))
-			}
+			dnsrrsetvalues(d, buf[0]["rr_type"].(string), dnsrrentryvalues(buf[0]))
 
 			d.Set("ttl", ttl)
 
@@ -428,7 +442,7 @@ func resourcednsrrImportState(ctx context.Context, d *schema.ResourceData, meta
 			}
 
 			if s.Version < 800 {
-				tflog.Info(ctx, fmt.Sprintf("RR class parameters are not supported in SOLIDserver Version (%i)", s.Version))
+				tflog.Info(ctx, fmt.Sprintf("RR class parameters are not supported in SOLIDserver Version (%d)", s.Version))
 			} else {
 				d.Set("class", buf[0]["rr_class_name"].(string))
 
@@ -0,0 +1,318 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+func resourcednsrrset() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcednsrrsetCreate,
+		ReadContext:   resourcednsrrsetRead,
+		UpdateContext: resourcednsrrsetUpdate,
+		DeleteContext: resourcednsrrsetDelete,
+		CustomizeDiff: resourcednsrrsetdiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcednsrrsetImportState,
+		},
+
+		Description: heredoc.Doc(`
+			DNS RRset resource allows to manage authoritatively all the DNS resource records sharing a name and a type
+			(ex: round-robin A records, MX or NS sets). Records are added and removed individually, while the records
+			of the set created outside of Terraform are reported as drift and removed on the next apply.
+			The import ID is formatted as <dnsserver>/<dnsview>/<dnszone>/<name>/<type>.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"dnsserver": {
+				Type:        schema.TypeString,
+				Description: "The managed SMART DNS server name, or DNS server name hosting the RRset's zone.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"dnsview": {
+				Type:        schema.TypeString,
+				Description: "The View name of the RRset.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "",
+			},
+			"dnszone": {
+				Type:        schema.TypeString,
+				Description: "The Zone name of the RRset.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The Fully Qualified Domain Name of the RRset.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Description:  "The type of the RRset (Supported: A, AAAA, PTR, CNAME, DNAME, TXT, NS, MX, SRV, CAA, NAPTR, SSHFP, TLSA and HINFO).",
+				ValidateFunc: resourcednsrrvalidatetype,
+				Required:     true,
+				ForceNew:     true,
+			},
+			"values": {
+				Type:        schema.TypeSet,
+				Description: "The values of the records of the RRset, in presentation format for the multi-value types (ex: \"10 mail.example.com\" for a MX).",
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"ttl": {
+				Type:        schema.TypeInt,
+				Description: "The DNS Time To Live of the records of the RRset.",
+				Optional:    true,
+				Default:     3600,
+			},
+		},
+	}
+}
+
+// Check at plan time the values of a RRset against its type
+func resourcednsrrsetdiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("type") || !d.NewValueKnown("values") {
+		return nil
+	}
+
+	for _, value := range d.Get("values").(*schema.Set).List() {
+		if _, err := dnsrrvaluesfromtext(d.Get("type").(string), value.(string)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Return the records of a RRset
+func dnsrrsetlist(d *schema.ResourceData, meta interface{}) ([]map[string]interface{}, error) {
+	// Building parameters
+	parameters := url.Values{}
+	whereClause := "dns_name='" + wherequote(d.Get("dnsserver").(string)) + "' AND rr_full_name='" + wherequote(d.Get("name").(string)) + "' AND rr_type='" + wherequote(strings.ToUpper(d.Get("type").(string))) + "' "
+
+	// Handle dnsview parameter
+	if len(d.Get("dnsview").(string)) != 0 {
+		whereClause += "AND dnsview_name='" + wherequote(d.Get("dnsview").(string)) + "' "
+	} else {
+		whereClause += "AND dnsview_name='#' "
+	}
+
+	// Add dnszone parameter if it is supplied
+	if len(d.Get("dnszone").(string)) != 0 {
+		whereClause += "AND dnszone_name='" + wherequote(d.Get("dnszone").(string)) + "' "
+	}
+
+	parameters.Add("WHERE", whereClause)
+
+	// Sending the read request(s)
+	return solidserverlist("dns_rr_list", parameters, 0, meta)
+}
+
+// Return the TTL of a RRset from the TTL of its records
+// The current TTL is kept if every record holds it, otherwise the first differing one is returned to be reported as drift
+func dnsrrsetttl(current int, ttls []int) int {
+	for _, ttl := range ttls {
+		if ttl != current {
+			return ttl
+		}
+	}
+
+	return current
+}
+
+// Add, update and delete the records of a RRset to match its values and TTL
+func dnsrrsetapply(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	rrType := strings.ToUpper(d.Get("type").(string))
+	ttl := d.Get("ttl").(int)
+
+	records, err := dnsrrsetlist(d, meta)
+
+	if err != nil {
+		return err
+	}
+
+	// Indexing the expected values by their normalized presentation
+	expected := map[string][]string{}
+
+	for _, value := range d.Get("values").(*schema.Set).List() {
+		values, valuesErr := dnsrrvaluesfromtext(rrType, value.(string))
+
+		if valuesErr != nil {
+			return valuesErr
+		}
+
+		expected[dnsrrnormalizetext(rrType, value.(string))] = values
+	}
+
+	// Deleting the unexpected records and updating the TTL of the expected ones
+	for _, record := range records {
+		attributes := restobjectattributes(record)
+		values := dnsrrentryvalues(record)
+		key := dnsrrnormalizetext(rrType, dnsrrtextfromvalues(rrType, values))
+
+		if _, expectedExist := expected[key]; !expectedExist {
+			if err := dnsrrdelete(attributes["rr_id"], meta); err != nil {
+				return err
+			}
+
+			tflog.Debug(ctx, fmt.Sprintf("Deleted RR (oid): %s from RRset: %s %s\n", attributes["rr_id"], d.Get("name").(string), rrType))
+			continue
+		}
+
+		if attributes["ttl"] != strconv.Itoa(ttl) {
+			if err := dnsrrupdate(attributes["rr_id"], d.Get("dnsserver").(string), d.Get("dnsview").(string), d.Get("dnszone").(string), d.Get("name").(string), rrType, values, ttl, meta); err != nil {
+				return err
+			}
+		}
+
+		delete(expected, key)
+	}
+
+	// Adding the missing records
+	for _, values := range expected {
+		oid, err := dnsrradd(d.Get("dnsserver").(string), d.Get("dnsview").(string), d.Get("dnszone").(string), d.Get("name").(string), rrType, values, ttl, meta)
+
+		if err != nil {
+			return err
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Added RR (oid): %s to RRset: %s %s\n", oid, d.Get("name").(string), rrType))
+	}
+
+	return nil
+}
+
+func resourcednsrrsetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// If no view is specified and server has some configured, trigger an error
+	if len(d.Get("dnsview").(string)) == 0 && dnsserverhasviews(d.Get("dnsserver").(string), meta) {
+		return diag.Errorf("Unable to create RRset: %s, this DNS server has views. Please specify a view name.\n", d.Get("name").(string))
+	}
+
+	if err := dnsrrsetapply(ctx, d, meta); err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strings.Join([]string{d.Get("dnsserver").(string), d.Get("dnsview").(string), d.Get("dnszone").(string), d.Get("name").(string), strings.ToUpper(d.Get("type").(string))}, "/"))
+
+	return resourcednsrrsetRead(ctx, d, meta)
+}
+
+func resourcednsrrsetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if err := dnsrrsetapply(ctx, d, meta); err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	return resourcednsrrsetRead(ctx, d, meta)
+}
+
+func resourcednsrrsetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	records, err := dnsrrsetlist(d, meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	for _, record := range records {
+		if rrID, _ := record["rr_id"].(string); len(rrID) > 0 {
+			if err := dnsrrdelete(rrID, meta); err != nil {
+				// Reporting a failure
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	// Log deletion
+	tflog.Debug(ctx, fmt.Sprintf("Deleted RRset: %s\n", d.Id()))
+
+	// Unset local ID
+	d.SetId("")
+
+	// Reporting a success
+	return nil
+}
+
+func resourcednsrrsetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	rrType := strings.ToUpper(d.Get("type").(string))
+
+	records, err := dnsrrsetlist(d, meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	// Keeping the presentation of the configured values when they match
+	configured := map[string]string{}
+
+	for _, value := range d.Get("values").(*schema.Set).List() {
+		configured[dnsrrnormalizetext(rrType, value.(string))] = value.(string)
+	}
+
+	values := []interface{}{}
+	ttls := []int{}
+
+	for _, record := range records {
+		text := dnsrrtextfromvalues(rrType, dnsrrentryvalues(record))
+
+		if value, valueExist := configured[dnsrrnormalizetext(rrType, text)]; valueExist {
+			text = value
+		}
+
+		values = append(values, text)
+
+		ttl, _ := strconv.Atoi(restobjectattributes(record)["ttl"])
+		ttls = append(ttls, ttl)
+	}
+
+	d.Set("ttl", dnsrrsetttl(d.Get("ttl").(int), ttls))
+
+	if len(records) == 0 {
+		tflog.Debug(ctx, fmt.Sprintf("Unable to find any record of RRset: %s\n", d.Id()))
+	}
+
+	d.Set("values", values)
+
+	return nil
+}
+
+func resourcednsrrsetImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keys := strings.Split(d.Id(), "/")
+
+	if len(keys) != 5 || len(keys[0]) == 0 || len(keys[3]) == 0 || len(keys[4]) == 0 {
+		return nil, fmt.Errorf("SOLIDServer - Invalid RRset import ID: %s, expecting <dnsserver>/<dnsview>/<dnszone>/<name>/<type>\n", d.Id())
+	}
+
+	d.Set("dnsserver", keys[0])
+	d.Set("dnsview", keys[1])
+	d.Set("dnszone", keys[2])
+	d.Set("name", keys[3])
+	d.Set("type", strings.ToUpper(keys[4]))
+	d.SetId(strings.Join([]string{keys[0], keys[1], keys[2], keys[3], strings.ToUpper(keys[4])}, "/"))
+
+	if diags := resourcednsrrsetRead(ctx, d, meta); diags.HasError() {
+		return nil, fmt.Errorf("SOLIDServer - Unable to find and import RRset: %s\n", d.Id())
+	}
+
+	if d.Get("values").(*schema.Set).Len() == 0 {
+		return nil, fmt.Errorf("SOLIDServer - Unable to find and import RRset: %s\n", d.Id())
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
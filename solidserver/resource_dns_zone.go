@@ -20,12 +20,14 @@ func resourcednszone() *schema.Resource {
 		ReadContext:   resourcednszoneRead,
 		UpdateContext: resourcednszoneUpdate,
 		DeleteContext: resourcednszoneDelete,
+		CustomizeDiff: resourcednszonediff,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourcednszoneImportState,
 		},
 
 		Description: heredoc.Doc(`
-			DNS Zone resource allows to create and configure DNS zones.
+			DNS Zone resource allows to create and configure DNS zones, either master zones or slave (secondary), stub and hint zones.
+			Slave and stub zones transfer their content from the masters they are configured with.
 		`),
 
 		Schema: map[string]*schema.Schema{
@@ -57,11 +59,43 @@ func resourcednszone() *schema.Resource {
 			},
 			"type": {
 				Type:         schema.TypeString,
-				Description:  "The type of the zone to create (Supported: Master).",
+				Description:  "The type of the zone to create (Supported: Master, Slave (or Secondary), Stub, Hint; Default: Master).",
 				ValidateFunc: resourcednszonevalidatetype,
-				Optional:     true,
-				ForceNew:     true,
-				Default:      "Master",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return dnszonetype(old) == dnszonetype(new)
+				},
+				Optional: true,
+				ForceNew: true,
+				Default:  "Master",
+			},
+			"masters": {
+				Type:        schema.TypeList,
+				Description: "The master servers the zone content is transferred from, required for the Slave and Stub zones.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address": {
+							Type:         schema.TypeString,
+							Description:  "The IP address of the master server.",
+							ValidateFunc: validation.IsIPAddress,
+							Required:     true,
+						},
+						"port": {
+							Type:         schema.TypeInt,
+							Description:  "The port of the master server (Default: 53).",
+							ValidateFunc: validation.IsPortNumber,
+							Optional:     true,
+							Default:      53,
+						},
+						"tsig_key": {
+							Type:        schema.TypeString,
+							Description: "The name of the TSIG key authenticating the transfers from the master server.",
+							Optional:    true,
+							Default:     "",
+						},
+					},
+				},
 			},
 			"createptr": {
 				Type:        schema.TypeBool,
@@ -109,13 +143,34 @@ func resourcednszone() *schema.Resource {
 
 func resourcednszonevalidatetype(v interface{}, _ string) ([]string, []error) {
 	switch strings.ToLower(v.(string)) {
-	case "master":
+	case "master", "slave", "secondary", "stub", "hint":
 		return nil, nil
+	case "forward":
+		return nil, []error{fmt.Errorf("Unsupported zone type, forward zones are managed by the solidserver_dns_forward_zone resource.")}
 	default:
 		return nil, []error{fmt.Errorf("Unsupported zone type.")}
 	}
 }
 
+// Check at plan time that the masters are only and always provided to the Slave and Stub zones
+func resourcednszonediff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("type") || !d.NewValueKnown("masters") {
+		return nil
+	}
+
+	hasMasters := len(d.Get("masters").([]interface{})) > 0
+
+	if dnszonetypehasmasters(d.Get("type").(string)) && !hasMasters {
+		return fmt.Errorf("SOLIDServer - DNS zone: %s of type %s requires at least one master\n", d.Get("name").(string), d.Get("type").(string))
+	}
+
+	if !dnszonetypehasmasters(d.Get("type").(string)) && hasMasters {
+		return fmt.Errorf("SOLIDServer - DNS zone: %s of type %s can't define masters\n", d.Get("name").(string), d.Get("type").(string))
+	}
+
+	return nil
+}
+
 func resourcednszoneCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
@@ -142,9 +197,14 @@ func resourcednszoneCreate(ctx context.Context, d *schema.ResourceData, meta int
 	}
 
 	parameters.Add("dnszone_name", d.Get("name").(string))
-	parameters.Add("dnszone_type", strings.ToLower(d.Get("type").(string)))
+	parameters.Add("dnszone_type", dnszonetype(d.Get("type").(string)))
 	parameters.Add("dnszone_site_id", siteID)
 
+	// Building the masters of the Slave and Stub zones
+	if dnszonetypehasmasters(d.Get("type").(string)) {
+		parameters.Add("dnszone_masters", dnszonemasters(d.Get("masters").([]interface{})))
+	}
+
 	// Building Notify and Also Notify Statements
 	parameters.Add("dnszone_notify", strings.ToLower(d.Get("notify").(string)))
 
@@ -229,6 +289,11 @@ func resourcednszoneUpdate(ctx context.Context, d *schema.ResourceData, meta int
 	}
 	parameters.Add("dnszone_site_id", siteID)
 
+	// Building the masters of the Slave and Stub zones
+	if dnszonetypehasmasters(d.Get("type").(string)) {
+		parameters.Add("dnszone_masters", dnszonemasters(d.Get("masters").([]interface{})))
+	}
+
 	// Building Notify and Also Notify Statements
 	parameters.Add("dnszone_notify", strings.ToLower(d.Get("notify").(string)))
 
@@ -359,6 +424,10 @@ func resourcednszoneRead(ctx context.Context, d *schema.ResourceData, meta inter
 			d.Set("name", buf[0]["dnszone_name"].(string))
 			d.Set("type", buf[0]["dnszone_type"].(string))
 
+			if masters, mastersExist := buf[0]["dnszone_masters"].(string); mastersExist {
+				d.Set("masters", dnszonemastersparse(masters))
+			}
+
 			if buf[0]["dnszone_site_name"].(string) != "#" {
 				d.Set("space", buf[0]["dnszone_site_name"].(string))
 			} else {
@@ -440,6 +509,10 @@ func resourcednszoneImportState(ctx context.Context, d *schema.ResourceData, met
 			d.Set("name", buf[0]["dnszone_name"].(string))
 			d.Set("type", buf[0]["dnszone_type"].(string))
 
+			if masters, mastersExist := buf[0]["dnszone_masters"].(string); mastersExist {
+				d.Set("masters", dnszonemastersparse(masters))
+			}
+
 			if buf[0]["dnszone_site_name"].(string) != "#" {
 				d.Set("space", buf[0]["dnszone_site_name"].(string))
 			} else {
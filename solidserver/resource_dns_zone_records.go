@@ -0,0 +1,402 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"strings"
+)
+
+func resourcednszonerecords() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcednszonerecordsCreate,
+		ReadContext:   resourcednszonerecordsRead,
+		UpdateContext: resourcednszonerecordsUpdate,
+		DeleteContext: resourcednszonerecordsDelete,
+		CustomizeDiff: resourcednszonerecordsdiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcednszonerecordsImportState,
+		},
+
+		Description: heredoc.Doc(`
+			DNS Zone Records resource allows to manage exclusively the content of a DNS zone. The full list of expected
+			records is compared with the records of the zone, only the necessary records are added, updated or deleted.
+			Only the record types supported by the provider are managed, the SOA, the records of other types (ex: DNSSEC records),
+			the apex NS records (unless managed) and the records matching an ignore rule are left untouched.
+			Destroying this resource leaves the records of the zone in place, unless delete_records is set.
+			The expected records can be loaded from a RFC 1035 zone file (ex: a BIND master file), listing each of them at plan time.
+			The import ID is formatted as <dnsserver>/<dnsview>/<dnszone>.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"dnsserver": {
+				Type:        schema.TypeString,
+				Description: "The managed SMART DNS server name, or DNS server name hosting the zone.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"dnsview": {
+				Type:        schema.TypeString,
+				Description: "The View name of the zone.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "",
+			},
+			"dnszone": {
+				Type:        schema.TypeString,
+				Description: "The name of the zone to manage the content of.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"manage_apex_ns": {
+				Type:        schema.TypeBool,
+				Description: "Manage the NS records of the zone apex along with the other records (Default: false).",
+				Optional:    true,
+				Default:     false,
+			},
+			"delete_records": {
+				Type:        schema.TypeBool,
+				Description: "Delete the managed records of the zone when destroying the resource, otherwise they are left in place (Default: false).",
+				Optional:    true,
+				Default:     false,
+			},
+			"ignore": {
+				Type:        schema.TypeList,
+				Description: "The records of the zone to leave untouched, by type and/or name (ex: system or dynamically registered records).",
+				Optional:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:        schema.TypeString,
+							Description: "The type of the records to ignore, any type if empty.",
+							Optional:    true,
+							Default:     "",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the records to ignore, relative to the zone or fully qualified, supporting wildcards (ex: \"_acme-challenge.*\"), any name if empty.",
+							Optional:    true,
+							Default:     "",
+						},
+					},
+				},
+			},
+			"zone_file": {
+				Type:          schema.TypeString,
+				Description:   "The content of a RFC 1035 zone file providing the expected records of the zone, supporting $ORIGIN, $TTL, relative names and multi-line records (the SOA and the records of unsupported types are skipped).",
+				Optional:      true,
+				ConflictsWith: []string{"record"},
+			},
+			"record": {
+				Type:        schema.TypeSet,
+				Description: "The expected records of the zone, computed from the zone file when provided.",
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the record, relative to the zone (\"@\" for the zone apex) or fully qualified.",
+							Required:    true,
+						},
+						"type": {
+							Type:         schema.TypeString,
+							Description:  "The type of the record (Supported: A, AAAA, PTR, CNAME, DNAME, TXT, NS, MX, SRV, CAA, NAPTR, SSHFP, TLSA and HINFO).",
+							ValidateFunc: resourcednsrrvalidatetype,
+							Required:     true,
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Description: "The value of the record, in presentation format for the multi-value types (ex: \"10 mail.example.com\" for a MX).",
+							Required:    true,
+						},
+						"ttl": {
+							Type:        schema.TypeInt,
+							Description: "The DNS Time To Live of the record.",
+							Optional:    true,
+							Default:     3600,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Return the expected records of a zone content
+func dnszonerecordsexpected(records []interface{}) []dnsZoneRecord {
+	res := []dnsZoneRecord{}
+
+	for _, r := range records {
+		record := r.(map[string]interface{})
+
+		res = append(res, dnsZoneRecord{
+			Name:  record["name"].(string),
+			Type:  strings.ToUpper(record["type"].(string)),
+			Value: record["value"].(string),
+			TTL:   record["ttl"].(int),
+		})
+	}
+
+	return res
+}
+
+// Return the ignore rules of a zone content
+func dnszonerecordsignores(ignores []interface{}) []dnsZoneIgnore {
+	res := []dnsZoneIgnore{}
+
+	for _, i := range ignores {
+		if ignore, ok := i.(map[string]interface{}); ok {
+			res = append(res, dnsZoneIgnore{Type: ignore["type"].(string), Name: ignore["name"].(string)})
+		}
+	}
+
+	return res
+}
+
+// Return the records of a zone that are managed by the resource
+func dnszonerecordsmanaged(d *schema.ResourceData, meta interface{}) ([]dnsZoneRecord, error) {
+	records, err := dnszonerecordslist(d.Get("dnsserver").(string), d.Get("dnsview").(string), d.Get("dnszone").(string), meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ignores := dnszonerecordsignores(d.Get("ignore").([]interface{}))
+	res := []dnsZoneRecord{}
+
+	for _, record := range records {
+		if !dnszonerecordignored(record, d.Get("dnszone").(string), d.Get("manage_apex_ns").(bool), ignores) {
+			res = append(res, record)
+		}
+	}
+
+	return res, nil
+}
+
+// Compute at plan time the expected records from the zone file if provided,
+// and check the values of the expected records against their type
+func resourcednszonerecordsdiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config := d.GetRawConfig()
+
+	if !config.IsKnown() || config.IsNull() {
+		return nil
+	}
+
+	if !config.GetAttr("zone_file").IsNull() {
+		if !d.NewValueKnown("zone_file") || !d.NewValueKnown("dnszone") || !d.NewValueKnown("manage_apex_ns") || !d.NewValueKnown("ignore") {
+			return d.SetNewComputed("record")
+		}
+
+		records, err := dnszonefileparse(d.Get("zone_file").(string), d.Get("dnszone").(string), 3600)
+
+		if err != nil {
+			return err
+		}
+
+		ignores := dnszonerecordsignores(d.Get("ignore").([]interface{}))
+		res := []interface{}{}
+
+		for _, record := range records {
+			if dnszonerecordignored(record, d.Get("dnszone").(string), d.Get("manage_apex_ns").(bool), ignores) {
+				continue
+			}
+
+			res = append(res, map[string]interface{}{
+				"name":  record.Name,
+				"type":  record.Type,
+				"value": record.Value,
+				"ttl":   record.TTL,
+			})
+		}
+
+		return d.SetNew("record", res)
+	}
+
+	// Without any record, the zone is expected to be empty
+	if records := config.GetAttr("record"); records.IsKnown() && (records.IsNull() || records.LengthInt() == 0) {
+		return d.SetNew("record", []interface{}{})
+	}
+
+	if !d.NewValueKnown("record") {
+		return nil
+	}
+
+	for _, record := range dnszonerecordsexpected(d.Get("record").(*schema.Set).List()) {
+		if len(record.Type) == 0 || len(record.Value) == 0 {
+			continue
+		}
+
+		if _, err := dnsrrvaluesfromtext(record.Type, record.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Add, update and delete the records of a zone to match its expected content
+func dnszonerecordsapply(ctx context.Context, d *schema.ResourceData, expected []dnsZoneRecord, meta interface{}) error {
+	serverName, viewName, zoneName := d.Get("dnsserver").(string), d.Get("dnsview").(string), d.Get("dnszone").(string)
+
+	current, err := dnszonerecordsmanaged(d, meta)
+
+	if err != nil {
+		return err
+	}
+
+	add, update, del := dnszonerecordsdiff(current, expected, zoneName)
+
+	for _, record := range del {
+		if err := dnsrrdelete(record.ID, meta); err != nil {
+			return err
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Deleted RR (oid): %s (%s %s %s) from zone: %s\n", record.ID, record.Name, record.Type, record.Value, zoneName))
+	}
+
+	for _, record := range update {
+		values, valuesErr := dnsrrvaluesfromtext(record.Type, record.Value)
+
+		if valuesErr != nil {
+			return valuesErr
+		}
+
+		if err := dnsrrupdate(record.ID, serverName, viewName, zoneName, dnszonerecordfqdn(record.Name, zoneName), record.Type, values, record.TTL, meta); err != nil {
+			return err
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Updated RR (oid): %s (%s %s %s) of zone: %s\n", record.ID, record.Name, record.Type, record.Value, zoneName))
+	}
+
+	for _, record := range add {
+		values, valuesErr := dnsrrvaluesfromtext(record.Type, record.Value)
+
+		if valuesErr != nil {
+			return valuesErr
+		}
+
+		oid, err := dnsrradd(serverName, viewName, zoneName, dnszonerecordfqdn(record.Name, zoneName), record.Type, values, record.TTL, meta)
+
+		if err != nil {
+			return err
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Added RR (oid): %s (%s %s %s) to zone: %s\n", oid, record.Name, record.Type, record.Value, zoneName))
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Applied the content of zone: %s (%d added, %d updated, %d deleted)\n", zoneName, len(add), len(update), len(del)))
+
+	return nil
+}
+
+func resourcednszonerecordsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// If no view is specified and server has some configured, trigger an error
+	if len(d.Get("dnsview").(string)) == 0 && dnsserverhasviews(d.Get("dnsserver").(string), meta) {
+		return diag.Errorf("Unable to manage the content of zone: %s, this DNS server has views. Please specify a view name.\n", d.Get("dnszone").(string))
+	}
+
+	if err := dnszonerecordsapply(ctx, d, dnszonerecordsexpected(d.Get("record").(*schema.Set).List()), meta); err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strings.Join([]string{d.Get("dnsserver").(string), d.Get("dnsview").(string), d.Get("dnszone").(string)}, "/"))
+
+	return resourcednszonerecordsRead(ctx, d, meta)
+}
+
+func resourcednszonerecordsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if err := dnszonerecordsapply(ctx, d, dnszonerecordsexpected(d.Get("record").(*schema.Set).List()), meta); err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	return resourcednszonerecordsRead(ctx, d, meta)
+}
+
+func resourcednszonerecordsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// The records are kept as is unless their deletion is requested
+	if !d.Get("delete_records").(bool) {
+		tflog.Debug(ctx, fmt.Sprintf("Forgetting the content of zone: %s\n", d.Id()))
+
+		// Unset local ID
+		d.SetId("")
+
+		return nil
+	}
+
+	if err := dnszonerecordsapply(ctx, d, []dnsZoneRecord{}, meta); err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	// Log deletion
+	tflog.Debug(ctx, fmt.Sprintf("Deleted the content of zone: %s\n", d.Id()))
+
+	// Unset local ID
+	d.SetId("")
+
+	// Reporting a success
+	return nil
+}
+
+func resourcednszonerecordsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	zoneName := d.Get("dnszone").(string)
+
+	current, err := dnszonerecordsmanaged(d, meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	// Keeping the presentation of the configured records when they match
+	configured := map[string]dnsZoneRecord{}
+
+	for _, record := range dnszonerecordsexpected(d.Get("record").(*schema.Set).List()) {
+		configured[dnszonerecordkey(record, zoneName)] = record
+	}
+
+	records := []interface{}{}
+
+	for _, record := range current {
+		if match, matchExist := configured[dnszonerecordkey(record, zoneName)]; matchExist {
+			record.Name, record.Type, record.Value = match.Name, match.Type, match.Value
+		}
+
+		records = append(records, map[string]interface{}{
+			"name":  record.Name,
+			"type":  record.Type,
+			"value": record.Value,
+			"ttl":   record.TTL,
+		})
+	}
+
+	d.Set("record", records)
+
+	return nil
+}
+
+func resourcednszonerecordsImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keys := strings.Split(d.Id(), "/")
+
+	if len(keys) != 3 || len(keys[0]) == 0 || len(keys[2]) == 0 {
+		return nil, fmt.Errorf("SOLIDServer - Invalid zone records import ID: %s, expecting <dnsserver>/<dnsview>/<dnszone>\n", d.Id())
+	}
+
+	d.Set("dnsserver", keys[0])
+	d.Set("dnsview", keys[1])
+	d.Set("dnszone", keys[2])
+	d.Set("manage_apex_ns", false)
+	d.Set("delete_records", false)
+
+	if diags := resourcednszonerecordsRead(ctx, d, meta); diags.HasError() {
+		return nil, fmt.Errorf("SOLIDServer - Unable to find and import the records of zone: %s\n", d.Id())
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -15,7 +15,7 @@ import (
 )
 
 func resourceip6address() *schema.Resource {
-	return &schema.Resource{
+	resource := &schema.Resource{
 		CreateContext: resourceip6addressCreate,
 		ReadContext:   resourceip6addressRead,
 		UpdateContext: resourceip6addressUpdate,
@@ -23,10 +23,12 @@ func resourceip6address() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceip6addressImportState,
 		},
+		CustomizeDiff: resourceipaddressmovediff,
 
 		Description: heredoc.Doc(`
 			IPv6 address resource allows to create and manage reserved addresses for specific devices, apps or users.
 			More importantly it allows to store useful meta-data for both tracking and automation purposes.
+			Changing the space, subnet, pool or requested address moves the IPv6 address in place, keeping its meta-data, device and aliases.
 		`),
 
 		Schema: map[string]*schema.Schema{
@@ -34,19 +36,19 @@ func resourceip6address() *schema.Resource {
 				Type:        schema.TypeString,
 				Description: "The name of the space into which creating the IPv6 address.",
 				Required:    true,
-				ForceNew:    true,
+				ForceNew:    false,
 			},
 			"subnet": {
 				Type:        schema.TypeString,
 				Description: "The name of the subnet into which creating the IPv6 address.",
 				Required:    true,
-				ForceNew:    true,
+				ForceNew:    false,
 			},
 			"pool": {
 				Type:        schema.TypeString,
 				Description: "The name of the pool into which creating the IPv6 address.",
 				Optional:    true,
-				ForceNew:    true,
+				ForceNew:    false,
 				Default:     "",
 			},
 			"request_ip": {
@@ -54,14 +56,47 @@ func resourceip6address() *schema.Resource {
 				Description:  "The optionally requested IPv6 address.",
 				ValidateFunc: validation.IsIPAddress,
 				Optional:     true,
-				ForceNew:     true,
+				ForceNew:     false,
 				Default:      "",
 			},
+			"allocation_strategy": {
+				Type:         schema.TypeString,
+				Description:  "The strategy used to pick a free IPv6 address when no address is requested, either lowest, highest or random (Default: lowest).",
+				ValidateFunc: validation.StringInSlice([]string{"lowest", "highest", "random"}, false),
+				Optional:     true,
+				ForceNew:     false,
+				Default:      "lowest",
+			},
+			"skip_first": {
+				Type:         schema.TypeInt,
+				Description:  "The number of addresses at the beginning of the subnet (or pool) never picked when no address is requested (Default: 0).",
+				ValidateFunc: validation.IntAtLeast(0),
+				Optional:     true,
+				ForceNew:     false,
+				Default:      0,
+			},
+			"skip_last": {
+				Type:         schema.TypeInt,
+				Description:  "The number of addresses at the end of the subnet (or pool) never picked when no address is requested (Default: 0).",
+				ValidateFunc: validation.IntAtLeast(0),
+				Optional:     true,
+				ForceNew:     false,
+				Default:      0,
+			},
+			"exclude": {
+				Type:        schema.TypeList,
+				Description: "The addresses, prefixes or ranges (ex: 2001:db8::10-2001:db8::1f) never picked when no address is requested.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateiprange(true),
+				},
+			},
 			"address": {
 				Type:        schema.TypeString,
 				Description: "The provisionned IPv6 address.",
 				Computed:    true,
-				ForceNew:    true,
 			},
 			"device": {
 				Type:        schema.TypeString,
@@ -104,9 +139,37 @@ func resourceip6address() *schema.Resource {
 			},
 		},
 	}
+
+	// Adding the DNS records management attributes
+	for k, v := range ipaddressdnsschema() {
+		resource.Schema[k] = v
+	}
+
+	return resource
 }
 
 func resourceip6addressCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	oid, address, diags := resourceip6addressallocate(ctx, d, url.Values{}, meta)
+
+	if diags != nil {
+		// Reporting a failure
+		return diags
+	}
+
+	d.SetId(oid)
+	d.Set("address", address)
+
+	// Registering the DNS records
+	if dnsErr := ipaddressdnsapply(d, address, true, meta); dnsErr != nil {
+		return diag.FromErr(dnsErr)
+	}
+
+	return nil
+}
+
+// Allocate and register a new IPv6 address from the configuration, return its oid and address
+// The inherited parameters complete the configured ones when moving an existing IPv6 address
+func resourceip6addressallocate(ctx context.Context, d *schema.ResourceData, inherited url.Values, meta interface{}) (string, string, diag.Diagnostics) {
 	s := meta.(*SOLIDserver)
 
 	var requestedHexIP string = ip6tohexip6(d.Get("request_ip").(string))
@@ -118,17 +181,17 @@ func resourceip6addressCreate(ctx context.Context, d *schema.ResourceData, meta
 	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
 	if siteErr != nil {
 		// Reporting a failure
-		return diag.FromErr(siteErr)
+		return "", "", diag.FromErr(siteErr)
 	}
 
 	subnetInfo, subnetErr := ip6subnetinfobyname(siteID, d.Get("subnet").(string), true, meta)
 	if subnetInfo == nil || subnetErr != nil {
 		// Reporting a failure
 		if subnetInfo == nil {
-			return diag.Errorf("Unable to create IP address: %s, unable to find requested network\n", d.Get("name").(string))
+			return "", "", diag.Errorf("Unable to create IP address: %s, unable to find requested network\n", d.Get("name").(string))
 		}
 
-		return diag.FromErr(subnetErr)
+		return "", "", diag.FromErr(subnetErr)
 	}
 
 	if len(d.Get("pool").(string)) > 0 {
@@ -137,7 +200,7 @@ func resourceip6addressCreate(ctx context.Context, d *schema.ResourceData, meta
 		poolInfo, poolErr = ip6poolinfobyname(siteID, d.Get("pool").(string), d.Get("subnet").(string), meta)
 		if poolErr != nil {
 			// Reporting a failure
-			return diag.FromErr(poolErr)
+			return "", "", diag.FromErr(poolErr)
 		}
 	}
 
@@ -149,10 +212,15 @@ func resourceip6addressCreate(ctx context.Context, d *schema.ResourceData, meta
 
 		if deviceErr != nil {
 			// Reporting a failure
-			return diag.FromErr(deviceErr)
+			return "", "", diag.FromErr(deviceErr)
 		}
 	}
 
+	// Serializing the allocations within the subnet
+	allocationKey := "ip6_subnet:" + subnetInfo["id"].(string)
+	unlock := s.Allocations.lock(allocationKey)
+	defer unlock()
+
 	// Determining if an IP address was submitted in or if we should get one from the IPAM
 	if len(d.Get("request_ip").(string)) > 0 {
 		// Ensure IP Address is within the given subnet start and end IP addresses
@@ -162,12 +230,12 @@ func resourceip6addressCreate(ctx context.Context, d *schema.ResourceData, meta
 
 			if poolInfo != nil && (strings.Compare(poolInfo["start_hex_addr"].(string), requestedHexIP) == 1 ||
 				strings.Compare(requestedHexIP, poolInfo["end_hex_addr"].(string)) == 1) {
-				return diag.Errorf("Unable to create IPv6 address: %s, address is out of pool's range\n", d.Get("name").(string))
+				return "", "", diag.Errorf("Unable to create IPv6 address: %s, address is out of pool's range\n", d.Get("name").(string))
 			}
 
 			ipAddresses = []string{d.Get("request_ip").(string)}
 		} else {
-			return diag.Errorf("Unable to create IPv6 address: %s, address is out of network's range\n", d.Get("name").(string))
+			return "", "", diag.Errorf("Unable to create IPv6 address: %s, address is out of network's range\n", d.Get("name").(string))
 		}
 	} else {
 		var poolID string = ""
@@ -177,12 +245,22 @@ func resourceip6addressCreate(ctx context.Context, d *schema.ResourceData, meta
 			poolID = poolInfo["id"].(string)
 		}
 
-		ipAddresses, ipErr = ip6addressfindfree(subnetInfo["id"].(string), poolID, meta)
+		policy, policyErr := ipaddresspolicyfromresource(d, true)
+
+		if policyErr != nil {
+			// Reporting a failure
+			return "", "", diag.FromErr(policyErr)
+		}
+
+		ipAddresses, ipErr = ip6addressfindfree(subnetInfo["id"].(string), poolID, policy, meta)
 
 		if ipErr != nil {
 			// Reporting a failure
-			return diag.FromErr(ipErr)
+			return "", "", diag.FromErr(ipErr)
 		}
+
+		// Skipping the addresses already allocated by parallel creations
+		ipAddresses = s.Allocations.filter(allocationKey, ipAddresses)
 	}
 
 	for i := 0; i < len(ipAddresses); i++ {
@@ -202,6 +280,11 @@ func resourceip6addressCreate(ctx context.Context, d *schema.ResourceData, meta
 		// Building class_parameters
 		parameters.Add("ip6_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
 
+		// Inheriting the meta-data of a moved IPv6 address
+		for k := range inherited {
+			parameters.Set(k, inherited.Get(k))
+		}
+
 		// Sending the creation request
 		resp, body, err := s.Request("post", "rest/ip6_address6_add", &parameters)
 
@@ -213,9 +296,8 @@ func resourceip6addressCreate(ctx context.Context, d *schema.ResourceData, meta
 			if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 				if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 					tflog.Debug(ctx, fmt.Sprintf("Created IPv6 address (oid): %s\n", oid))
-					d.SetId(oid)
-					d.Set("address", ipAddresses[i])
-					return nil
+					s.Allocations.claim(allocationKey, ipAddresses[i])
+					return oid, ipAddresses[i], nil
 				}
 			} else {
 				if len(buf) > 0 {
@@ -230,17 +312,71 @@ func resourceip6addressCreate(ctx context.Context, d *schema.ResourceData, meta
 			}
 		} else {
 			// Reporting a failure
-			return diag.FromErr(err)
+			return "", "", diag.FromErr(err)
 		}
 	}
 
 	// Reporting a failure
-	return diag.Errorf("Unable to create IPv6 address: %s, unable to find a suitable network or address\n", d.Get("name").(string))
+	return "", "", diag.Errorf("Unable to create IPv6 address: %s, unable to find a suitable network or address\n", d.Get("name").(string))
+}
+
+// Move an IPv6 address to a new subnet, pool or address
+// The new IPv6 address is created first with the same meta-data, then the aliases are moved and the previous one is deleted
+func resourceip6addressmove(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	previousID := d.Id()
+
+	inherited, err := ipaddressinherited(d, previousID, true, meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	oid, address, diags := resourceip6addressallocate(ctx, d, inherited, meta)
+
+	if diags != nil {
+		// Reporting a failure
+		return diags
+	}
+
+	// Moving the aliases
+	if aliasErr := ipaddressmovealiases(previousID, oid, true, meta); aliasErr != nil {
+		// Rolling back the new IPv6 address
+		if delErr := ipaddressdeletebyid(oid, true, meta); delErr != nil {
+			tflog.Debug(ctx, fmt.Sprintf("Unable to roll back the move of IPv6 address: %s (%s)\n", d.Get("name").(string), delErr))
+		}
+
+		// Reporting a failure
+		return diag.FromErr(aliasErr)
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Moved IPv6 address (oid): %s to %s (oid): %s\n", previousID, address, oid))
+
+	d.SetId(oid)
+	d.Set("address", address)
+
+	// Removing the previous IPv6 address
+	if delErr := ipaddressdeletebyid(previousID, true, meta); delErr != nil {
+		// Reporting a failure
+		return diag.FromErr(delErr)
+	}
+
+	// Moving the DNS records
+	if dnsErr := ipaddressdnsapply(d, address, true, meta); dnsErr != nil {
+		return diag.FromErr(dnsErr)
+	}
+
+	return nil
 }
 
 func resourceip6addressUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
+	// Moving the IPv6 address when its subnet, pool or requested address changed
+	if ipaddressmoverequired(d) {
+		return resourceip6addressmove(ctx, d, meta)
+	}
+
 	var deviceID string = ""
 
 	// Retrieving device ID
@@ -282,6 +418,14 @@ func resourceip6addressUpdate(ctx context.Context, d *schema.ResourceData, meta
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Updated IPv6 address (oid): %s\n", oid))
 				d.SetId(oid)
+
+				// Maintaining the DNS records
+				if ipaddressdnshaschange(d) {
+					if dnsErr := ipaddressdnsapply(d, d.Get("address").(string), true, meta); dnsErr != nil {
+						return diag.FromErr(dnsErr)
+					}
+				}
+
 				return nil
 			}
 		}
@@ -303,6 +447,11 @@ func resourceip6addressUpdate(ctx context.Context, d *schema.ResourceData, meta
 func resourceip6addressDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
+	// Removing the DNS records
+	if dnsErr := ipaddressdnsclear(d, meta); dnsErr != nil {
+		return diag.FromErr(dnsErr)
+	}
+
 	// Building parameters
 	parameters := url.Values{}
 	parameters.Add("ip6_id", d.Id())
@@ -384,6 +533,12 @@ func resourceip6addressRead(ctx context.Context, d *schema.ResourceData, meta in
 
 			d.Set("class_parameters", computedClassParameters)
 
+			// Refreshing the DNS records
+			if dnsErr := ipaddressdnsread(d, meta); dnsErr != nil {
+				// Reporting a failure
+				return diag.FromErr(dnsErr)
+			}
+
 			return nil
 		}
 
@@ -429,6 +584,9 @@ func resourceip6addressImportState(ctx context.Context, d *schema.ResourceData,
 			d.Set("name", buf[0]["ip6_name"].(string))
 			d.Set("mac", buf[0]["mac_addr"].(string))
 			d.Set("class", buf[0]["ip6_class_name"].(string))
+			d.Set("allocation_strategy", "lowest")
+			d.Set("create_ptr", true)
+			d.Set("ttl", 3600)
 
 			// Updating local class_parameters
 			currentClassParameters := d.Get("class_parameters").(map[string]interface{})
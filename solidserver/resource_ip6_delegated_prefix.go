@@ -0,0 +1,177 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/url"
+	"strconv"
+)
+
+func resourceip6delegatedprefix() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceip6delegatedprefixCreate,
+		ReadContext:   resourceip6delegatedprefixRead,
+		UpdateContext: resourceip6delegatedprefixUpdate,
+		DeleteContext: resourceip6delegatedprefixDelete,
+
+		Description: heredoc.Doc(`
+			IPv6 delegated prefix resource allows to allocate a prefix of the delegated length from an IPv6 delegation pool,
+			typically for a customer-edge router. The prefix is recorded as an IPv6 subnet of the pool and released on destroy.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space of the IPv6 delegation pool.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"pool": {
+				Type:        schema.TypeString,
+				Description: "The name of the IPv6 delegation pool from which allocating the prefix.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"request_ip": {
+				Type:         schema.TypeString,
+				Description:  "The optionally requested delegated prefix address.",
+				ValidateFunc: validation.IsIPv6Address,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the delegated prefix to create (ex: the customer-edge router identifier).",
+				Required:    true,
+				ForceNew:    false,
+			},
+			"address": {
+				Type:        schema.TypeString,
+				Description: "The provisionned delegated prefix network address.",
+				Computed:    true,
+			},
+			"prefix": {
+				Type:        schema.TypeString,
+				Description: "The provisionned delegated prefix.",
+				Computed:    true,
+			},
+			"prefix_size": {
+				Type:        schema.TypeInt,
+				Description: "The delegated prefix length, defined by the IPv6 delegation pool.",
+				Computed:    true,
+			},
+			"class": {
+				Type:        schema.TypeString,
+				Description: "The class associated to the delegated prefix.",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     "",
+			},
+			"class_parameters": {
+				Type:        schema.TypeMap,
+				Description: "The class parameters associated to the delegated prefix.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceip6delegatedprefixCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Gather required ID(s) from provided information
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+	if siteErr != nil {
+		// Reporting a failure
+		return diag.FromErr(siteErr)
+	}
+
+	poolInfo, prefixSize, poolErr := ip6delegationpoolinfobyname(siteID, d.Get("pool").(string), meta)
+
+	if poolErr != nil {
+		// Reporting a failure
+		return diag.FromErr(poolErr)
+	}
+
+	oid, address, err := ip6subnetallocate(ctx, siteID, poolInfo, d.Get("request_ip").(string), prefixSize, d.Get("name").(string), d.Get("class").(string), urlfromclassparams(d.Get("class_parameters")), true, meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Delegated IPv6 prefix (oid): %s\n", oid))
+
+	d.SetId(oid)
+	d.Set("address", address)
+	d.Set("prefix", address+"/"+strconv.Itoa(prefixSize))
+	d.Set("prefix_size", prefixSize)
+
+	return nil
+}
+
+func resourceip6delegatedprefixUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if err := ip6subnetedit(d.Id(), d.Get("name").(string), d.Get("class").(string), urlfromclassparams(d.Get("class_parameters")), meta); err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceip6delegatedprefixDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Releasing the delegated prefix
+	if err := ip6subnetdeletebyid(d.Id(), meta); err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	// Unset local ID
+	d.SetId("")
+
+	// Reporting a success
+	return nil
+}
+
+func resourceip6delegatedprefixRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	prefixInfo, err := ipsubnetinfobyid(d.Id(), true, meta)
+
+	if err != nil {
+		// Log the error
+		tflog.Debug(ctx, fmt.Sprintf("Unable to find delegated IPv6 prefix (oid): %s\n", d.Id()))
+
+		// Do not unset the local ID to avoid inconsistency
+
+		// Reporting a failure
+		return diag.Errorf("Unable to find delegated IPv6 prefix: %s\n", d.Get("name").(string))
+	}
+
+	d.Set("name", prefixInfo["name"])
+	d.Set("class", prefixInfo["class"])
+
+	// Updating local class_parameters
+	currentClassParameters := d.Get("class_parameters").(map[string]interface{})
+	retrievedClassParameters, _ := url.ParseQuery(prefixInfo["class_parameters"])
+	computedClassParameters := map[string]string{}
+
+	for ck := range currentClassParameters {
+		if rv, rvExist := retrievedClassParameters[ck]; rvExist {
+			computedClassParameters[ck] = rv[0]
+		} else {
+			computedClassParameters[ck] = ""
+		}
+	}
+
+	d.Set("class_parameters", computedClassParameters)
+
+	return nil
+}
@@ -0,0 +1,229 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"math/big"
+	"net/url"
+	"strconv"
+)
+
+func resourceip6delegationpool() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceip6delegationpoolCreate,
+		ReadContext:   resourceip6delegationpoolRead,
+		UpdateContext: resourceip6delegationpoolUpdate,
+		DeleteContext: resourceip6delegationpoolDelete,
+		CustomizeDiff: resourceip6delegationpoolDiff,
+
+		Description: heredoc.Doc(`
+			IPv6 delegation pool resource allows to define a DHCPv6 prefix delegation (PD) pool within an IPv6 block.
+			The pool is registered as an IPv6 block carved from its parent block, from which prefixes of the delegated length
+			are allocated using the solidserver_ip6_delegated_prefix resource (ex: /56 or /60 for customer-edge routers).
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space into which creating the IPv6 delegation pool.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"block": {
+				Type:        schema.TypeString,
+				Description: "The name of the parent IPv6 block into which creating the IPv6 delegation pool.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"request_ip": {
+				Type:         schema.TypeString,
+				Description:  "The optionally requested IPv6 delegation pool address.",
+				ValidateFunc: validation.IsIPv6Address,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "",
+			},
+			"prefix_size": {
+				Type:         schema.TypeInt,
+				Description:  "The IPv6 delegation pool's prefix length (ex: 48 for a '/48').",
+				ValidateFunc: validation.IntBetween(1, 127),
+				Required:     true,
+				ForceNew:     true,
+			},
+			"delegated_prefix_size": {
+				Type:         schema.TypeInt,
+				Description:  "The prefix length of the prefixes delegated from the pool (ex: 56 for '/56' prefixes).",
+				ValidateFunc: validation.IntBetween(2, 128),
+				Required:     true,
+				ForceNew:     true,
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the IPv6 delegation pool to create.",
+				Required:    true,
+				ForceNew:    false,
+			},
+			"address": {
+				Type:        schema.TypeString,
+				Description: "The provisionned IPv6 delegation pool network address.",
+				Computed:    true,
+			},
+			"prefix": {
+				Type:        schema.TypeString,
+				Description: "The provisionned IPv6 delegation pool prefix.",
+				Computed:    true,
+			},
+			"capacity": {
+				Type:        schema.TypeString,
+				Description: "The number of prefixes that can be delegated from the pool (decimal string).",
+				Computed:    true,
+			},
+			"class": {
+				Type:        schema.TypeString,
+				Description: "The class associated to the IPv6 delegation pool.",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     "",
+			},
+			"class_parameters": {
+				Type:        schema.TypeMap,
+				Description: "The class parameters associated to the IPv6 delegation pool.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// Check at plan time that the delegated prefixes fit within the pool
+func resourceip6delegationpoolDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("prefix_size") || !d.NewValueKnown("delegated_prefix_size") {
+		return nil
+	}
+
+	if d.Get("delegated_prefix_size").(int) <= d.Get("prefix_size").(int) {
+		return fmt.Errorf("delegated_prefix_size (%d) must be longer than prefix_size (%d)", d.Get("delegated_prefix_size").(int), d.Get("prefix_size").(int))
+	}
+
+	return nil
+}
+
+// Build the class parameters of an IPv6 delegation pool, including its delegated prefix length
+func resourceip6delegationpoolclassparameters(d *schema.ResourceData) url.Values {
+	classParameters := urlfromclassparams(d.Get("class_parameters"))
+	classParameters.Set(ip6DelegatedPrefixSizeParameter, strconv.Itoa(d.Get("delegated_prefix_size").(int)))
+
+	return classParameters
+}
+
+func resourceip6delegationpoolCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Gather required ID(s) from provided information
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+	if siteErr != nil {
+		// Reporting a failure
+		return diag.FromErr(siteErr)
+	}
+
+	blockInfo, blockErr := ip6subnetinfobyname(siteID, d.Get("block").(string), false, meta)
+
+	if blockErr != nil {
+		// Reporting a failure
+		return diag.FromErr(blockErr)
+	}
+
+	oid, address, err := ip6subnetallocate(ctx, siteID, blockInfo, d.Get("request_ip").(string), d.Get("prefix_size").(int), d.Get("name").(string), d.Get("class").(string), resourceip6delegationpoolclassparameters(d), false, meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Created IPv6 delegation pool (oid): %s\n", oid))
+
+	d.SetId(oid)
+	d.Set("address", address)
+	d.Set("prefix", address+"/"+strconv.Itoa(d.Get("prefix_size").(int)))
+	d.Set("capacity", ip6delegationcapacity(d.Get("prefix_size").(int), d.Get("delegated_prefix_size").(int)))
+
+	return nil
+}
+
+func resourceip6delegationpoolUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if err := ip6subnetedit(d.Id(), d.Get("name").(string), d.Get("class").(string), resourceip6delegationpoolclassparameters(d), meta); err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceip6delegationpoolDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if err := ip6subnetdeletebyid(d.Id(), meta); err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	// Unset local ID
+	d.SetId("")
+
+	// Reporting a success
+	return nil
+}
+
+func resourceip6delegationpoolRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	poolInfo, err := ipsubnetinfobyid(d.Id(), true, meta)
+
+	if err != nil {
+		// Log the error
+		tflog.Debug(ctx, fmt.Sprintf("Unable to find IPv6 delegation pool (oid): %s\n", d.Id()))
+
+		// Do not unset the local ID to avoid inconsistency
+
+		// Reporting a failure
+		return diag.Errorf("Unable to find IPv6 delegation pool: %s\n", d.Get("name").(string))
+	}
+
+	start, end := hexiptobig(poolInfo["start_hex_addr"]), hexiptobig(poolInfo["end_hex_addr"])
+
+	if start != nil && end != nil {
+		prefixSize := bitsprefixlength(new(big.Int).Add(new(big.Int).Sub(end, start), big.NewInt(1)), true)
+
+		d.Set("address", hexip6toip6(poolInfo["start_hex_addr"]))
+		d.Set("prefix", hexip6toip6(poolInfo["start_hex_addr"])+"/"+strconv.Itoa(prefixSize))
+		d.Set("prefix_size", prefixSize)
+	}
+
+	d.Set("name", poolInfo["name"])
+	d.Set("class", poolInfo["class"])
+
+	// Updating local class_parameters
+	currentClassParameters := d.Get("class_parameters").(map[string]interface{})
+	retrievedClassParameters, _ := url.ParseQuery(poolInfo["class_parameters"])
+	computedClassParameters := map[string]string{}
+
+	if delegatedPrefixSize, convErr := strconv.Atoi(retrievedClassParameters.Get(ip6DelegatedPrefixSizeParameter)); convErr == nil {
+		d.Set("delegated_prefix_size", delegatedPrefixSize)
+	}
+
+	for ck := range currentClassParameters {
+		if rv, rvExist := retrievedClassParameters[ck]; rvExist {
+			computedClassParameters[ck] = rv[0]
+		} else {
+			computedClassParameters[ck] = ""
+		}
+	}
+
+	d.Set("class_parameters", computedClassParameters)
+	d.Set("capacity", ip6delegationcapacity(d.Get("prefix_size").(int), d.Get("delegated_prefix_size").(int)))
+
+	return nil
+}
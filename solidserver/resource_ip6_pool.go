@@ -20,6 +20,7 @@ func resourceip6pool() *schema.Resource {
 		ReadContext:   resourceip6poolRead,
 		UpdateContext: resourceip6poolUpdate,
 		DeleteContext: resourceip6poolDelete,
+		CustomizeDiff: resourceippooldiff(true),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceip6poolImportState,
 		},
@@ -44,18 +45,48 @@ func resourceip6pool() *schema.Resource {
 				ForceNew:    true,
 			},
 			"start": {
-				Type:         schema.TypeString,
-				Description:  "The IPv6 pool's lower IPv6 address.",
-				ValidateFunc: validation.IsIPAddress,
-				Required:     true,
-				ForceNew:     true,
+				Type:             schema.TypeString,
+				Description:      "The IPv6 pool's lower IP address, changing it moves the IPv6 pool in place when the range stays free.",
+				ValidateFunc:     validation.IsIPv6Address,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         false,
+				ExactlyOneOf:     []string{"start", "cidr"},
+				DiffSuppressFunc: resourcediffsuppressIPv6Format,
 			},
 			"end": {
+				Type:             schema.TypeString,
+				Description:      "The IPv6 pool's higher IP address, changing it resizes the IPv6 pool in place when the range stays free.",
+				ValidateFunc:     validation.IsIPv6Address,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         false,
+				ConflictsWith:    []string{"size", "cidr"},
+				DiffSuppressFunc: resourcediffsuppressIPv6Format,
+			},
+			"size": {
+				Type:          schema.TypeInt,
+				Description:   "The number of addresses of the IPv6 pool, changing it resizes the IPv6 pool in place when the range stays free. Computed as 0 when it exceeds the integer range.",
+				ValidateFunc:  validation.IntAtLeast(1),
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      false,
+				ConflictsWith: []string{"end", "cidr"},
+			},
+			"cidr": {
 				Type:         schema.TypeString,
-				Description:  "The IPv6 pool's higher IPv6 address.",
-				ValidateFunc: validation.IsIPAddress,
-				Required:     true,
-				ForceNew:     true,
+				Description:  "The IPv6 pool range in CIDR notation (ex: 2001:db8::/120), instead of its start, end or size.",
+				ValidateFunc: validation.IsCIDR,
+				Optional:     true,
+				ForceNew:     false,
+				ExactlyOneOf: []string{"start", "cidr"},
+			},
+			"read_only": {
+				Type:        schema.TypeBool,
+				Description: "Specify wether the IPv6 pool is read-only, preventing the allocation of its addresses (Default: false).",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     false,
 			},
 			"dhcp_range": {
 				Type:        schema.TypeBool,
@@ -121,8 +152,8 @@ func resourceip6poolCreate(ctx context.Context, d *schema.ResourceData, meta int
 	parameters := url.Values{}
 	parameters.Add("add_flag", "new_only")
 	parameters.Add("subnet6_id", subnetInfo["id"].(string))
-	parameters.Add("start_addr", ip6tohexip6(d.Get("start").(string)))
-	parameters.Add("end_addr", ip6tohexip6(d.Get("end").(string)))
+	parameters.Add("start_addr", bigtohexip(iptobig(d.Get("start").(string), true), true))
+	parameters.Add("end_addr", bigtohexip(iptobig(d.Get("end").(string), true), true))
 	parameters.Add("pool6_name", d.Get("name").(string))
 	parameters.Add("pool6_class_name", d.Get("class").(string))
 
@@ -131,13 +162,18 @@ func resourceip6poolCreate(ctx context.Context, d *schema.ResourceData, meta int
 
 	// Generate class parameter for dhcp range sync
 	if d.Get("dhcp_range").(bool) {
-		parameters.Add("pool6_read_only", "1")
 		classParameters.Add("dhcprange6", "1")
 	} else {
-		parameters.Add("pool6_read_only", "0")
 		classParameters.Add("dhcprange6", "0")
 	}
 
+	// DHCP ranges are synchronized from read-only pools
+	if d.Get("read_only").(bool) || d.Get("dhcp_range").(bool) {
+		parameters.Add("pool6_read_only", "1")
+	} else {
+		parameters.Add("pool6_read_only", "0")
+	}
+
 	for k, v := range d.Get("class_parameters").(map[string]interface{}) {
 		classParameters.Add(k, v.(string))
 	}
@@ -188,18 +224,29 @@ func resourceip6poolUpdate(ctx context.Context, d *schema.ResourceData, meta int
 	parameters.Add("pool6_name", d.Get("name").(string))
 	parameters.Add("pool6_class_name", d.Get("class").(string))
 
+	// Resizing the IPv6 pool in place if required
+	if d.HasChanges("start", "end", "size", "cidr") {
+		parameters.Add("start_addr", bigtohexip(iptobig(d.Get("start").(string), true), true))
+		parameters.Add("end_addr", bigtohexip(iptobig(d.Get("end").(string), true), true))
+	}
+
 	// Building class_parameters
 	classParameters := url.Values{}
 
 	// Generate class parameter for dhcp range sync
 	if d.Get("dhcp_range").(bool) {
-		parameters.Add("pool6_read_only", "1")
 		classParameters.Add("dhcprange6", "1")
 	} else {
-		parameters.Add("pool6_read_only", "0")
 		classParameters.Add("dhcprange6", "0")
 	}
 
+	// DHCP ranges are synchronized from read-only pools
+	if d.Get("read_only").(bool) || d.Get("dhcp_range").(bool) {
+		parameters.Add("pool6_read_only", "1")
+	} else {
+		parameters.Add("pool6_read_only", "0")
+	}
+
 	for k, v := range d.Get("class_parameters").(map[string]interface{}) {
 		classParameters.Add(k, v.(string))
 	}
@@ -302,9 +349,9 @@ func resourceip6poolRead(ctx context.Context, d *schema.ResourceData, meta inter
 
 			if dhcprange, dhcprangeExist := retrievedClassParameters["dhcprange6"]; dhcprangeExist {
 				if dhcprange[0] == "1" || strings.ToLower(dhcprange[0]) == "yes" {
-					d.Set("dhcprange", true)
+					d.Set("dhcp_range", true)
 				} else {
-					d.Set("dhcprange", false)
+					d.Set("dhcp_range", false)
 				}
 			}
 
@@ -318,6 +365,13 @@ func resourceip6poolRead(ctx context.Context, d *schema.ResourceData, meta inter
 
 			d.Set("class_parameters", computedClassParameters)
 
+			// DHCP range pools are always read-only
+			if !d.Get("dhcp_range").(bool) {
+				d.Set("read_only", buf[0]["pool6_read_only"] == "1")
+			}
+
+			ippoolsetrange(d, buf[0], true)
+
 			return nil
 		}
 
@@ -367,9 +421,9 @@ func resourceip6poolImportState(ctx context.Context, d *schema.ResourceData, met
 
 			if dhcprange, dhcprangeExist := retrievedClassParameters["dhcprange6"]; dhcprangeExist {
 				if dhcprange[0] == "1" || strings.ToLower(dhcprange[0]) == "yes" {
-					d.Set("dhcprange", true)
+					d.Set("dhcp_range", true)
 				} else {
-					d.Set("dhcprange", false)
+					d.Set("dhcp_range", false)
 				}
 			}
 
@@ -383,6 +437,13 @@ func resourceip6poolImportState(ctx context.Context, d *schema.ResourceData, met
 
 			d.Set("class_parameters", computedClassParameters)
 
+			// DHCP range pools are always read-only
+			if !d.Get("dhcp_range").(bool) {
+				d.Set("read_only", buf[0]["pool6_read_only"] == "1")
+			}
+
+			ippoolsetrange(d, buf[0], true)
+
 			return []*schema.ResourceData{d}, nil
 		}
 
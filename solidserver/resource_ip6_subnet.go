@@ -7,13 +7,12 @@ import (
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"math/big"
-	"math/rand"
 	"net/url"
 	"strconv"
-	"time"
 )
 
 func resourceip6subnet() *schema.Resource {
@@ -40,10 +39,36 @@ func resourceip6subnet() *schema.Resource {
 				ForceNew:    true,
 			},
 			"block": {
-				Type:        schema.TypeString,
-				Description: "The name of the block intyo which creating the IPv6 subnet.",
-				Optional:    true,
-				ForceNew:    true,
+				Type:             schema.TypeString,
+				Description:      "The name of the block intyo which creating the IPv6 subnet.",
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: resourceipsubnetdiffsuppressblock,
+			},
+			"blocks": {
+				Type:          schema.TypeList,
+				Description:   "The names of the candidate parent IPv6 blocks/subnets into which creating the IPv6 subnet, tried in order.",
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"block", "block_query"},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"block_query": {
+				Type:          schema.TypeString,
+				Description:   "The SQL WHERE clause selecting the candidate parent IPv6 blocks/subnets within the space (ex: \"subnet6_class_name='region-eu'\").",
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"block", "blocks"},
+			},
+			"allocation_strategy": {
+				Type:         schema.TypeString,
+				Description:  "The strategy used to pick the IPv6 subnet within the candidate blocks, supported values: first-fit (lowest free prefix), best-fit (smallest fitting free range), last-fit (highest free prefix) and random (Default: first-fit).",
+				ValidateFunc: validation.StringInSlice([]string{"first-fit", "best-fit", "last-fit", "random"}, false),
+				Optional:     true,
+				ForceNew:     false,
+				Default:      "first-fit",
 			},
 			"request_ip": {
 				Type:         schema.TypeString,
@@ -55,9 +80,9 @@ func resourceip6subnet() *schema.Resource {
 			},
 			"prefix_size": {
 				Type:        schema.TypeInt,
-				Description: "The expected IPv6 subnet's prefix length (ex: 24 for a '/24').",
+				Description: "The expected IPv6 subnet's prefix length (ex: 24 for a '/24'), changing it resizes the subnet in place when the neighbouring space is free.",
 				Required:    true,
-				ForceNew:    true,
+				ForceNew:    false,
 			},
 			"prefix": {
 				Type:        schema.TypeString,
@@ -81,7 +106,6 @@ func resourceip6subnet() *schema.Resource {
 				Type:        schema.TypeString,
 				Description: "The subnet's computed gateway.",
 				Computed:    true,
-				ForceNew:    true,
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -117,6 +141,7 @@ func resourceip6subnet() *schema.Resource {
 				ForceNew:    false,
 				Default:     "",
 			},
+			"reserved_addresses": ipsubnetreservedschema(),
 			"class_parameters": {
 				Type:        schema.TypeMap,
 				Description: "The class parameters associated to the IPv6 subnet.",
@@ -127,11 +152,13 @@ func resourceip6subnet() *schema.Resource {
 				},
 			},
 		},
+		CustomizeDiff: customdiff.All(
+			resourceipsubnetresizediff(true),
+		),
 	}
 }
 
 func resourceip6subnetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	blockInfo := make(map[string]interface{})
 	s := meta.(*SOLIDserver)
 	var gateway string = ""
 	vlmVlanID := ""
@@ -159,45 +186,60 @@ func resourceip6subnetCreate(ctx context.Context, d *schema.ResourceData, meta i
 		}
 	}
 
-	// If a block is specified, look for free IP subnet within this block
-	if len(d.Get("block").(string)) > 0 {
-		var blockErr error = nil
+	// Gather the candidate blocks into which looking for a free IPv6 subnet
+	blocks, blocksErr := ipsubnetcandidateblocks(siteID, d, true, meta)
 
-		blockInfo, blockErr = ip6subnetinfobyname(siteID, d.Get("block").(string), false, meta)
+	if blocksErr != nil {
+		// Reporting a failure
+		return diag.FromErr(blocksErr)
+	}
 
-		if blockErr != nil {
-			// Reporting a failure
-			return diag.FromErr(blockErr)
-		}
-	} else {
-		// Otherwise, set an empty blockInfo's ID by default
-		blockInfo["id"] = ""
+	// However, we can't create a block as a terminal subnet
+	if blocks[0]["id"].(string) == "" && d.Get("terminal").(bool) {
+		return diag.Errorf("Can't create a terminal IPv6 block subnet: %s", d.Get("name").(string))
+	}
 
-		// However, we can't create a block as a terminal subnet
-		if d.Get("terminal").(bool) {
-			return diag.Errorf("Can't create a terminal IPv6 block subnet: %s", d.Get("name").(string))
+	// Serializing the allocations within the candidate blocks
+	allocationKeys := []string{}
+
+	for _, blockInfo := range blocks {
+		if blockID, _ := blockInfo["id"].(string); len(blockID) > 0 {
+			allocationKeys = append(allocationKeys, "ip6_block:"+blockID)
+		} else {
+			allocationKeys = append(allocationKeys, "ip6_space:"+siteID)
 		}
 	}
 
-	subnetAddresses, subnetErr := ip6subnetfindbysize(siteID, blockInfo["id"].(string), d.Get("request_ip").(string), d.Get("prefix_size").(int), meta)
+	unlock := s.Allocations.lock(allocationKeys...)
+	defer unlock()
+
+	candidates, subnetErr := ipsubnetallocationcandidates(siteID, blocks, d.Get("request_ip").(string), d.Get("prefix_size").(int), d.Get("allocation_strategy").(string), true, meta)
 
 	if subnetErr != nil {
 		// Reporting a failure
 		return diag.FromErr(subnetErr)
 	}
 
-	for i := 0; i < len(subnetAddresses); i++ {
+	for i := 0; i < len(candidates); i++ {
+		blockInfo := candidates[i].Block
+		subnetAddress := candidates[i].Address
+
+		// Skipping the prefixes already allocated by parallel creations
+		if s.Allocations.isclaimed("ip6_space:"+siteID, subnetAddress+"/"+strconv.Itoa(d.Get("prefix_size").(int))) {
+			continue
+		}
+
 		// Building parameters
 		parameters := url.Values{}
 		parameters.Add("site_id", siteID)
 		parameters.Add("add_flag", "new_only")
 		parameters.Add("subnet6_name", d.Get("name").(string))
-		parameters.Add("subnet6_addr", hexip6toip6(subnetAddresses[i]))
+		parameters.Add("subnet6_addr", hexip6toip6(subnetAddress))
 		parameters.Add("subnet6_prefix", strconv.Itoa(d.Get("prefix_size").(int)))
 		parameters.Add("subnet6_class_name", d.Get("class").(string))
 
 		// If no block specified, create an IP block
-		if len(d.Get("block").(string)) == 0 {
+		if len(blockInfo["id"].(string)) == 0 {
 			parameters.Add("subnet_level", "0")
 		} else {
 			parameters.Add("use_reversed_relative_position", "1")
@@ -224,7 +266,7 @@ func resourceip6subnetCreate(ctx context.Context, d *schema.ResourceData, meta i
 		goffset := d.Get("gateway_offset").(int)
 
 		if goffset != 0 {
-			bigStartAddr, _ := new(big.Int).SetString(subnetAddresses[i], 16)
+			bigStartAddr, _ := new(big.Int).SetString(subnetAddress, 16)
 
 			if goffset > 0 {
 				bigOffset := big.NewInt(int64(goffset))
@@ -244,9 +286,6 @@ func resourceip6subnetCreate(ctx context.Context, d *schema.ResourceData, meta i
 		}
 		parameters.Add("subnet6_class_parameters", classParameters.Encode())
 
-		// Random Delay
-		time.Sleep(time.Duration(rand.Intn(1000)) * time.Millisecond)
-
 		// Sending the creation request
 		resp, body, err := s.Request("post", "rest/ip6_subnet6_add", &parameters)
 
@@ -254,19 +293,20 @@ func resourceip6subnetCreate(ctx context.Context, d *schema.ResourceData, meta i
 			var buf [](map[string]interface{})
 			json.Unmarshal([]byte(body), &buf)
 
-			prefix := hexip6toip6(subnetAddresses[i]) + "/" + strconv.Itoa(d.Get("prefix_size").(int))
+			prefix := hexip6toip6(subnetAddress) + "/" + strconv.Itoa(d.Get("prefix_size").(int))
 
 			// Checking the answer
 			if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 				if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 					tflog.Debug(ctx, fmt.Sprintf("Created IPv6 subnet (oid): %s\n", oid))
+					s.Allocations.claim("ip6_space:"+siteID, subnetAddress+"/"+strconv.Itoa(d.Get("prefix_size").(int)))
 					d.SetId(oid)
 					d.Set("prefix", prefix)
-					d.Set("address", hexip6toip6(subnetAddresses[i]))
+					d.Set("address", hexip6toip6(subnetAddress))
 					if goffset != 0 {
 						d.Set("gateway", gateway)
 					}
-					return nil
+					return ipsubnetreservedapply(ctx, d, siteID, true, meta)
 				}
 			} else {
 				if len(buf) > 0 {
@@ -300,6 +340,12 @@ func resourceip6subnetUpdate(ctx context.Context, d *schema.ResourceData, meta i
 	parameters.Add("subnet6_name", d.Get("name").(string))
 	parameters.Add("subnet6_class_name", d.Get("class").(string))
 
+	// Resizing the subnet in place if required
+	if d.HasChange("prefix_size") {
+		parameters.Add("subnet6_addr", d.Get("address").(string))
+		parameters.Add("subnet6_prefix", strconv.Itoa(d.Get("prefix_size").(int)))
+	}
+
 	if d.Get("terminal").(bool) {
 		parameters.Add("is_terminal", "1")
 	} else {
@@ -334,6 +380,14 @@ func resourceip6subnetUpdate(ctx context.Context, d *schema.ResourceData, meta i
 	// Generate class parameter for the gateway if required
 	goffset := d.Get("gateway_offset").(int)
 
+	// Moving the gateway along with the end of the subnet when resized
+	if goffset < 0 && d.HasChange("prefix_size") {
+		bigStartAddr, _ := new(big.Int).SetString(ip6tohexip6(d.Get("address").(string)), 16)
+		bigEndAddr := bigStartAddr.Add(bigStartAddr, prefix6lengthtosize(int64(d.Get("prefix_size").(int))))
+		bigOffset := big.NewInt(int64(abs(goffset)))
+		d.Set("gateway", hexip6toip6(BigIntToHexStr(bigEndAddr.Sub(bigEndAddr, bigOffset))))
+	}
+
 	if goffset != 0 {
 		classParameters.Add("gateway", d.Get("gateway").(string))
 		tflog.Debug(ctx, fmt.Sprintf("Subnet updated gateway: %s\n", d.Get("gateway").(string)))
@@ -357,7 +411,8 @@ func resourceip6subnetUpdate(ctx context.Context, d *schema.ResourceData, meta i
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Updated IPv6 subnet (oid): %s\n", oid))
 				d.SetId(oid)
-				return nil
+				d.Set("prefix", d.Get("address").(string)+"/"+strconv.Itoa(d.Get("prefix_size").(int)))
+				return ipsubnetreservedupdate(ctx, d, true, meta)
 			}
 		}
 
@@ -421,6 +476,12 @@ func resourceip6subnetgatewayDelete(ctx context.Context, d *schema.ResourceData,
 func resourceip6subnetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
+	// Release the reserved addresses
+	if err := ipsubnetreservedclear(ctx, d.Get("reserved_addresses").([]interface{}), true, meta); err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
 	// Delete related resources such as the Gateway
 	if d.Get("gateway_offset") != 0 {
 		resourceip6subnetgatewayDelete(ctx, d, meta)
@@ -518,6 +579,12 @@ func resourceip6subnetRead(ctx context.Context, d *schema.ResourceData, meta int
 
 			d.Set("class_parameters", computedClassParameters)
 
+			// Refreshing the reserved addresses
+			if reservedErr := ipsubnetreservedread(ctx, d, true, meta); reservedErr != nil {
+				// Reporting a failure
+				return diag.FromErr(reservedErr)
+			}
+
 			return nil
 		}
 
@@ -559,6 +626,7 @@ func resourceip6subnetImportState(ctx context.Context, d *schema.ResourceData, m
 		if resp.StatusCode == 200 && len(buf) > 0 {
 			d.Set("space", buf[0]["site_name"].(string))
 			d.Set("block", buf[0]["parent_subnet6_name"].(string))
+			d.Set("allocation_strategy", "first-fit")
 			d.Set("name", buf[0]["subnet6_name"].(string))
 			d.Set("class", buf[0]["subnet6_class_name"].(string))
 
@@ -15,7 +15,7 @@ import (
 )
 
 func resourceipaddress() *schema.Resource {
-	return &schema.Resource{
+	resource := &schema.Resource{
 		CreateContext: resourceipaddressCreate,
 		ReadContext:   resourceipaddressRead,
 		UpdateContext: resourceipaddressUpdate,
@@ -23,10 +23,12 @@ func resourceipaddress() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceipaddressImportState,
 		},
+		CustomizeDiff: resourceipaddressmovediff,
 
 		Description: heredoc.Doc(`
 			IP address resource allows to create and manage reserved addresses for specific devices, apps or users.
 			More importantly it allows to store useful meta-data for both tracking and automation purposes.
+			Changing the space, subnet, pool or requested address moves the IP address in place, keeping its meta-data, device and aliases.
 		`),
 
 		Schema: map[string]*schema.Schema{
@@ -34,19 +36,19 @@ func resourceipaddress() *schema.Resource {
 				Type:        schema.TypeString,
 				Description: "The name of the space into which creating the IP address.",
 				Required:    true,
-				ForceNew:    true,
+				ForceNew:    false,
 			},
 			"subnet": {
 				Type:        schema.TypeString,
 				Description: "The name of the subnet into which creating the IP address.",
 				Required:    true,
-				ForceNew:    true,
+				ForceNew:    false,
 			},
 			"pool": {
 				Type:        schema.TypeString,
 				Description: "The name of the pool into which creating the IP address.",
 				Optional:    true,
-				ForceNew:    true,
+				ForceNew:    false,
 				Default:     "",
 			},
 			"request_ip": {
@@ -54,14 +56,47 @@ func resourceipaddress() *schema.Resource {
 				Description:  "The optionally requested IP address.",
 				ValidateFunc: validation.IsIPAddress,
 				Optional:     true,
-				ForceNew:     true,
+				ForceNew:     false,
 				Default:      "",
 			},
+			"allocation_strategy": {
+				Type:         schema.TypeString,
+				Description:  "The strategy used to pick a free IP address when no address is requested, either lowest, highest or random (Default: lowest).",
+				ValidateFunc: validation.StringInSlice([]string{"lowest", "highest", "random"}, false),
+				Optional:     true,
+				ForceNew:     false,
+				Default:      "lowest",
+			},
+			"skip_first": {
+				Type:         schema.TypeInt,
+				Description:  "The number of addresses at the beginning of the subnet (or pool) never picked when no address is requested (Default: 0).",
+				ValidateFunc: validation.IntAtLeast(0),
+				Optional:     true,
+				ForceNew:     false,
+				Default:      0,
+			},
+			"skip_last": {
+				Type:         schema.TypeInt,
+				Description:  "The number of addresses at the end of the subnet (or pool) never picked when no address is requested (Default: 0).",
+				ValidateFunc: validation.IntAtLeast(0),
+				Optional:     true,
+				ForceNew:     false,
+				Default:      0,
+			},
+			"exclude": {
+				Type:        schema.TypeList,
+				Description: "The addresses, prefixes or ranges (ex: 10.0.0.10-10.0.0.20) never picked when no address is requested.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateiprange(false),
+				},
+			},
 			"address": {
 				Type:        schema.TypeString,
 				Description: "The provisionned IP address.",
 				Computed:    true,
-				ForceNew:    true,
 			},
 			"device": {
 				Type:        schema.TypeString,
@@ -103,9 +138,37 @@ func resourceipaddress() *schema.Resource {
 			},
 		},
 	}
+
+	// Adding the DNS records management attributes
+	for k, v := range ipaddressdnsschema() {
+		resource.Schema[k] = v
+	}
+
+	return resource
 }
 
 func resourceipaddressCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	oid, address, diags := resourceipaddressallocate(ctx, d, url.Values{}, meta)
+
+	if diags != nil {
+		// Reporting a failure
+		return diags
+	}
+
+	d.SetId(oid)
+	d.Set("address", address)
+
+	// Registering the DNS records
+	if dnsErr := ipaddressdnsapply(d, address, false, meta); dnsErr != nil {
+		return diag.FromErr(dnsErr)
+	}
+
+	return nil
+}
+
+// Allocate and register a new IP address from the configuration, return its oid and address
+// The inherited parameters complete the configured ones when moving an existing IP address
+func resourceipaddressallocate(ctx context.Context, d *schema.ResourceData, inherited url.Values, meta interface{}) (string, string, diag.Diagnostics) {
 	s := meta.(*SOLIDserver)
 
 	var requestedHexIP string = iptohexip(d.Get("request_ip").(string))
@@ -118,13 +181,13 @@ func resourceipaddressCreate(ctx context.Context, d *schema.ResourceData, meta i
 
 	if siteErr != nil {
 		// Reporting a failure
-		return diag.FromErr(siteErr)
+		return "", "", diag.FromErr(siteErr)
 	}
 
 	//subnetID, subnetErr := ipsubnetidbyname(siteID, d.Get("subnet").(string), true, meta)
 	//if subnetErr != nil {
 	//	// Reporting a failure
-	//	return diag.FromErr(subnetErr)
+	//	return "", "", diag.FromErr(subnetErr)
 	//}
 
 	subnetInfo, subnetErr := ipsubnetinfobyname(siteID, d.Get("subnet").(string), true, meta)
@@ -132,10 +195,10 @@ func resourceipaddressCreate(ctx context.Context, d *schema.ResourceData, meta i
 	if subnetInfo == nil || subnetErr != nil {
 		// Reporting a failure
 		if subnetInfo == nil {
-			return diag.Errorf("Unable to create IP address: %s, unable to find requested network\n", d.Get("name").(string))
+			return "", "", diag.Errorf("Unable to create IP address: %s, unable to find requested network\n", d.Get("name").(string))
 		}
 
-		return diag.FromErr(subnetErr)
+		return "", "", diag.FromErr(subnetErr)
 	}
 
 	if len(d.Get("pool").(string)) > 0 {
@@ -144,7 +207,7 @@ func resourceipaddressCreate(ctx context.Context, d *schema.ResourceData, meta i
 		poolInfo, poolErr = ippoolinfobyname(siteID, d.Get("pool").(string), d.Get("subnet").(string), meta)
 		if poolErr != nil {
 			// Reporting a failure
-			return diag.FromErr(poolErr)
+			return "", "", diag.FromErr(poolErr)
 		}
 	}
 
@@ -155,10 +218,15 @@ func resourceipaddressCreate(ctx context.Context, d *schema.ResourceData, meta i
 		deviceID, deviceErr = hostdevidbyname(d.Get("device").(string), meta)
 		if deviceErr != nil {
 			// Reporting a failure
-			return diag.FromErr(deviceErr)
+			return "", "", diag.FromErr(deviceErr)
 		}
 	}
 
+	// Serializing the allocations within the subnet
+	allocationKey := "ip_subnet:" + subnetInfo["id"].(string)
+	unlock := s.Allocations.lock(allocationKey)
+	defer unlock()
+
 	// Determining if an IP address was submitted in or if we should get one from the IPAM
 	if len(d.Get("request_ip").(string)) > 0 {
 		// Ensure IP Address is within the given subnet start and end IP addresses
@@ -168,12 +236,12 @@ func resourceipaddressCreate(ctx context.Context, d *schema.ResourceData, meta i
 
 			if poolInfo != nil && (strings.Compare(poolInfo["start_hex_addr"].(string), requestedHexIP) == 1 ||
 				strings.Compare(requestedHexIP, poolInfo["end_hex_addr"].(string)) == 1) {
-				return diag.Errorf("Unable to create IP address: %s, address is out of pool's range\n", d.Get("name").(string))
+				return "", "", diag.Errorf("Unable to create IP address: %s, address is out of pool's range\n", d.Get("name").(string))
 			}
 
 			ipAddresses = []string{d.Get("request_ip").(string)}
 		} else {
-			return diag.Errorf("Unable to create IP address: %s, address is out of network's range\n", d.Get("name").(string))
+			return "", "", diag.Errorf("Unable to create IP address: %s, address is out of network's range\n", d.Get("name").(string))
 		}
 	} else {
 		var poolID string = ""
@@ -183,12 +251,22 @@ func resourceipaddressCreate(ctx context.Context, d *schema.ResourceData, meta i
 			poolID = poolInfo["id"].(string)
 		}
 
-		ipAddresses, ipErr = ipaddressfindfree(subnetInfo["id"].(string), poolID, meta)
+		policy, policyErr := ipaddresspolicyfromresource(d, false)
+
+		if policyErr != nil {
+			// Reporting a failure
+			return "", "", diag.FromErr(policyErr)
+		}
+
+		ipAddresses, ipErr = ipaddressfindfree(subnetInfo["id"].(string), poolID, policy, meta)
 
 		if ipErr != nil {
 			// Reporting a failure
-			return diag.FromErr(ipErr)
+			return "", "", diag.FromErr(ipErr)
 		}
+
+		// Skipping the addresses already allocated by parallel creations
+		ipAddresses = s.Allocations.filter(allocationKey, ipAddresses)
 	}
 
 	for i := 0; i < len(ipAddresses); i++ {
@@ -208,6 +286,11 @@ func resourceipaddressCreate(ctx context.Context, d *schema.ResourceData, meta i
 		// Building class_parameters
 		parameters.Add("ip_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
 
+		// Inheriting the meta-data of a moved IP address
+		for k := range inherited {
+			parameters.Set(k, inherited.Get(k))
+		}
+
 		// Sending the creation request
 		resp, body, err := s.Request("post", "rest/ip_add", &parameters)
 
@@ -219,9 +302,8 @@ func resourceipaddressCreate(ctx context.Context, d *schema.ResourceData, meta i
 			if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 				if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 					tflog.Debug(ctx, fmt.Sprintf("Created IP address (oid): %s\n", oid))
-					d.SetId(oid)
-					d.Set("address", ipAddresses[i])
-					return nil
+					s.Allocations.claim(allocationKey, ipAddresses[i])
+					return oid, ipAddresses[i], nil
 				}
 			} else {
 				if len(buf) > 0 {
@@ -236,17 +318,71 @@ func resourceipaddressCreate(ctx context.Context, d *schema.ResourceData, meta i
 			}
 		} else {
 			// Reporting a failure
-			return diag.FromErr(err)
+			return "", "", diag.FromErr(err)
 		}
 	}
 
 	// Reporting a failure
-	return diag.Errorf("Unable to create IP address: %s, unable to find a suitable network or address\n", d.Get("name").(string))
+	return "", "", diag.Errorf("Unable to create IP address: %s, unable to find a suitable network or address\n", d.Get("name").(string))
+}
+
+// Move an IP address to a new subnet, pool or address
+// The new IP address is created first with the same meta-data, then the aliases are moved and the previous one is deleted
+func resourceipaddressmove(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	previousID := d.Id()
+
+	inherited, err := ipaddressinherited(d, previousID, false, meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	oid, address, diags := resourceipaddressallocate(ctx, d, inherited, meta)
+
+	if diags != nil {
+		// Reporting a failure
+		return diags
+	}
+
+	// Moving the aliases
+	if aliasErr := ipaddressmovealiases(previousID, oid, false, meta); aliasErr != nil {
+		// Rolling back the new IP address
+		if delErr := ipaddressdeletebyid(oid, false, meta); delErr != nil {
+			tflog.Debug(ctx, fmt.Sprintf("Unable to roll back the move of IP address: %s (%s)\n", d.Get("name").(string), delErr))
+		}
+
+		// Reporting a failure
+		return diag.FromErr(aliasErr)
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Moved IP address (oid): %s to %s (oid): %s\n", previousID, address, oid))
+
+	d.SetId(oid)
+	d.Set("address", address)
+
+	// Removing the previous IP address
+	if delErr := ipaddressdeletebyid(previousID, false, meta); delErr != nil {
+		// Reporting a failure
+		return diag.FromErr(delErr)
+	}
+
+	// Moving the DNS records
+	if dnsErr := ipaddressdnsapply(d, address, false, meta); dnsErr != nil {
+		return diag.FromErr(dnsErr)
+	}
+
+	return nil
 }
 
 func resourceipaddressUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
+	// Moving the IP address when its subnet, pool or requested address changed
+	if ipaddressmoverequired(d) {
+		return resourceipaddressmove(ctx, d, meta)
+	}
+
 	var deviceID string = ""
 
 	// Retrieving device ID
@@ -288,6 +424,14 @@ func resourceipaddressUpdate(ctx context.Context, d *schema.ResourceData, meta i
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Updated IP address (oid): %s\n", oid))
 				d.SetId(oid)
+
+				// Maintaining the DNS records
+				if ipaddressdnshaschange(d) {
+					if dnsErr := ipaddressdnsapply(d, d.Get("address").(string), false, meta); dnsErr != nil {
+						return diag.FromErr(dnsErr)
+					}
+				}
+
 				return nil
 			}
 		}
@@ -309,6 +453,11 @@ func resourceipaddressUpdate(ctx context.Context, d *schema.ResourceData, meta i
 func resourceipaddressDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
+	// Removing the DNS records
+	if dnsErr := ipaddressdnsclear(d, meta); dnsErr != nil {
+		return diag.FromErr(dnsErr)
+	}
+
 	// Building parameters
 	parameters := url.Values{}
 	parameters.Add("ip_id", d.Id())
@@ -391,6 +540,12 @@ func resourceipaddressRead(ctx context.Context, d *schema.ResourceData, meta int
 
 			d.Set("class_parameters", computedClassParameters)
 
+			// Refreshing the DNS records
+			if dnsErr := ipaddressdnsread(d, meta); dnsErr != nil {
+				// Reporting a failure
+				return diag.FromErr(dnsErr)
+			}
+
 			return nil
 		}
 
@@ -436,6 +591,9 @@ func resourceipaddressImportState(ctx context.Context, d *schema.ResourceData, m
 			d.Set("name", buf[0]["name"].(string))
 			d.Set("mac", buf[0]["mac_addr"].(string))
 			d.Set("class", buf[0]["ip_class_name"].(string))
+			d.Set("allocation_strategy", "lowest")
+			d.Set("create_ptr", true)
+			d.Set("ttl", 3600)
 			d.Set("pool", buf[0]["pool_name"].(string))
 
 			// Updating local class_parameters
@@ -0,0 +1,588 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"math/big"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+func resourceipaddressrange() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceipaddressrangeCreate,
+		ReadContext:   resourceipaddressrangeRead,
+		UpdateContext: resourceipaddressrangeUpdate,
+		DeleteContext: resourceipaddressrangeDelete,
+		CustomizeDiff: resourceipaddressrangediff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceipaddressrangeImportState,
+		},
+
+		Description: heredoc.Doc(`
+			IP address range resource allows to reserve a run of contiguous IP addresses within a subnet or a pool,
+			sharing a common name prefix, class and class parameters (ex: Kubernetes node pools or load-balancer ranges).
+			All the IP addresses are created or none: a failure during the creation removes the IP addresses already created.
+			The run can grow or shrink at its end. Only the IP addresses named after the name prefix and their position
+			are considered as part of the range, the other IP addresses registered within the run are never modified.
+			The IP addresses of the range deleted outside of Terraform are recreated on the next apply.
+			The import ID is formatted as <space>/<subnet>/<pool>/<first>/<name_prefix> (ex: mySpace/mySubnet//10.0.0.10/k8s-node-).
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space into which creating the IP addresses.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"subnet": {
+				Type:        schema.TypeString,
+				Description: "The name of the subnet into which creating the IP addresses.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"pool": {
+				Type:        schema.TypeString,
+				Description: "The name of the pool into which creating the IP addresses.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "",
+			},
+			"request_ip": {
+				Type:         schema.TypeString,
+				Description:  "The optionally requested first IP address of the range.",
+				ValidateFunc: validation.IsIPv4Address,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "",
+			},
+			"size": {
+				Type:         schema.TypeInt,
+				Description:  "The number of contiguous IP addresses to reserve.",
+				ValidateFunc: validation.IntBetween(1, 1024),
+				Required:     true,
+				ForceNew:     false,
+			},
+			"name_prefix": {
+				Type:        schema.TypeString,
+				Description: "The prefix of the IP addresses names, suffixed with their position within the range starting at 1 (ex: k8s-node- gives k8s-node-1, k8s-node-2 ...).",
+				Required:    true,
+				ForceNew:    false,
+			},
+			"class": {
+				Type:        schema.TypeString,
+				Description: "The class associated to the IP addresses.",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     "",
+			},
+			"class_parameters": {
+				Type:        schema.TypeMap,
+				Description: "The class parameters associated to the IP addresses.",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"first": {
+				Type:        schema.TypeString,
+				Description: "The first IP address of the range.",
+				Computed:    true,
+			},
+			"last": {
+				Type:        schema.TypeString,
+				Description: "The last IP address of the range.",
+				Computed:    true,
+			},
+			"addresses": {
+				Type:        schema.TypeList,
+				Description: "The IP addresses of the range ordered by address, empty for the IP addresses no longer existing.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// Plan the recreation of the IP addresses of the range deleted outside of Terraform
+func resourceipaddressrangediff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+
+	for _, address := range d.Get("addresses").([]interface{}) {
+		if address == nil || address.(string) == "" {
+			return d.SetNewComputed("addresses")
+		}
+	}
+
+	return nil
+}
+
+// Return the name of the IP address at the given position (starting at 0) of the range
+func resourceipaddressrangename(d *schema.ResourceData, position int) string {
+	return ipaddressrangename(d.Get("name_prefix").(string), position)
+}
+
+// Return the name of the IP address at the given position (starting at 0) of a range with the given name prefix
+func ipaddressrangename(namePrefix string, position int) string {
+	return namePrefix + strconv.Itoa(position+1)
+}
+
+// Create or update (oid not empty) an IP address of the range
+// Return the oid of the IP address
+func resourceipaddressrangeset(d *schema.ResourceData, siteID string, oid string, address *big.Int, position int, meta interface{}) (string, error) {
+	s := meta.(*SOLIDserver)
+	method := "post"
+
+	// Building parameters
+	parameters := url.Values{}
+
+	if oid == "" {
+		parameters.Add("site_id", siteID)
+		parameters.Add("add_flag", "new_only")
+		parameters.Add("hostaddr", hexiptoip(bigtohexip(address, false)))
+	} else {
+		method = "put"
+		parameters.Add("ip_id", oid)
+		parameters.Add("add_flag", "edit_only")
+	}
+
+	parameters.Add("ip_name", resourceipaddressrangename(d, position))
+	parameters.Add("ip_class_name", d.Get("class").(string))
+
+	// Building class_parameters
+	parameters.Add("ip_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+
+	// Sending the creation/update request
+	resp, body, err := s.Request(method, "rest/ip_add", &parameters)
+
+	if err != nil {
+		return "", err
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	// Checking the answer
+	if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+		if retOid, retOidExist := buf[0]["ret_oid"].(string); retOidExist {
+			return retOid, nil
+		}
+	}
+
+	if len(buf) > 0 {
+		if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+			return "", fmt.Errorf("SOLIDServer - Unable to register IP address: %s (%s)\n", resourceipaddressrangename(d, position), errMsg)
+		}
+	}
+
+	return "", fmt.Errorf("SOLIDServer - Unable to register IP address: %s\n", resourceipaddressrangename(d, position))
+}
+
+// Create the IP addresses of the range from position `from` to `to` (excluded)
+// The IP addresses already created are removed in case of failure
+func resourceipaddressrangecreate(ctx context.Context, d *schema.ResourceData, siteID string, first *big.Int, from int, to int, meta interface{}) ([]string, error) {
+	oids := []string{}
+
+	for i := from; i < to; i++ {
+		address := new(big.Int).Add(first, big.NewInt(int64(i)))
+		oid, err := resourceipaddressrangeset(d, siteID, "", address, i, meta)
+
+		if err != nil {
+			// Rolling back the IP addresses already created
+			for _, created := range oids {
+				if delErr := ipaddressdeletebyid(created, false, meta); delErr != nil {
+					tflog.Debug(ctx, fmt.Sprintf("Unable to roll back IP address (oid): %s (%s)\n", created, delErr))
+				}
+			}
+
+			return nil, err
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Created IP address (oid): %s\n", oid))
+		oids = append(oids, oid)
+	}
+
+	return oids, nil
+}
+
+// Return the IP addresses of the range indexed by position
+// Only the IP addresses named after the name prefix and their position belong to the range
+func resourceipaddressrangelist(siteID string, first *big.Int, count int, namePrefix string, meta interface{}) (map[int]map[string]string, error) {
+	res := map[int]map[string]string{}
+	last := new(big.Int).Add(first, big.NewInt(int64(count-1)))
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("WHERE", "site_id='"+siteID+"' AND ip_addr>='"+bigtohexip(first, false)+"' AND ip_addr<='"+bigtohexip(last, false)+"'")
+
+	addresses, err := solidserverlist("ip_address_list", parameters, 0, meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, address := range addresses {
+		attributes := restobjectattributes(address)
+
+		if attributes["type"] == "free" {
+			continue
+		}
+
+		if addr := hexiptobig(attributes["ip_addr"]); addr != nil {
+			position := int(new(big.Int).Sub(addr, first).Int64())
+
+			if attributes["name"] == ipaddressrangename(namePrefix, position) {
+				res[position] = attributes
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func resourceipaddressrangeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	var poolID string = ""
+	var requested *big.Int = nil
+
+	// Gather required ID(s) from provided information
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+	if siteErr != nil {
+		// Reporting a failure
+		return diag.FromErr(siteErr)
+	}
+
+	subnetInfo, subnetErr := ipsubnetinfobyname(siteID, d.Get("subnet").(string), true, meta)
+
+	if subnetErr != nil {
+		// Reporting a failure
+		return diag.FromErr(subnetErr)
+	}
+
+	if len(d.Get("pool").(string)) > 0 {
+		poolInfo, poolErr := ippoolinfobyname(siteID, d.Get("pool").(string), d.Get("subnet").(string), meta)
+
+		if poolErr != nil {
+			// Reporting a failure
+			return diag.FromErr(poolErr)
+		}
+
+		poolID = poolInfo["id"].(string)
+	}
+
+	// Serializing the allocations within the subnet
+	unlock := meta.(*SOLIDserver).Allocations.lock("ip_subnet:" + subnetInfo["id"].(string))
+	defer unlock()
+
+	if len(d.Get("request_ip").(string)) > 0 {
+		requested = hexiptobig(iptohexip(d.Get("request_ip").(string)))
+	}
+
+	// Looking for a contiguous run of free IP addresses
+	free, freeErr := ipaddressfreeranges(subnetInfo["id"].(string), poolID, ipAddressPolicy{}, false, meta)
+
+	if freeErr != nil {
+		// Reporting a failure
+		return diag.FromErr(freeErr)
+	}
+
+	first := ipcontiguousrun(free, d.Get("size").(int), requested)
+
+	if first == nil {
+		return diag.Errorf("Unable to create IP address range: %s, unable to find %d contiguous free addresses\n", d.Get("name_prefix").(string), d.Get("size").(int))
+	}
+
+	oids, err := resourceipaddressrangecreate(ctx, d, siteID, first, 0, d.Get("size").(int), meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	d.SetId(oids[0])
+	d.Set("first", hexiptoip(bigtohexip(first, false)))
+
+	return resourceipaddressrangeRead(ctx, d, meta)
+}
+
+func resourceipaddressrangeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	first := hexiptobig(iptohexip(d.Get("first").(string)))
+	oldSize, newSize := d.GetChange("size")
+	oldNamePrefix, _ := d.GetChange("name_prefix")
+
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+	if siteErr != nil {
+		// Reporting a failure
+		return diag.FromErr(siteErr)
+	}
+
+	current, err := resourceipaddressrangelist(siteID, first, oldSize.(int), oldNamePrefix.(string), meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	// Shrinking: removing the IP addresses at the end of the range
+	for i := oldSize.(int) - 1; i >= newSize.(int); i-- {
+		if address, addressExist := current[i]; addressExist {
+			if delErr := ipaddressdeletebyid(address["ip_id"], false, meta); delErr != nil {
+				// Reporting a failure
+				return diag.FromErr(delErr)
+			}
+
+			tflog.Debug(ctx, fmt.Sprintf("Deleted IP address (oid): %s\n", address["ip_id"]))
+		}
+	}
+
+	// Updating the remaining IP addresses
+	if d.HasChange("name_prefix") || d.HasChange("class") || d.HasChange("class_parameters") {
+		for i := 0; i < oldSize.(int) && i < newSize.(int); i++ {
+			if address, addressExist := current[i]; addressExist {
+				if _, setErr := resourceipaddressrangeset(d, siteID, address["ip_id"], nil, i, meta); setErr != nil {
+					// Reporting a failure
+					return diag.FromErr(setErr)
+				}
+			}
+		}
+	}
+
+	// Looking for the IP addresses of the range deleted outside of Terraform
+	missing := []int{}
+
+	for i := 0; i < oldSize.(int) && i < newSize.(int); i++ {
+		if _, addressExist := current[i]; !addressExist {
+			missing = append(missing, i)
+		}
+	}
+
+	if len(missing) == 0 && newSize.(int) <= oldSize.(int) {
+		return resourceipaddressrangeRead(ctx, d, meta)
+	}
+
+	subnetID, subnetErr := ipsubnetidbyname(siteID, d.Get("subnet").(string), true, meta)
+
+	if subnetErr != nil {
+		// Reporting a failure
+		return diag.FromErr(subnetErr)
+	}
+
+	// Serializing the allocations within the subnet
+	unlock := meta.(*SOLIDserver).Allocations.lock("ip_subnet:" + subnetID)
+	defer unlock()
+
+	// Recreating the missing IP addresses at their position
+	for _, i := range missing {
+		if _, createErr := resourceipaddressrangecreate(ctx, d, siteID, first, i, i+1, meta); createErr != nil {
+			// Reporting a failure
+			return diag.FromErr(createErr)
+		}
+	}
+
+	// Growing: the addresses following the range must be free
+	if newSize.(int) > oldSize.(int) {
+		var poolID string = ""
+
+		if len(d.Get("pool").(string)) > 0 {
+			poolInfo, poolErr := ippoolinfobyname(siteID, d.Get("pool").(string), d.Get("subnet").(string), meta)
+
+			if poolErr != nil {
+				// Reporting a failure
+				return diag.FromErr(poolErr)
+			}
+
+			poolID = poolInfo["id"].(string)
+		}
+
+		free, freeErr := ipaddressfreeranges(subnetID, poolID, ipAddressPolicy{}, false, meta)
+
+		if freeErr != nil {
+			// Reporting a failure
+			return diag.FromErr(freeErr)
+		}
+
+		next := new(big.Int).Add(first, big.NewInt(int64(oldSize.(int))))
+
+		if ipcontiguousrun(free, newSize.(int)-oldSize.(int), next) == nil {
+			return diag.Errorf("Unable to grow IP address range: %s, the %d addresses following %s are not free\n", d.Get("name_prefix").(string), newSize.(int)-oldSize.(int), d.Get("last").(string))
+		}
+
+		if _, createErr := resourceipaddressrangecreate(ctx, d, siteID, first, oldSize.(int), newSize.(int), meta); createErr != nil {
+			// Reporting a failure
+			return diag.FromErr(createErr)
+		}
+	}
+
+	return resourceipaddressrangeRead(ctx, d, meta)
+}
+
+func resourceipaddressrangeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	first := hexiptobig(iptohexip(d.Get("first").(string)))
+
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+	if siteErr != nil {
+		// Reporting a failure
+		return diag.FromErr(siteErr)
+	}
+
+	current, err := resourceipaddressrangelist(siteID, first, d.Get("size").(int), d.Get("name_prefix").(string), meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	for i := d.Get("size").(int) - 1; i >= 0; i-- {
+		if address, addressExist := current[i]; addressExist {
+			if delErr := ipaddressdeletebyid(address["ip_id"], false, meta); delErr != nil {
+				// Reporting a failure
+				return diag.FromErr(delErr)
+			}
+
+			// Log deletion
+			tflog.Debug(ctx, fmt.Sprintf("Deleted IP address (oid): %s\n", address["ip_id"]))
+		}
+	}
+
+	// Unset local ID
+	d.SetId("")
+
+	// Reporting a success
+	return nil
+}
+
+func resourceipaddressrangeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	first := hexiptobig(iptohexip(d.Get("first").(string)))
+
+	if first == nil {
+		// Unset local ID
+		d.SetId("")
+
+		return nil
+	}
+
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+	if siteErr != nil {
+		// Reporting a failure
+		return diag.FromErr(siteErr)
+	}
+
+	current, err := resourceipaddressrangelist(siteID, first, d.Get("size").(int), d.Get("name_prefix").(string), meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	// The IP addresses no longer existing are reported empty to be recreated
+	addresses := []string{}
+	reference := -1
+
+	for i := 0; i < d.Get("size").(int); i++ {
+		if _, addressExist := current[i]; !addressExist {
+			addresses = append(addresses, "")
+			continue
+		}
+
+		if reference < 0 {
+			reference = i
+		}
+
+		addresses = append(addresses, hexiptoip(bigtohexip(new(big.Int).Add(first, big.NewInt(int64(i))), false)))
+	}
+
+	if reference < 0 {
+		// Log the error
+		tflog.Debug(ctx, fmt.Sprintf("Unable to find IP address range starting at: %s\n", d.Get("first").(string)))
+
+		// Unset local ID
+		d.SetId("")
+
+		return nil
+	}
+
+	d.Set("addresses", addresses)
+	d.Set("last", hexiptoip(bigtohexip(new(big.Int).Add(first, big.NewInt(int64(d.Get("size").(int)-1))), false)))
+	d.Set("class", current[reference]["ip_class_name"])
+
+	// Updating local class_parameters
+	currentClassParameters := d.Get("class_parameters").(map[string]interface{})
+	retrievedClassParameters, _ := url.ParseQuery(current[reference]["ip_class_parameters"])
+	computedClassParameters := map[string]string{}
+
+	for ck := range currentClassParameters {
+		if rv, rvExist := retrievedClassParameters[ck]; rvExist {
+			computedClassParameters[ck] = rv[0]
+		} else {
+			computedClassParameters[ck] = ""
+		}
+	}
+
+	d.Set("class_parameters", computedClassParameters)
+
+	return nil
+}
+
+func resourceipaddressrangeImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	keys := strings.SplitN(d.Id(), "/", 5)
+
+	if len(keys) != 5 || len(keys[0]) == 0 || len(keys[1]) == 0 || hexiptobig(iptohexip(keys[3])) == nil || len(keys[4]) == 0 {
+		return nil, fmt.Errorf("SOLIDServer - Invalid IP address range import ID: %s, expecting <space>/<subnet>/<pool>/<first>/<name_prefix>\n", d.Id())
+	}
+
+	d.Set("space", keys[0])
+	d.Set("subnet", keys[1])
+	d.Set("pool", keys[2])
+	d.Set("first", keys[3])
+	d.Set("name_prefix", keys[4])
+
+	siteID, siteErr := ipsiteidbyname(keys[0], meta)
+
+	if siteErr != nil {
+		return nil, siteErr
+	}
+
+	current, err := resourceipaddressrangelist(siteID, hexiptobig(iptohexip(keys[3])), 1024, keys[4], meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if _, firstExist := current[0]; !firstExist {
+		return nil, fmt.Errorf("SOLIDServer - Unable to find and import IP address range: %s\n", d.Id())
+	}
+
+	// The range ends with its last IP address named after its position
+	size := 0
+
+	for position := range current {
+		if position >= size {
+			size = position + 1
+		}
+	}
+
+	d.Set("size", size)
+	d.SetId(current[0]["ip_id"])
+
+	if diags := resourceipaddressrangeRead(ctx, d, meta); diags.HasError() || d.Id() == "" {
+		return nil, fmt.Errorf("SOLIDServer - Unable to find and import IP address range: %s\n", d.Id())
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
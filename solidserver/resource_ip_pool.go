@@ -20,6 +20,7 @@ func resourceippool() *schema.Resource {
 		ReadContext:   resourceippoolRead,
 		UpdateContext: resourceippoolUpdate,
 		DeleteContext: resourceippoolDelete,
+		CustomizeDiff: resourceippooldiff(false),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceippoolImportState,
 		},
@@ -45,16 +46,45 @@ func resourceippool() *schema.Resource {
 			},
 			"start": {
 				Type:         schema.TypeString,
-				Description:  "The IP pool lower IP address.",
-				ValidateFunc: validation.IsIPAddress,
-				Required:     true,
-				ForceNew:     true,
+				Description:  "The IP pool's lower IP address, changing it moves the IP pool in place when the range stays free.",
+				ValidateFunc: validation.IsIPv4Address,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     false,
+				ExactlyOneOf: []string{"start", "cidr"},
+			},
+			"end": {
+				Type:          schema.TypeString,
+				Description:   "The IP pool's higher IP address, changing it resizes the IP pool in place when the range stays free.",
+				ValidateFunc:  validation.IsIPv4Address,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      false,
+				ConflictsWith: []string{"size", "cidr"},
 			},
 			"size": {
-				Type:        schema.TypeInt,
-				Description: "The size of the IP pool to create.",
-				Required:    true,
-				ForceNew:    true,
+				Type:          schema.TypeInt,
+				Description:   "The number of addresses of the IP pool, changing it resizes the IP pool in place when the range stays free.",
+				ValidateFunc:  validation.IntAtLeast(1),
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      false,
+				ConflictsWith: []string{"end", "cidr"},
+			},
+			"cidr": {
+				Type:         schema.TypeString,
+				Description:  "The IP pool range in CIDR notation (ex: 10.0.0.64/26), instead of its start, end or size.",
+				ValidateFunc: validation.IsCIDR,
+				Optional:     true,
+				ForceNew:     false,
+				ExactlyOneOf: []string{"start", "cidr"},
+			},
+			"read_only": {
+				Type:        schema.TypeBool,
+				Description: "Specify wether the IP pool is read-only, preventing the allocation of its addresses (Default: false).",
+				Optional:    true,
+				ForceNew:    false,
+				Default:     false,
 			},
 			"dhcp_range": {
 				Type:        schema.TypeBool,
@@ -130,13 +160,18 @@ func resourceippoolCreate(ctx context.Context, d *schema.ResourceData, meta inte
 
 	// Generate class parameter for dhcp range sync
 	if d.Get("dhcp_range").(bool) {
-		parameters.Add("pool_read_only", "1")
 		classParameters.Add("dhcprange", "1")
 	} else {
-		parameters.Add("pool_read_only", "0")
 		classParameters.Add("dhcprange", "0")
 	}
 
+	// DHCP ranges are synchronized from read-only pools
+	if d.Get("read_only").(bool) || d.Get("dhcp_range").(bool) {
+		parameters.Add("pool_read_only", "1")
+	} else {
+		parameters.Add("pool_read_only", "0")
+	}
+
 	for k, v := range d.Get("class_parameters").(map[string]interface{}) {
 		classParameters.Add(k, v.(string))
 	}
@@ -187,18 +222,29 @@ func resourceippoolUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 	parameters.Add("pool_name", d.Get("name").(string))
 	parameters.Add("pool_class_name", d.Get("class").(string))
 
+	// Resizing the IP pool in place if required
+	if d.HasChanges("start", "end", "size", "cidr") {
+		parameters.Add("start_addr", d.Get("start").(string))
+		parameters.Add("pool_size", strconv.Itoa(d.Get("size").(int)))
+	}
+
 	// Building class_parameters
 	classParameters := url.Values{}
 
 	// Generate class parameter for dhcp range sync
 	if d.Get("dhcp_range").(bool) {
-		parameters.Add("pool_read_only", "1")
 		classParameters.Add("dhcprange", "1")
 	} else {
-		parameters.Add("pool_read_only", "0")
 		classParameters.Add("dhcprange", "0")
 	}
 
+	// DHCP ranges are synchronized from read-only pools
+	if d.Get("read_only").(bool) || d.Get("dhcp_range").(bool) {
+		parameters.Add("pool_read_only", "1")
+	} else {
+		parameters.Add("pool_read_only", "0")
+	}
+
 	for k, v := range d.Get("class_parameters").(map[string]interface{}) {
 		classParameters.Add(k, v.(string))
 	}
@@ -301,9 +347,9 @@ func resourceippoolRead(ctx context.Context, d *schema.ResourceData, meta interf
 
 			if dhcprange, dhcprangeExist := retrievedClassParameters["dhcprange"]; dhcprangeExist {
 				if dhcprange[0] == "1" || strings.ToLower(dhcprange[0]) == "yes" {
-					d.Set("dhcprange", true)
+					d.Set("dhcp_range", true)
 				} else {
-					d.Set("dhcprange", false)
+					d.Set("dhcp_range", false)
 				}
 			}
 
@@ -317,6 +363,13 @@ func resourceippoolRead(ctx context.Context, d *schema.ResourceData, meta interf
 
 			d.Set("class_parameters", computedClassParameters)
 
+			// DHCP range pools are always read-only
+			if !d.Get("dhcp_range").(bool) {
+				d.Set("read_only", buf[0]["pool_read_only"] == "1")
+			}
+
+			ippoolsetrange(d, buf[0], false)
+
 			return nil
 		}
 
@@ -366,9 +419,9 @@ func resourceippoolImportState(ctx context.Context, d *schema.ResourceData, meta
 
 			if dhcprange, dhcprangeExist := retrievedClassParameters["dhcprange"]; dhcprangeExist {
 				if dhcprange[0] == "1" || strings.ToLower(dhcprange[0]) == "yes" {
-					d.Set("dhcprange", true)
+					d.Set("dhcp_range", true)
 				} else {
-					d.Set("dhcprange", false)
+					d.Set("dhcp_range", false)
 				}
 			}
 
@@ -382,6 +435,13 @@ func resourceippoolImportState(ctx context.Context, d *schema.ResourceData, meta
 
 			d.Set("class_parameters", computedClassParameters)
 
+			// DHCP range pools are always read-only
+			if !d.Get("dhcp_range").(bool) {
+				d.Set("read_only", buf[0]["pool_read_only"] == "1")
+			}
+
+			ippoolsetrange(d, buf[0], false)
+
 			return []*schema.ResourceData{d}, nil
 		}
 
@@ -34,6 +34,13 @@ func resourceipspace() *schema.Resource {
 				Required:    true,
 				ForceNew:    true,
 			},
+			"parent_space": {
+				Type:        schema.TypeString,
+				Description: "The name of the parent IP space (VLSM), from which the blocks of the IP space are delegated.",
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "",
+			},
 			"class": {
 				Type:        schema.TypeString,
 				Description: "The class associated to the IP space.",
@@ -64,6 +71,18 @@ func resourceipspaceCreate(ctx context.Context, d *schema.ResourceData, meta int
 	parameters.Add("site_class_name", d.Get("class").(string))
 	parameters.Add("site_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
 
+	// Attach the space to its parent space if applicable
+	if parentSpace := d.Get("parent_space").(string); len(parentSpace) > 0 {
+		parentSiteID, parentSiteErr := ipsiteidbyname(parentSpace, meta)
+
+		if parentSiteErr != nil || len(parentSiteID) == 0 {
+			// Reporting a failure
+			return diag.Errorf("Unable to find parent IP space: %s\n", parentSpace)
+		}
+
+		parameters.Add("parent_site_id", parentSiteID)
+	}
+
 	// Sending creation request
 	resp, body, err := s.Request("post", "rest/ip_site_add", &parameters)
 
@@ -195,6 +214,12 @@ func resourceipspaceRead(ctx context.Context, d *schema.ResourceData, meta inter
 			d.Set("name", buf[0]["site_name"].(string))
 			d.Set("class", buf[0]["site_class_name"].(string))
 
+			if parentSpace, parentSpaceExist := buf[0]["parent_site_name"].(string); parentSpaceExist && parentSpace != "#" {
+				d.Set("parent_space", parentSpace)
+			} else {
+				d.Set("parent_space", "")
+			}
+
 			// Updating local class_parameters
 			currentClassParameters := d.Get("class_parameters").(map[string]interface{})
 			retrievedClassParameters, _ := url.ParseQuery(buf[0]["site_class_parameters"].(string))
@@ -252,6 +277,12 @@ func resourceipspaceImportState(ctx context.Context, d *schema.ResourceData, met
 			d.Set("name", buf[0]["site_name"].(string))
 			d.Set("class", buf[0]["site_class_name"].(string))
 
+			if parentSpace, parentSpaceExist := buf[0]["parent_site_name"].(string); parentSpaceExist && parentSpace != "#" {
+				d.Set("parent_space", parentSpace)
+			} else {
+				d.Set("parent_space", "")
+			}
+
 			// Updating local class_parameters
 			currentClassParameters := d.Get("class_parameters").(map[string]interface{})
 			retrievedClassParameters, _ := url.ParseQuery(buf[0]["site_class_parameters"].(string))
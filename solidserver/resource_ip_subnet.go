@@ -7,12 +7,11 @@ import (
 	"github.com/MakeNowJust/heredoc/v2"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
-	"math/rand"
 	"net/url"
 	"strconv"
-	"time"
 )
 
 func resourceipsubnet() *schema.Resource {
@@ -39,11 +38,45 @@ func resourceipsubnet() *schema.Resource {
 				ForceNew:    true,
 			},
 			"block": {
-				Type:        schema.TypeString,
-				Description: "The name of the parent IP block/subnet into which creating the IP subnet.",
-				Optional:    true,
-				ForceNew:    true,
-				Default:     "",
+				Type:             schema.TypeString,
+				Description:      "The name of the parent IP block/subnet into which creating the IP subnet.",
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: resourceipsubnetdiffsuppressblock,
+				Default:          "",
+			},
+			"blocks": {
+				Type:          schema.TypeList,
+				Description:   "The names of the candidate parent IP blocks/subnets into which creating the IP subnet, tried in order.",
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"block", "block_query"},
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"block_query": {
+				Type:          schema.TypeString,
+				Description:   "The SQL WHERE clause selecting the candidate parent IP blocks/subnets within the space (ex: \"subnet_class_name='region-eu'\").",
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"block", "blocks"},
+			},
+			"parent_subnet": {
+				Type:          schema.TypeString,
+				Description:   "The name of the subnet of the parent IP space (VLSM) from which the IP block is delegated, the IP block then mirrors its address and prefix.",
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"block", "blocks", "block_query"},
+				Default:       "",
+			},
+			"allocation_strategy": {
+				Type:         schema.TypeString,
+				Description:  "The strategy used to pick the IP subnet within the candidate blocks, supported values: first-fit (lowest free prefix), best-fit (smallest fitting free range), last-fit (highest free prefix) and random (Default: first-fit).",
+				ValidateFunc: validation.StringInSlice([]string{"first-fit", "best-fit", "last-fit", "random"}, false),
+				Optional:     true,
+				ForceNew:     false,
+				Default:      "first-fit",
 			},
 			"request_ip": {
 				Type:         schema.TypeString,
@@ -55,9 +88,9 @@ func resourceipsubnet() *schema.Resource {
 			},
 			"prefix_size": {
 				Type:        schema.TypeInt,
-				Description: "The expected IP subnet's prefix length (ex: 24 for a '/24').",
+				Description: "The expected IP subnet's prefix length (ex: 24 for a '/24'), changing it resizes the subnet in place when the neighbouring space is free.",
 				Required:    true,
-				ForceNew:    true,
+				ForceNew:    false,
 			},
 			"prefix": {
 				Type:        schema.TypeString,
@@ -74,7 +107,6 @@ func resourceipsubnet() *schema.Resource {
 				Type:        schema.TypeString,
 				Description: "The provisionned IP address netmask.",
 				Computed:    true,
-				ForceNew:    true,
 			},
 			"gateway_offset": {
 				Type:        schema.TypeInt,
@@ -87,7 +119,6 @@ func resourceipsubnet() *schema.Resource {
 				Type:        schema.TypeString,
 				Description: "The subnet's computed gateway.",
 				Computed:    true,
-				ForceNew:    true,
 			},
 			"name": {
 				Type:        schema.TypeString,
@@ -123,6 +154,7 @@ func resourceipsubnet() *schema.Resource {
 				ForceNew:    false,
 				Default:     "",
 			},
+			"reserved_addresses": ipsubnetreservedschema(),
 			"class_parameters": {
 				Type:        schema.TypeMap,
 				Description: "The class parameters associated to the IP subnet.",
@@ -133,11 +165,13 @@ func resourceipsubnet() *schema.Resource {
 				},
 			},
 		},
+		CustomizeDiff: customdiff.All(
+			resourceipsubnetresizediff(false),
+		),
 	}
 }
 
 func resourceipsubnetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	blockInfo := make(map[string]interface{})
 	s := meta.(*SOLIDserver)
 	var gateway string = ""
 	vlmVlanID := ""
@@ -165,46 +199,81 @@ func resourceipsubnetCreate(ctx context.Context, d *schema.ResourceData, meta in
 		}
 	}
 
-	// If a block is specified, look for free IP subnet within this block
-	if len(d.Get("block").(string)) > 0 {
-		var blockErr error = nil
+	// Gather the candidate blocks into which looking for a free IP subnet
+	blocks, blocksErr := ipsubnetcandidateblocks(siteID, d, false, meta)
+
+	if blocksErr != nil {
+		// Reporting a failure
+		return diag.FromErr(blocksErr)
+	}
+
+	// Mirror the delegated subnet of the parent space if applicable
+	requestedIP := d.Get("request_ip").(string)
+	vlsmSubnetID := ""
 
-		//blockID, blockErr = ipsubnetidbyname(siteID, d.Get("block").(string), false, meta)
-		blockInfo, blockErr = ipsubnetinfobyname(siteID, d.Get("block").(string), false, meta)
+	if parentSubnet := d.Get("parent_subnet").(string); len(parentSubnet) > 0 {
+		parentInfo, parentErr := ipsubnetvlsmparentinfo(siteID, parentSubnet, meta)
 
-		if blockErr != nil {
+		if parentErr != nil {
 			// Reporting a failure
-			return diag.FromErr(blockErr)
+			return diag.FromErr(parentErr)
 		}
-	} else {
-		// Otherwise, set an empty blockInfo's ID by default
-		blockInfo["id"] = ""
 
-		// However, we can't create a block as a terminal subnet
-		if d.Get("terminal").(bool) {
-			return diag.Errorf("Can't create a terminal IP block subnet: %s", d.Get("name").(string))
+		if checkErr := ipsubnetvlsmcheck(parentInfo, requestedIP, d.Get("prefix_size").(int)); checkErr != nil {
+			// Reporting a failure
+			return diag.FromErr(checkErr)
+		}
+
+		requestedIP = parentInfo["start_addr"].(string)
+		vlsmSubnetID = parentInfo["id"].(string)
+	}
+
+	// However, we can't create a block as a terminal subnet
+	if blocks[0]["id"].(string) == "" && d.Get("terminal").(bool) {
+		return diag.Errorf("Can't create a terminal IP block subnet: %s", d.Get("name").(string))
+	}
+
+	// Serializing the allocations within the candidate blocks
+	allocationKeys := []string{}
+
+	for _, blockInfo := range blocks {
+		if blockID, _ := blockInfo["id"].(string); len(blockID) > 0 {
+			allocationKeys = append(allocationKeys, "ip_block:"+blockID)
+		} else {
+			allocationKeys = append(allocationKeys, "ip_space:"+siteID)
 		}
 	}
 
-	subnetAddresses, subnetErr := ipsubnetfindbysize(siteID, blockInfo["id"].(string), d.Get("request_ip").(string), d.Get("prefix_size").(int), meta)
+	unlock := s.Allocations.lock(allocationKeys...)
+	defer unlock()
+
+	candidates, subnetErr := ipsubnetallocationcandidates(siteID, blocks, requestedIP, d.Get("prefix_size").(int), d.Get("allocation_strategy").(string), false, meta)
 
 	if subnetErr != nil {
 		// Reporting a failure
 		return diag.FromErr(subnetErr)
 	}
 
-	for i := 0; i < len(subnetAddresses); i++ {
+	for i := 0; i < len(candidates); i++ {
+		blockInfo := candidates[i].Block
+		subnetAddress := candidates[i].Address
+
+		// Skipping the prefixes already allocated by parallel creations
+		if s.Allocations.isclaimed("ip_space:"+siteID, subnetAddress+"/"+strconv.Itoa(d.Get("prefix_size").(int))) {
+			continue
+		}
+
 		// Building parameters
 		parameters := url.Values{}
 		parameters.Add("site_id", siteID)
 		parameters.Add("add_flag", "new_only")
 		parameters.Add("subnet_name", d.Get("name").(string))
-		parameters.Add("subnet_addr", hexiptoip(subnetAddresses[i]))
+		parameters.Add("subnet_addr", hexiptoip(subnetAddress))
 		parameters.Add("subnet_prefix", strconv.Itoa(d.Get("prefix_size").(int)))
 		parameters.Add("subnet_class_name", d.Get("class").(string))
 
 		// If no block specified, create an IP block
-		if len(d.Get("block").(string)) == 0 {
+		if len(blockInfo["id"].(string)) == 0 {
 			parameters.Add("subnet_level", "0")
 		} else {
 			subnetLevel, _ := strconv.Atoi(blockInfo["level"].(string))
@@ -218,6 +287,11 @@ func resourceipsubnetCreate(ctx context.Context, d *schema.ResourceData, meta in
 			parameters.Add("is_terminal", "0")
 		}
 
+		// Link the IP block to the delegated subnet of the parent space if applicable
+		if len(vlsmSubnetID) > 0 {
+			parameters.Add("vlsm_subnet_id", vlsmSubnetID)
+		}
+
 		// Specify the VLAN if applicable
 		if len(vlmVlanID) > 0 {
 			parameters.Add("vlmvlan_id", vlmVlanID)
@@ -232,9 +306,9 @@ func resourceipsubnetCreate(ctx context.Context, d *schema.ResourceData, meta in
 
 		if goffset != 0 {
 			if goffset > 0 {
-				gateway = longtoip(iptolong(hexiptoip(subnetAddresses[i])) + uint32(goffset))
+				gateway = longtoip(iptolong(hexiptoip(subnetAddress)) + uint32(goffset))
 			} else {
-				gateway = longtoip(iptolong(hexiptoip(subnetAddresses[i])) + uint32(prefixlengthtosize(d.Get("prefix_size").(int))) - uint32(abs(goffset)) - 1)
+				gateway = longtoip(iptolong(hexiptoip(subnetAddress)) + uint32(prefixlengthtosize(d.Get("prefix_size").(int))) - uint32(abs(goffset)) - 1)
 			}
 
 			classParameters.Add("gateway", gateway)
@@ -247,9 +321,6 @@ func resourceipsubnetCreate(ctx context.Context, d *schema.ResourceData, meta in
 
 		parameters.Add("subnet_class_parameters", classParameters.Encode())
 
-		// Random Delay
-		time.Sleep(time.Duration(rand.Intn(1000)) * time.Millisecond)
-
 		// Sending the creation request
 		resp, body, err := s.Request("post", "rest/ip_subnet_add", &parameters)
 
@@ -257,20 +328,21 @@ func resourceipsubnetCreate(ctx context.Context, d *schema.ResourceData, meta in
 			var buf [](map[string]interface{})
 			json.Unmarshal([]byte(body), &buf)
 
-			prefix := hexiptoip(subnetAddresses[i]) + "/" + strconv.Itoa(d.Get("prefix_size").(int))
+			prefix := hexiptoip(subnetAddress) + "/" + strconv.Itoa(d.Get("prefix_size").(int))
 
 			// Checking the answer
 			if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
 				if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 					tflog.Debug(ctx, fmt.Sprintf("Created IP subnet (oid): %s\n", oid))
+					s.Allocations.claim("ip_space:"+siteID, subnetAddress+"/"+strconv.Itoa(d.Get("prefix_size").(int)))
 					d.SetId(oid)
 					d.Set("prefix", prefix)
-					d.Set("address", hexiptoip(subnetAddresses[i]))
+					d.Set("address", hexiptoip(subnetAddress))
 					d.Set("netmask", prefixlengthtohexip(d.Get("prefix_size").(int)))
 					if goffset != 0 {
 						d.Set("gateway", gateway)
 					}
-					return nil
+					return ipsubnetreservedapply(ctx, d, siteID, false, meta)
 				}
 			} else {
 				if len(buf) > 0 {
@@ -304,6 +376,12 @@ func resourceipsubnetUpdate(ctx context.Context, d *schema.ResourceData, meta in
 	parameters.Add("subnet_name", d.Get("name").(string))
 	parameters.Add("subnet_class_name", d.Get("class").(string))
 
+	// Resizing the subnet in place if required
+	if d.HasChange("prefix_size") {
+		parameters.Add("subnet_addr", d.Get("address").(string))
+		parameters.Add("subnet_prefix", strconv.Itoa(d.Get("prefix_size").(int)))
+	}
+
 	if d.Get("terminal").(bool) {
 		parameters.Add("is_terminal", "1")
 	} else {
@@ -338,6 +416,11 @@ func resourceipsubnetUpdate(ctx context.Context, d *schema.ResourceData, meta in
 	// Generate class parameter for the gateway if required
 	goffset := d.Get("gateway_offset").(int)
 
+	// Moving the gateway along with the end of the subnet when resized
+	if goffset < 0 && d.HasChange("prefix_size") {
+		d.Set("gateway", longtoip(iptolong(d.Get("address").(string))+uint32(prefixlengthtosize(d.Get("prefix_size").(int)))-uint32(abs(goffset))-1))
+	}
+
 	if goffset != 0 {
 		classParameters.Add("gateway", d.Get("gateway").(string))
 		tflog.Debug(ctx, fmt.Sprintf("Subnet updated gateway: %s\n", d.Get("gateway").(string)))
@@ -360,7 +443,9 @@ func resourceipsubnetUpdate(ctx context.Context, d *schema.ResourceData, meta in
 			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 				tflog.Debug(ctx, fmt.Sprintf("Updated IP subnet (oid): %s\n", oid))
 				d.SetId(oid)
-				return nil
+				d.Set("prefix", d.Get("address").(string)+"/"+strconv.Itoa(d.Get("prefix_size").(int)))
+				d.Set("netmask", prefixlengthtohexip(d.Get("prefix_size").(int)))
+				return ipsubnetreservedupdate(ctx, d, false, meta)
 			}
 		}
 
@@ -424,6 +509,12 @@ func resourceipsubnetgatewayDelete(ctx context.Context, d *schema.ResourceData,
 func resourceipsubnetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	s := meta.(*SOLIDserver)
 
+	// Release the reserved addresses
+	if err := ipsubnetreservedclear(ctx, d.Get("reserved_addresses").([]interface{}), false, meta); err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
 	// Delete related resources such as the Gateway
 	if d.Get("gateway_offset") != 0 {
 		resourceipsubnetgatewayDelete(ctx, d, meta)
@@ -484,6 +575,17 @@ func resourceipsubnetRead(ctx context.Context, d *schema.ResourceData, meta inte
 		if resp.StatusCode == 200 && len(buf) > 0 {
 			d.Set("space", buf[0]["site_name"].(string))
 			d.Set("block", buf[0]["parent_subnet_name"].(string))
+
+			// Updating the subnet of the parent space (VLSM) the block is delegated from
+			parentSubnet, parentErr := ipsubnetvlsmparentname(buf[0], meta)
+
+			if parentErr != nil {
+				// Reporting a failure
+				return diag.FromErr(parentErr)
+			}
+
+			d.Set("parent_subnet", parentSubnet)
+
 			d.Set("name", buf[0]["subnet_name"].(string))
 			d.Set("class", buf[0]["subnet_class_name"].(string))
 
@@ -521,6 +623,12 @@ func resourceipsubnetRead(ctx context.Context, d *schema.ResourceData, meta inte
 
 			d.Set("class_parameters", computedClassParameters)
 
+			// Refreshing the reserved addresses
+			if reservedErr := ipsubnetreservedread(ctx, d, false, meta); reservedErr != nil {
+				// Reporting a failure
+				return diag.FromErr(reservedErr)
+			}
+
 			return nil
 		}
 
@@ -562,6 +670,13 @@ func resourceipsubnetImportState(ctx context.Context, d *schema.ResourceData, me
 		if resp.StatusCode == 200 && len(buf) > 0 {
 			d.Set("space", buf[0]["site_name"].(string))
 			d.Set("block", buf[0]["parent_subnet_name"].(string))
+
+			// Updating the subnet of the parent space (VLSM) the block is delegated from
+			if parentSubnet, parentErr := ipsubnetvlsmparentname(buf[0], meta); parentErr == nil {
+				d.Set("parent_subnet", parentSubnet)
+			}
+
+			d.Set("allocation_strategy", "first-fit")
 			d.Set("name", buf[0]["subnet_name"].(string))
 			d.Set("request_ip", "")
 
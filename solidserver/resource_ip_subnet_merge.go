@@ -0,0 +1,253 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"math/big"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+func resourceipsubnetmerge() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceipsubnetmergeCreate,
+		ReadContext:   resourceipsubnetmergeRead,
+		DeleteContext: resourceipsubnetmergeDelete,
+		CustomizeDiff: resourceipsubnetmergeDiff,
+
+		Description: heredoc.Doc(`
+			IP subnet merge resource allows to merge several contiguous IPv4 subnets into a single subnet,
+			keeping the existing IP addresses and the class parameters of the lowest subnet.
+			The merged subnets must form a single prefix aligned on its size.
+			Destroying this resource does not split back the resulting subnet.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space of the IP subnets to merge.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"subnets": {
+				Type:        schema.TypeList,
+				Description: "The names of the terminal IP subnets to merge.",
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    2,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Description: "The name of the resulting IP subnet (Default: the name of the lowest merged IP subnet).",
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"address": {
+				Type:        schema.TypeString,
+				Description: "The resulting IP subnet address.",
+				Computed:    true,
+			},
+			"prefix": {
+				Type:        schema.TypeString,
+				Description: "The resulting IP subnet prefix.",
+				Computed:    true,
+			},
+			"prefix_size": {
+				Type:        schema.TypeInt,
+				Description: "The resulting IP subnet prefix length.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Retrieve the subnets to merge ordered by address along with the resulting range
+func ipsubnetmergeinfo(space string, subnets []string, meta interface{}) ([]map[string]interface{}, ipRange, error) {
+	siteID, siteErr := ipsiteidbyname(space, meta)
+
+	if siteErr != nil {
+		return nil, ipRange{}, siteErr
+	}
+
+	infos := []map[string]interface{}{}
+	ranges := []ipRange{}
+
+	for _, subnet := range subnets {
+		info, err := ipsubnetinfobyname(siteID, subnet, true, meta)
+
+		if err != nil {
+			return nil, ipRange{}, err
+		}
+
+		start, startExist := info["start_hex_addr"].(string)
+		end, endExist := info["end_hex_addr"].(string)
+
+		if !startExist || !endExist || hexiptobig(start) == nil || hexiptobig(end) == nil {
+			return nil, ipRange{}, fmt.Errorf("SOLIDServer - Unable to retrieve the boundaries of IP subnet: %s\n", subnet)
+		}
+
+		infos = append(infos, info)
+		ranges = append(ranges, ipRange{Start: hexiptobig(start), End: hexiptobig(end)})
+	}
+
+	merged, err := ipmergedrange(ranges)
+
+	if err != nil {
+		return nil, ipRange{}, fmt.Errorf("SOLIDServer - Unable to merge IP subnets: %s\n", err)
+	}
+
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i]["start_hex_addr"].(string) < infos[j]["start_hex_addr"].(string)
+	})
+
+	return infos, merged, nil
+}
+
+// Validate the merge at plan time
+func resourceipsubnetmergeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" || meta == nil || !d.NewValueKnown("space") || !d.NewValueKnown("subnets") {
+		return nil
+	}
+
+	_, _, err := ipsubnetmergeinfo(d.Get("space").(string), toStringArray(d.Get("subnets").([]interface{})), meta)
+
+	return err
+}
+
+func resourceipsubnetmergeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	infos, merged, err := ipsubnetmergeinfo(d.Get("space").(string), toStringArray(d.Get("subnets").([]interface{})), meta)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	// The lowest subnet is kept and extended over the other ones
+	subnetID := infos[0]["id"].(string)
+	name := infos[0]["name"].(string)
+
+	if d.Get("name").(string) != "" {
+		name = d.Get("name").(string)
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("subnet_id", subnetID)
+
+	for _, info := range infos[1:] {
+		parameters.Add("merge_subnet_id", info["id"].(string))
+	}
+
+	// Sending the merge request
+	resp, body, err := s.Request("put", "rest/ip_subnet_merge", &parameters)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	// Checking the answer
+	if resp.StatusCode != 200 && resp.StatusCode != 201 {
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return diag.Errorf("Unable to merge IP subnets into: %s (%s)", name, errMsg)
+			}
+		}
+
+		return diag.Errorf("Unable to merge IP subnets into: %s\n", name)
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Merged IP subnets into: %s\n", name))
+
+	if name != infos[0]["name"].(string) {
+		// Renaming the resulting subnet
+		editParameters := url.Values{}
+		editParameters.Add("subnet_id", subnetID)
+		editParameters.Add("add_flag", "edit_only")
+		editParameters.Add("subnet_name", name)
+
+		editResp, editBody, editErr := s.Request("put", "rest/ip_subnet_add", &editParameters)
+
+		if editErr != nil {
+			// Reporting a failure
+			return diag.FromErr(editErr)
+		}
+
+		if editResp.StatusCode != 200 && editResp.StatusCode != 201 {
+			var editBuf [](map[string]interface{})
+			json.Unmarshal([]byte(editBody), &editBuf)
+
+			if len(editBuf) > 0 {
+				if errMsg, errExist := editBuf[0]["errmsg"].(string); errExist {
+					return diag.Errorf("Unable to rename merged IP subnet: %s (%s)", name, errMsg)
+				}
+			}
+
+			return diag.Errorf("Unable to rename merged IP subnet: %s\n", name)
+		}
+	}
+
+	d.SetId(subnetID)
+	resourceipsubnetmergeset(d, merged)
+
+	return nil
+}
+
+// Set the computed attributes from the resulting range
+func resourceipsubnetmergeset(d *schema.ResourceData, r ipRange) {
+	size := new(big.Int).Sub(r.End, r.Start)
+	size.Add(size, big.NewInt(1))
+
+	address := hexiptoip(bigtohexip(r.Start, false))
+	prefixSize := sizetoprefixlength(int(size.Int64()))
+
+	d.Set("address", address)
+	d.Set("prefix", address+"/"+strconv.Itoa(prefixSize))
+	d.Set("prefix_size", prefixSize)
+}
+
+func resourceipsubnetmergeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	info, err := ipsubnetinfobyid(d.Id(), false, meta)
+
+	if err != nil {
+		// Log the error
+		tflog.Debug(ctx, fmt.Sprintf("Unable to find merged IP subnet (oid): %s (%s)\n", d.Id(), err))
+
+		// Do not unset the local ID to avoid inconsistency
+
+		// Reporting a failure
+		return diag.Errorf("Unable to find merged IP subnet (oid): %s\n", d.Id())
+	}
+
+	start, end := hexiptobig(info["start_hex_addr"]), hexiptobig(info["end_hex_addr"])
+
+	if start != nil && end != nil {
+		resourceipsubnetmergeset(d, ipRange{Start: start, End: end})
+	}
+
+	return nil
+}
+
+func resourceipsubnetmergeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// The resulting subnet is kept as is
+	tflog.Debug(ctx, fmt.Sprintf("Forgetting the merge of IP subnets into (oid): %s\n", d.Id()))
+
+	// Unset local ID
+	d.SetId("")
+
+	return nil
+}
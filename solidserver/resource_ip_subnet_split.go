@@ -0,0 +1,334 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/url"
+	"strconv"
+)
+
+func resourceipsubnetsplit() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceipsubnetsplitCreate,
+		ReadContext:   resourceipsubnetsplitRead,
+		DeleteContext: resourceipsubnetsplitDelete,
+		CustomizeDiff: resourceipsubnetsplitDiff,
+
+		Description: heredoc.Doc(`
+			IP subnet split resource allows to split an existing IPv4 subnet into several smaller contiguous subnets,
+			keeping the existing IP addresses and the class parameters of the original subnet.
+			Destroying this resource does not merge back the resulting subnets.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"space": {
+				Type:        schema.TypeString,
+				Description: "The name of the space of the IP subnet to split.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"subnet": {
+				Type:        schema.TypeString,
+				Description: "The name of the terminal IP subnet to split.",
+				Required:    true,
+				ForceNew:    true,
+			},
+			"prefix_size": {
+				Type:         schema.TypeInt,
+				Description:  "The prefix length of the resulting IP subnets (ex: 26 to split a /24 into four /26).",
+				ValidateFunc: validation.IntBetween(1, 32),
+				Required:     true,
+				ForceNew:     true,
+			},
+			"names": {
+				Type:        schema.TypeList,
+				Description: "The names of the resulting IP subnets ordered by address (Default: the name of the split IP subnet).",
+				Optional:    true,
+				ForceNew:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"subnets": {
+				Type:        schema.TypeList,
+				Description: "The resulting IP subnets ordered by address.",
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Description: "The ID of the IP subnet.",
+							Computed:    true,
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Description: "The name of the IP subnet.",
+							Computed:    true,
+						},
+						"address": {
+							Type:        schema.TypeString,
+							Description: "The IP subnet address.",
+							Computed:    true,
+						},
+						"prefix": {
+							Type:        schema.TypeString,
+							Description: "The IP subnet prefix.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Validate the split at plan time
+func resourceipsubnetsplitDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" || meta == nil || !d.NewValueKnown("space") || !d.NewValueKnown("subnet") {
+		return nil
+	}
+
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+	if siteErr != nil {
+		return siteErr
+	}
+
+	subnetInfo, subnetErr := ipsubnetinfobyname(siteID, d.Get("subnet").(string), true, meta)
+
+	if subnetErr != nil {
+		return subnetErr
+	}
+
+	return ipsubnetsplitcheck(subnetInfo["prefix_length"].(int), d.Get("prefix_size").(int), len(d.Get("names").([]interface{})))
+}
+
+// Check the split of a /current prefix into /target prefixes
+func ipsubnetsplitcheck(current int, target int, names int) error {
+	if target <= current {
+		return fmt.Errorf("SOLIDServer - Unable to split a /%d IP subnet into /%d IP subnets, the prefix length must be greater\n", current, target)
+	}
+
+	if target-current > 8 {
+		return fmt.Errorf("SOLIDServer - Unable to split a /%d IP subnet into more than 256 IP subnets\n", current)
+	}
+
+	if parts := 1 << uint(target-current); names > parts {
+		return fmt.Errorf("SOLIDServer - Unable to split a /%d IP subnet into %d IP subnets, only %d names expected\n", current, parts, parts)
+	}
+
+	return nil
+}
+
+func resourceipsubnetsplitCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	// Gather required ID(s) from provided information
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+	if siteErr != nil {
+		// Reporting a failure
+		return diag.FromErr(siteErr)
+	}
+
+	subnetInfo, subnetErr := ipsubnetinfobyname(siteID, d.Get("subnet").(string), true, meta)
+	if subnetErr != nil {
+		// Reporting a failure
+		return diag.FromErr(subnetErr)
+	}
+
+	if err := ipsubnetsplitcheck(subnetInfo["prefix_length"].(int), d.Get("prefix_size").(int), len(d.Get("names").([]interface{}))); err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	// Keeping the original class and class parameters to apply them on every resulting subnet
+	original, originalErr := ipsubnetinfobyid(subnetInfo["id"].(string), false, meta)
+	if originalErr != nil {
+		// Reporting a failure
+		return diag.FromErr(originalErr)
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("subnet_id", subnetInfo["id"].(string))
+	parameters.Add("subnet_prefix", strconv.Itoa(d.Get("prefix_size").(int)))
+
+	// Sending the split request
+	resp, body, err := s.Request("put", "rest/ip_subnet_split", &parameters)
+
+	if err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	// Checking the answer
+	if resp.StatusCode != 200 && resp.StatusCode != 201 {
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return diag.Errorf("Unable to split IP subnet: %s (%s)", d.Get("subnet").(string), errMsg)
+			}
+		}
+
+		return diag.Errorf("Unable to split IP subnet: %s\n", d.Get("subnet").(string))
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Split IP subnet: %s into /%d IP subnets\n", d.Get("subnet").(string), d.Get("prefix_size").(int)))
+
+	// Retrieving the resulting subnets
+	listParameters := url.Values{}
+	listParameters.Add("WHERE", "site_id='"+siteID+"' AND is_terminal='1' AND start_ip_addr>='"+subnetInfo["start_hex_addr"].(string)+"' AND end_ip_addr<='"+subnetInfo["end_hex_addr"].(string)+"'")
+	listParameters.Add("ORDERBY", "start_ip_addr")
+
+	parts, listErr := solidserverlist("ip_block_subnet_list", listParameters, 0, meta)
+
+	if listErr != nil {
+		// Reporting a failure
+		return diag.FromErr(listErr)
+	}
+
+	if len(parts) == 0 {
+		return diag.Errorf("Unable to find the IP subnets resulting from the split of: %s\n", d.Get("subnet").(string))
+	}
+
+	names := toStringArray(d.Get("names").([]interface{}))
+	ids := []string{}
+
+	for i, part := range parts {
+		attributes := restobjectattributes(part)
+		name := original["name"]
+
+		if i < len(names) {
+			name = names[i]
+		}
+
+		// Renaming the subnet and restoring the original class parameters
+		editParameters := url.Values{}
+		editParameters.Add("subnet_id", attributes["subnet_id"])
+		editParameters.Add("add_flag", "edit_only")
+		editParameters.Add("subnet_name", name)
+		editParameters.Add("subnet_class_name", original["class"])
+		editParameters.Add("subnet_class_parameters", original["class_parameters"])
+
+		editResp, editBody, editErr := s.Request("put", "rest/ip_subnet_add", &editParameters)
+
+		if editErr != nil {
+			// Reporting a failure
+			return diag.FromErr(editErr)
+		}
+
+		if editResp.StatusCode != 200 && editResp.StatusCode != 201 {
+			var editBuf [](map[string]interface{})
+			json.Unmarshal([]byte(editBody), &editBuf)
+
+			if len(editBuf) > 0 {
+				if errMsg, errExist := editBuf[0]["errmsg"].(string); errExist {
+					return diag.Errorf("Unable to update IP subnet resulting from the split: %s (%s)", name, errMsg)
+				}
+			}
+
+			return diag.Errorf("Unable to update IP subnet resulting from the split: %s\n", name)
+		}
+
+		ids = append(ids, attributes["subnet_id"])
+	}
+
+	subnets, subnetsErr := resourceipsubnetsplitflatten(ids, meta)
+
+	d.SetId(ids[0])
+
+	if subnetsErr != nil {
+		// Reporting a failure
+		return diag.FromErr(subnetsErr)
+	}
+
+	d.Set("subnets", subnets)
+
+	return nil
+}
+
+// Build the subnets attribute from the IDs of the resulting subnets still existing
+// Return an error if one of them can't be read
+func resourceipsubnetsplitflatten(ids []string, meta interface{}) ([]interface{}, error) {
+	res := []interface{}{}
+
+	for _, id := range ids {
+		info, err := ipsubnetlookupbyid(id, false, meta)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// The subnet no longer exists
+		if info == nil {
+			continue
+		}
+
+		start := hexiptobig(info["start_hex_addr"])
+		end := hexiptobig(info["end_hex_addr"])
+
+		if start == nil || end == nil {
+			continue
+		}
+
+		size := int(end.Int64() - start.Int64() + 1)
+
+		res = append(res, map[string]interface{}{
+			"id":      id,
+			"name":    info["name"],
+			"address": hexiptoip(info["start_hex_addr"]),
+			"prefix":  hexiptoip(info["start_hex_addr"]) + "/" + strconv.Itoa(sizetoprefixlength(size)),
+		})
+	}
+
+	return res, nil
+}
+
+func resourceipsubnetsplitRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	ids := []string{}
+
+	for _, subnet := range d.Get("subnets").([]interface{}) {
+		ids = append(ids, subnet.(map[string]interface{})["id"].(string))
+	}
+
+	subnets, err := resourceipsubnetsplitflatten(ids, meta)
+
+	if err != nil {
+		// Do not unset the local ID to avoid inconsistency
+
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	if len(subnets) == 0 {
+		// Log the error
+		tflog.Debug(ctx, fmt.Sprintf("Unable to find any IP subnet resulting from the split of: %s\n", d.Get("subnet").(string)))
+
+		// Unset local ID
+		d.SetId("")
+
+		return nil
+	}
+
+	d.Set("subnets", subnets)
+
+	return nil
+}
+
+func resourceipsubnetsplitDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// The resulting subnets are kept as is
+	tflog.Debug(ctx, fmt.Sprintf("Forgetting the split of IP subnet: %s\n", d.Get("subnet").(string)))
+
+	// Unset local ID
+	d.SetId("")
+
+	return nil
+}
@@ -0,0 +1,351 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/url"
+	"strings"
+)
+
+func resourcerestobject() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourcerestobjectCreate,
+		ReadContext:   resourcerestobjectRead,
+		UpdateContext: resourcerestobjectUpdate,
+		DeleteContext: resourcerestobjectDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcerestobjectImportState,
+		},
+
+		Description: heredoc.Doc(`
+			REST object resource allows to create and manage any SOLIDserver object through its REST services,
+			including objects not covered by a dedicated resource (DHCP, NetChange, Workflow, Rules, System settings ...).
+			Only the parameters listed in read_fields are refreshed from the SOLIDserver to detect drifts.
+			The import ID is formatted as <add_service>:<info_service>:<delete_service>:<id_parameter>:<oid>[:<read_fields>],
+			read_fields being an optional comma separated list of <parameter>=<field> used to populate the imported parameters
+			(ex: ip_site_add:ip_site_info:ip_site_delete:site_id:2:site_name=site_name,site_description=site_description).
+			The configured parameters not populated on import are expected to be updated on the first apply.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"add_service": {
+				Type:         schema.TypeString,
+				Description:  "The name of the service used to create (POST) and update (PUT) the object (ex: ip_site_add).",
+				ValidateFunc: validation.StringIsNotEmpty,
+				Required:     true,
+				ForceNew:     true,
+			},
+			"info_service": {
+				Type:         schema.TypeString,
+				Description:  "The name of the service used to read the object (ex: ip_site_info).",
+				ValidateFunc: validation.StringIsNotEmpty,
+				Required:     true,
+				ForceNew:     false,
+			},
+			"delete_service": {
+				Type:         schema.TypeString,
+				Description:  "The name of the service used to delete the object (ex: ip_site_delete).",
+				ValidateFunc: validation.StringIsNotEmpty,
+				Required:     true,
+				ForceNew:     false,
+			},
+			"id_parameter": {
+				Type:         schema.TypeString,
+				Description:  "The name of the parameter identifying the object on update, read and delete (ex: site_id).",
+				ValidateFunc: validation.StringIsNotEmpty,
+				Required:     true,
+				ForceNew:     true,
+			},
+			"parameters": {
+				Type:        schema.TypeMap,
+				Description: "The parameters sent to the add service when creating or updating the object.",
+				Required:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"read_fields": {
+				Type:        schema.TypeMap,
+				Description: "The mapping between parameters and the fields returned by the info service, used to detect drifts (ex: { site_name = \"site_name\" }).",
+				Optional:    true,
+				ForceNew:    false,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"attributes": {
+				Type:        schema.TypeMap,
+				Description: "The fields returned by the info service.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourcerestobjectCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+
+	for k, v := range d.Get("parameters").(map[string]interface{}) {
+		parameters.Add(k, v.(string))
+	}
+
+	// Sending the creation request
+	resp, body, err := s.Request("post", restservicepath(d.Get("add_service").(string)), &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
+				tflog.Debug(ctx, fmt.Sprintf("Created REST object (oid): %s\n", oid))
+				d.SetId(oid)
+				return resourcerestobjectRead(ctx, d, meta)
+			}
+		}
+
+		// Reporting a failure
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return diag.Errorf("Unable to create REST object using service: %s (%s)", d.Get("add_service").(string), errMsg)
+			}
+		}
+
+		return diag.Errorf("Unable to create REST object using service: %s\n", d.Get("add_service").(string))
+	}
+
+	// Reporting a failure
+	return diag.FromErr(err)
+}
+
+func resourcerestobjectUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+
+	for k, v := range d.Get("parameters").(map[string]interface{}) {
+		parameters.Add(k, v.(string))
+	}
+
+	parameters.Set(d.Get("id_parameter").(string), d.Id())
+
+	// Sending the update request
+	resp, body, err := s.Request("put", restservicepath(d.Get("add_service").(string)), &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
+				tflog.Debug(ctx, fmt.Sprintf("Updated REST object (oid): %s\n", oid))
+				d.SetId(oid)
+				return resourcerestobjectRead(ctx, d, meta)
+			}
+		}
+
+		// Reporting a failure
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return diag.Errorf("Unable to update REST object (oid): %s (%s)", d.Id(), errMsg)
+			}
+		}
+
+		return diag.Errorf("Unable to update REST object (oid): %s\n", d.Id())
+	}
+
+	// Reporting a failure
+	return diag.FromErr(err)
+}
+
+func resourcerestobjectDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add(d.Get("id_parameter").(string), d.Id())
+
+	// Sending the deletion request
+	resp, body, err := s.Request("delete", restservicepath(d.Get("delete_service").(string)), &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode != 200 && resp.StatusCode != 204 {
+			// Reporting a failure
+			if len(buf) > 0 {
+				if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+					return diag.Errorf("Unable to delete REST object (oid): %s (%s)", d.Id(), errMsg)
+				}
+			}
+
+			return diag.Errorf("Unable to delete REST object (oid): %s", d.Id())
+		}
+
+		// Log deletion
+		tflog.Debug(ctx, fmt.Sprintf("Deleted REST object (oid): %s\n", d.Id()))
+
+		// Unset local ID
+		d.SetId("")
+
+		// Reporting a success
+		return nil
+	}
+
+	// Reporting a failure
+	return diag.FromErr(err)
+}
+
+func resourcerestobjectRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add(d.Get("id_parameter").(string), d.Id())
+
+	// Sending the read request
+	resp, body, err := s.Request("get", restservicepath(d.Get("info_service").(string)), &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			attributes := restobjectattributes(buf[0])
+
+			// Updating local parameters from the declared read_fields
+			computedParameters := map[string]string{}
+
+			for k, v := range d.Get("parameters").(map[string]interface{}) {
+				computedParameters[k] = v.(string)
+			}
+
+			for k, field := range d.Get("read_fields").(map[string]interface{}) {
+				if rv, rvExist := attributes[field.(string)]; rvExist {
+					computedParameters[k] = rv
+				}
+			}
+
+			d.Set("parameters", computedParameters)
+			d.Set("attributes", attributes)
+
+			return nil
+		}
+
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				// Log the error
+				tflog.Debug(ctx, fmt.Sprintf("Unable to find REST object (oid): %s (%s)\n", d.Id(), errMsg))
+			}
+		} else {
+			// Log the error
+			tflog.Debug(ctx, fmt.Sprintf("Unable to find REST object (oid): %s\n", d.Id()))
+		}
+
+		// Do not unset the local ID to avoid inconsistency
+
+		// Reporting a failure
+		return diag.Errorf("Unable to find REST object (oid): %s\n", d.Id())
+	}
+
+	// Reporting a failure
+	return diag.FromErr(err)
+}
+
+// Import ID format: <add_service>:<info_service>:<delete_service>:<id_parameter>:<oid>[:<parameter>=<field>,...]
+func resourcerestobjectImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	s := meta.(*SOLIDserver)
+
+	importID := strings.Split(d.Id(), ":")
+
+	if len(importID) != 5 && len(importID) != 6 {
+		return nil, fmt.Errorf("SOLIDServer - Unable to import REST object, expected ID format is <add_service>:<info_service>:<delete_service>:<id_parameter>:<oid>[:<parameter>=<field>,...] (got: %s)\n", d.Id())
+	}
+
+	readFields := map[string]string{}
+
+	if len(importID) == 6 && len(importID[5]) > 0 {
+		for _, readField := range strings.Split(importID[5], ",") {
+			kv := strings.SplitN(readField, "=", 2)
+
+			if len(kv) != 2 || len(kv[0]) == 0 || len(kv[1]) == 0 {
+				return nil, fmt.Errorf("SOLIDServer - Unable to import REST object, invalid read field: %s, expecting <parameter>=<field>\n", readField)
+			}
+
+			readFields[kv[0]] = kv[1]
+		}
+	}
+
+	d.SetId(importID[4])
+	d.Set("add_service", importID[0])
+	d.Set("info_service", importID[1])
+	d.Set("delete_service", importID[2])
+	d.Set("id_parameter", importID[3])
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add(importID[3], d.Id())
+
+	// Sending the read request
+	resp, body, err := s.Request("get", restservicepath(importID[1]), &parameters)
+
+	if err == nil {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode == 200 && len(buf) > 0 {
+			attributes := restobjectattributes(buf[0])
+
+			// Populating the parameters from the read_fields
+			computedParameters := map[string]string{}
+
+			for k, field := range readFields {
+				if rv, rvExist := attributes[field]; rvExist {
+					computedParameters[k] = rv
+				}
+			}
+
+			d.Set("read_fields", readFields)
+			d.Set("parameters", computedParameters)
+			d.Set("attributes", attributes)
+
+			return []*schema.ResourceData{d}, nil
+		}
+
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				// Log the error
+				tflog.Debug(ctx, fmt.Sprintf("Unable to import REST object (oid): %s (%s)\n", d.Id(), errMsg))
+			}
+		} else {
+			// Log the error
+			tflog.Debug(ctx, fmt.Sprintf("Unable to find and import REST object (oid): %s\n", d.Id()))
+		}
+
+		// Reporting a failure
+		return nil, fmt.Errorf("SOLIDServer - Unable to find and import REST object (oid): %s\n", d.Id())
+	}
+
+	// Reporting a failure
+	return nil, err
+}
@@ -0,0 +1,360 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/MakeNowJust/heredoc/v2"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net/url"
+)
+
+func resourcesubnet() *schema.Resource {
+	resource := &schema.Resource{
+		CreateContext: resourcesubnetCreate,
+		ReadContext:   resourcesubnetRead,
+		UpdateContext: resourcesubnetUpdate,
+		DeleteContext: resourcesubnetDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourcesubnetImportState,
+		},
+		CustomizeDiff: customdiff.All(
+			resourceipfamilydiff,
+			resourcesubnetresizediff,
+		),
+
+		Description: heredoc.Doc(`
+			Subnet resource allows to create and manage IPv4 or IPv6 blocks and subnets from a single resource type.
+			The family is picked from the requested address or the parent block, then the IPv4 or IPv6 endpoints are used accordingly.
+			The optional dual_stack block allocates a subnet of the other family along with it, sharing its name, VLAN, class and class parameters.
+			Imported subnets are identified by <family>:<oid> (ex: ipv6:42), the family defaulting to ipv4, the dual_stack block is not imported.
+		`),
+
+		Schema: map[string]*schema.Schema{
+			"family": ipfamilyschema("IP subnet"),
+			"dual_stack": {
+				Type:        schema.TypeList,
+				Description: "The subnet of the other family allocated and maintained along with the IP subnet.",
+				Optional:    true,
+				ForceNew:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"block": {
+							Type:        schema.TypeString,
+							Description: "The name of the parent block into which creating the paired subnet.",
+							Required:    true,
+							ForceNew:    true,
+						},
+						"prefix_size": {
+							Type:         schema.TypeInt,
+							Description:  "The expected prefix length of the paired subnet (ex: 64 for a '/64').",
+							ValidateFunc: validation.IntBetween(1, 128),
+							Required:     true,
+							ForceNew:     true,
+						},
+						"request_ip": {
+							Type:         schema.TypeString,
+							Description:  "The optionally requested address of the paired subnet.",
+							ValidateFunc: validation.IsIPAddress,
+							Optional:     true,
+							ForceNew:     true,
+							Default:      "",
+						},
+						"id": {
+							Type:        schema.TypeString,
+							Description: "The ID of the paired subnet.",
+							Computed:    true,
+						},
+						"address": {
+							Type:        schema.TypeString,
+							Description: "The provisionned network address of the paired subnet.",
+							Computed:    true,
+						},
+						"prefix": {
+							Type:        schema.TypeString,
+							Description: "The provisionned prefix of the paired subnet.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// Sharing the attributes of the IPv4 and IPv6 subnet resources
+	for k, v := range resourceipsubnet().Schema {
+		resource.Schema[k] = v
+	}
+
+	resource.Schema["netmask"].Description = "The provisionned IP address netmask (IPv4 only)."
+
+	return resource
+}
+
+// Return the IPv4 or IPv6 subnet resource implementing a family
+func resourcesubnetfamily(family string) *schema.Resource {
+	if family == "ipv6" {
+		return resourceip6subnet()
+	}
+
+	return resourceipsubnet()
+}
+
+// Validate at plan time the in place resize of a subnet according to its family
+func resourcesubnetresizediff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	return resourceipsubnetresizediff(d.Get("family").(string) == "ipv6")(ctx, d, meta)
+}
+
+// Build the resource data of the subnet paired with a dual-stack subnet
+// It shares the space, name, VLAN, class and class parameters of the subnet
+func resourcesubnetpair(d *schema.ResourceData) (*schema.Resource, *schema.ResourceData) {
+	dual := d.Get("dual_stack").([]interface{})[0].(map[string]interface{})
+	resource := resourcesubnetfamily(ipfamilyother(d.Get("family").(string)))
+	pair := resource.Data(nil)
+
+	pair.SetId(dual["id"].(string))
+	pair.Set("space", d.Get("space").(string))
+	pair.Set("block", dual["block"].(string))
+	pair.Set("request_ip", dual["request_ip"].(string))
+	pair.Set("prefix_size", dual["prefix_size"].(int))
+	pair.Set("address", dual["address"].(string))
+	pair.Set("prefix", dual["prefix"].(string))
+	pair.Set("allocation_strategy", d.Get("allocation_strategy").(string))
+	pair.Set("gateway_offset", 0)
+	pair.Set("name", d.Get("name").(string))
+	pair.Set("terminal", d.Get("terminal").(bool))
+	pair.Set("vlan_domain", d.Get("vlan_domain").(string))
+	pair.Set("vlan_id", d.Get("vlan_id").(int))
+	pair.Set("class", d.Get("class").(string))
+	pair.Set("class_parameters", d.Get("class_parameters").(map[string]interface{}))
+
+	return resource, pair
+}
+
+// Update the name, VLAN, class and class parameters of the subnet paired with a dual-stack subnet
+// The paired subnet is neither resized nor its reserved addresses updated
+func resourcesubnetpairedit(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
+	s := meta.(*SOLIDserver)
+	dual := d.Get("dual_stack").([]interface{})[0].(map[string]interface{})
+	service, prefix := "rest/ip_subnet_add", "subnet"
+	vlmVlanID := ""
+
+	if ipfamilyother(d.Get("family").(string)) == "ipv6" {
+		service, prefix = "rest/ip6_subnet6_add", "subnet6"
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add(prefix+"_id", dual["id"].(string))
+	parameters.Add("add_flag", "edit_only")
+	parameters.Add(prefix+"_name", d.Get("name").(string))
+	parameters.Add(prefix+"_class_name", d.Get("class").(string))
+	parameters.Add(prefix+"_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
+
+	if d.Get("terminal").(bool) {
+		parameters.Add("is_terminal", "1")
+	} else {
+		parameters.Add("is_terminal", "0")
+	}
+
+	// Retrieve vlmVlanID Information from the provided VLAN ID
+	if d.Get("vlan_id").(int) > 0 {
+		if len(d.Get("vlan_domain").(string)) == 0 {
+			return fmt.Errorf("SOLIDServer - Can't associate an IP subnet with VLAN ID %d without specifying a VLAN Domain\n", d.Get("vlan_id").(int))
+		}
+
+		var vlmVlanIDErr error = nil
+
+		if vlmVlanID, vlmVlanIDErr = vlanidbyinfo(d.Get("vlan_domain").(string), d.Get("vlan_id").(int), meta); vlmVlanIDErr != nil {
+			return vlmVlanIDErr
+		}
+	}
+
+	// Specify the VLAN if applicable
+	if len(vlmVlanID) > 0 {
+		parameters.Add("vlmvlan_id", vlmVlanID)
+	}
+
+	// Sending the update request
+	resp, body, err := s.Request("put", service, &parameters)
+
+	if err != nil {
+		return err
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	// Checking the answer
+	if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+		if _, oidExist := buf[0]["ret_oid"].(string); oidExist {
+			tflog.Debug(ctx, fmt.Sprintf("Updated paired subnet (oid): %s\n", dual["id"].(string)))
+			return nil
+		}
+	}
+
+	if len(buf) > 0 {
+		if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+			return fmt.Errorf("SOLIDServer - Unable to update paired subnet: %s (%s)\n", d.Get("name").(string), errMsg)
+		}
+	}
+
+	return fmt.Errorf("SOLIDServer - Unable to update paired subnet: %s\n", d.Get("name").(string))
+}
+
+// Report the paired subnet into the dual_stack block
+func resourcesubnetsetpair(d *schema.ResourceData, pair *schema.ResourceData) {
+	dual := d.Get("dual_stack").([]interface{})[0].(map[string]interface{})
+
+	d.Set("dual_stack", []interface{}{
+		map[string]interface{}{
+			"block":       dual["block"].(string),
+			"prefix_size": dual["prefix_size"].(int),
+			"request_ip":  dual["request_ip"].(string),
+			"id":          pair.Id(),
+			"address":     pair.Get("address").(string),
+			"prefix":      pair.Get("prefix").(string),
+		},
+	})
+}
+
+func resourcesubnetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	family := d.Get("family").(string)
+
+	// Guessing the family from the requested address or the parent block
+	if family == "" {
+		family = ipfamilyofaddress(d.Get("request_ip").(string))
+	}
+
+	if family == "" {
+		parent := d.Get("block").(string)
+
+		if blocks := toStringArray(d.Get("blocks").([]interface{})); len(blocks) > 0 {
+			parent = blocks[0]
+		}
+
+		if len(parent) > 0 {
+			siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+			if siteErr != nil {
+				// Reporting a failure
+				return diag.FromErr(siteErr)
+			}
+
+			var familyErr error = nil
+
+			family, familyErr = ipfamilyofsubnet(siteID, parent, false, meta)
+
+			if familyErr != nil {
+				// Reporting a failure
+				return diag.FromErr(familyErr)
+			}
+		} else {
+			family = "ipv4"
+		}
+	}
+
+	d.Set("family", family)
+
+	// VLSM delegation is only available for IPv4 subnets
+	if family == "ipv6" && len(d.Get("parent_subnet").(string)) > 0 {
+		return diag.Errorf("Can't delegate an IPv6 subnet from a parent subnet: %s", d.Get("name").(string))
+	}
+
+	if diags := resourcesubnetfamily(family).CreateContext(ctx, d, meta); diags.HasError() {
+		// Reporting a failure
+		return diags
+	}
+
+	if len(d.Get("dual_stack").([]interface{})) == 0 {
+		return nil
+	}
+
+	// Allocating the paired subnet
+	pairResource, pair := resourcesubnetpair(d)
+
+	if diags := pairResource.CreateContext(ctx, pair, meta); diags.HasError() {
+		// Rolling back the subnet
+		if delDiags := resourcesubnetfamily(family).DeleteContext(ctx, d, meta); delDiags.HasError() {
+			tflog.Debug(ctx, fmt.Sprintf("Unable to roll back the creation of IP subnet: %s\n", d.Get("name").(string)))
+		}
+
+		// Reporting a failure
+		return diags
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Created paired %s subnet (oid): %s\n", ipfamilyother(family), pair.Id()))
+	resourcesubnetsetpair(d, pair)
+
+	return nil
+}
+
+func resourcesubnetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := resourcesubnetfamily(d.Get("family").(string)).UpdateContext(ctx, d, meta); diags.HasError() {
+		// Reporting a failure
+		return diags
+	}
+
+	// Keeping the paired subnet linked
+	if len(d.Get("dual_stack").([]interface{})) > 0 && d.HasChanges("name", "terminal", "vlan_domain", "vlan_id", "class", "class_parameters") {
+		if err := resourcesubnetpairedit(ctx, d, meta); err != nil {
+			// Reporting a failure
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourcesubnetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// Deleting the paired subnet first
+	if len(d.Get("dual_stack").([]interface{})) > 0 {
+		if pairResource, pair := resourcesubnetpair(d); len(pair.Id()) > 0 {
+			if diags := pairResource.DeleteContext(ctx, pair, meta); diags.HasError() {
+				// Reporting a failure
+				return diags
+			}
+		}
+	}
+
+	return resourcesubnetfamily(d.Get("family").(string)).DeleteContext(ctx, d, meta)
+}
+
+func resourcesubnetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := resourcesubnetfamily(d.Get("family").(string)).ReadContext(ctx, d, meta); diags.HasError() {
+		// Reporting a failure
+		return diags
+	}
+
+	if len(d.Get("dual_stack").([]interface{})) > 0 {
+		pairResource, pair := resourcesubnetpair(d)
+
+		if diags := pairResource.ReadContext(ctx, pair, meta); diags.HasError() {
+			// Reporting a failure
+			return diags
+		}
+
+		resourcesubnetsetpair(d, pair)
+	}
+
+	return nil
+}
+
+func resourcesubnetImportState(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	family, oid, err := ipfamilyimportid(d.Id())
+
+	if err != nil {
+		// Reporting a failure
+		return nil, err
+	}
+
+	d.SetId(oid)
+	d.Set("family", family)
+
+	return resourcesubnetfamily(family).Importer.StateContext(ctx, d, meta)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"net/url"
 	"strconv"
+	"strings"
 )
 
 func resourcevlan() *schema.Resource {
@@ -86,6 +87,11 @@ func resourcevlanCreate(ctx context.Context, d *schema.ResourceData, meta interf
 
 	var vlanIDs []string = nil
 
+	// Serializing the allocations within the VLAN domain
+	allocationKey := "vlan_domain:" + strings.ToLower(d.Get("vlan_domain").(string))
+	unlock := s.Allocations.lock(allocationKey)
+	defer unlock()
+
 	// Determining if a VLAN ID was submitted in or if we should get one from the VLAN Manager
 	if d.Get("request_id").(int) > 0 {
 		vlanIDs = []string{strconv.Itoa(d.Get("request_id").(int))}
@@ -98,6 +104,9 @@ func resourcevlanCreate(ctx context.Context, d *schema.ResourceData, meta interf
 			// Reporting a failure
 			return diag.FromErr(vlanErr)
 		}
+
+		// Skipping the VLAN IDs already allocated by parallel creations
+		vlanIDs = s.Allocations.filter(allocationKey, vlanIDs)
 	}
 
 	for i := 0; i < len(vlanIDs); i++ {
@@ -114,7 +123,7 @@ func resourcevlanCreate(ctx context.Context, d *schema.ResourceData, meta interf
 		parameters.Add("vlmvlan_name", d.Get("name").(string))
 
 		if s.Version < 730 {
-			tflog.Info(ctx, fmt.Sprintf("VLAN class parameters are not supported in SOLIDserver Version (%i)\n", s.Version))
+			tflog.Info(ctx, fmt.Sprintf("VLAN class parameters are not supported in SOLIDserver Version (%d)\n", s.Version))
 		} else {
 			parameters.Add("vlmvlan_class_name", d.Get("class").(string))
 			parameters.Add("vlmvlan_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
@@ -132,6 +141,8 @@ func resourcevlanCreate(ctx context.Context, d *schema.ResourceData, meta interf
 				if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
 					tflog.Debug(ctx, fmt.Sprintf("Created vlan (oid): %s\n", oid))
 
+					s.Allocations.claim(allocationKey, vlanIDs[i])
+
 					vnid, _ := strconv.Atoi(vlanIDs[i])
 					d.Set("vlan_id", vnid)
 					d.SetId(oid)
@@ -169,7 +180,7 @@ func resourcevlanUpdate(ctx context.Context, d *schema.ResourceData, meta interf
 	parameters.Add("vlmvlan_name", d.Get("name").(string))
 
 	if s.Version < 730 {
-		tflog.Info(ctx, fmt.Sprintf("VLAN class parameters are not supported in SOLIDserver Version (%i)\n", s.Version))
+		tflog.Info(ctx, fmt.Sprintf("VLAN class parameters are not supported in SOLIDserver Version (%d)\n", s.Version))
 	} else {
 		parameters.Add("vlmvlan_class_name", d.Get("class").(string))
 		parameters.Add("vlmvlan_class_parameters", urlfromclassparams(d.Get("class_parameters")).Encode())
@@ -276,7 +287,7 @@ func resourcevlanRead(ctx context.Context, d *schema.ResourceData, meta interfac
 			d.Set("vlan_id", vnid)
 
 			if s.Version < 730 {
-				tflog.Info(ctx, fmt.Sprintf("VLAN class parameters are not supported in SOLIDserver Version (%i)\n", s.Version))
+				tflog.Info(ctx, fmt.Sprintf("VLAN class parameters are not supported in SOLIDserver Version (%d)\n", s.Version))
 			} else {
 				d.Set("class", buf[0]["vlmvlan_class_name"].(string))
 
@@ -343,7 +354,7 @@ func resourcevlanImportState(ctx context.Context, d *schema.ResourceData, meta i
 			d.Set("vlan_id", vnid)
 
 			if s.Version < 730 {
-				tflog.Info(ctx, fmt.Sprintf("VLAN class parameters are not supported in SOLIDserver Version (%i)\n", s.Version))
+				tflog.Info(ctx, fmt.Sprintf("VLAN class parameters are not supported in SOLIDserver Version (%d)\n", s.Version))
 			} else {
 				d.Set("class", buf[0]["vlmvlan_class_name"].(string))
 
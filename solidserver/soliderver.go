@@ -49,6 +49,7 @@ type SOLIDserver struct {
 	Version                  int
 	Authenticated            bool
 	ProxyURL                 string
+	Allocations              *allocationCoordinator
 }
 
 func NewSOLIDserver(ctx context.Context, host string, use_token bool, username string, password string, sslverify bool, certsfile string, timeout int, version string, proxyURL string) (*SOLIDserver, diag.Diagnostics) {
@@ -65,6 +66,7 @@ func NewSOLIDserver(ctx context.Context, host string, use_token bool, username s
 		Version:                  0,
 		Authenticated:            false,
 		ProxyURL:                 proxyURL,
+		Allocations:              newallocationcoordinator(),
 	}
 
 	if err := s.GetVersion(version); err != nil {
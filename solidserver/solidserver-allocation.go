@@ -0,0 +1,104 @@
+package solidserver
+
+import (
+	"sort"
+	"sync"
+)
+
+// Coordinate the allocations sharing the same parent (subnet, block, space or VLAN domain)
+// so that parallel creations within a provider instance get distinct candidates
+type allocationCoordinator struct {
+	mutex   sync.Mutex
+	locks   map[string]*sync.Mutex
+	claimed map[string]map[string]bool
+}
+
+func newallocationcoordinator() *allocationCoordinator {
+	return &allocationCoordinator{
+		locks:   map[string]*sync.Mutex{},
+		claimed: map[string]map[string]bool{},
+	}
+}
+
+// Lock the given parents until the returned function is called
+// Parents are always locked in the same order to prevent deadlocks between overlapping allocations
+func (c *allocationCoordinator) lock(keys ...string) func() {
+	if c == nil {
+		return func() {}
+	}
+
+	sorted := []string{}
+	seen := map[string]bool{}
+
+	for _, key := range keys {
+		if !seen[key] {
+			seen[key] = true
+			sorted = append(sorted, key)
+		}
+	}
+
+	sort.Strings(sorted)
+
+	locks := []*sync.Mutex{}
+
+	c.mutex.Lock()
+	for _, key := range sorted {
+		if _, exist := c.locks[key]; !exist {
+			c.locks[key] = &sync.Mutex{}
+		}
+
+		locks = append(locks, c.locks[key])
+	}
+	c.mutex.Unlock()
+
+	for _, l := range locks {
+		l.Lock()
+	}
+
+	return func() {
+		for i := len(locks) - 1; i >= 0; i-- {
+			locks[i].Unlock()
+		}
+	}
+}
+
+// Record a value allocated by the provider within a parent
+func (c *allocationCoordinator) claim(key string, value string) {
+	if c == nil {
+		return
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if _, exist := c.claimed[key]; !exist {
+		c.claimed[key] = map[string]bool{}
+	}
+
+	c.claimed[key][value] = true
+}
+
+// Return true if a value was already allocated by the provider within a parent
+func (c *allocationCoordinator) isclaimed(key string, value string) bool {
+	if c == nil {
+		return false
+	}
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.claimed[key][value]
+}
+
+// Remove the values already allocated by the provider within a parent from a list of candidates
+func (c *allocationCoordinator) filter(key string, candidates []string) []string {
+	res := []string{}
+
+	for _, candidate := range candidates {
+		if !c.isclaimed(key, candidate) {
+			res = append(res, candidate)
+		}
+	}
+
+	return res
+}
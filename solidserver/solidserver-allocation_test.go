@@ -0,0 +1,61 @@
+package solidserver
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestAllocationCoordinator(t *testing.T) {
+	c := newallocationcoordinator()
+	candidates := []string{"10.0.0.1", "10.0.0.2", "10.0.0.3", "10.0.0.4"}
+	results := make(chan string, len(candidates))
+	wg := sync.WaitGroup{}
+
+	// Parallel allocations within the same subnet must get distinct candidates
+	for i := 0; i < len(candidates); i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			unlock := c.lock("ip_subnet:3", "ip_subnet:3")
+			defer unlock()
+
+			if free := c.filter("ip_subnet:3", candidates); len(free) > 0 {
+				c.claim("ip_subnet:3", free[0])
+				results <- free[0]
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(results)
+
+	seen := map[string]bool{}
+
+	for result := range results {
+		if seen[result] {
+			t.Errorf("candidate %s allocated twice", result)
+		}
+
+		seen[result] = true
+	}
+
+	if len(seen) != len(candidates) {
+		t.Errorf("expected %d allocations, got %d", len(candidates), len(seen))
+	}
+
+	// Claims are scoped to their parent
+	if c.isclaimed("ip_subnet:4", "10.0.0.1") {
+		t.Errorf("expected claims to be scoped to their parent")
+	}
+
+	// A nil coordinator does not coordinate anything
+	var n *allocationCoordinator = nil
+	n.lock("ip_subnet:3")()
+	n.claim("ip_subnet:3", "10.0.0.1")
+
+	if len(n.filter("ip_subnet:3", candidates)) != len(candidates) {
+		t.Errorf("expected a nil coordinator to keep every candidate")
+	}
+}
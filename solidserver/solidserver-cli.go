@@ -0,0 +1,121 @@
+package solidserver
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// Connection settings shared by the provider sub-commands (export, doctor ...)
+// Defaults are taken from the same environment variables as the provider configuration
+type cliConfig struct {
+	Host                     string
+	UseToken                 bool
+	Username                 string
+	Password                 string
+	SSLVerify                bool
+	AdditionalTrustCertsFile string
+	Timeout                  int
+	Version                  string
+	ProxyURL                 string
+}
+
+// Return the value of the first environment variable set among the SOLIDSERVER_<name>
+// and SOLIDServer_<name> variants, otherwise the default value
+func clienv(name string, def string) string {
+	for _, prefix := range []string{"SOLIDSERVER_", "SOLIDServer_"} {
+		if v, exist := os.LookupEnv(prefix + name); exist && v != "" {
+			return v
+		}
+	}
+
+	return def
+}
+
+func clienvbool(name string, def bool) bool {
+	if v, err := strconv.ParseBool(clienv(name, strconv.FormatBool(def))); err == nil {
+		return v
+	}
+
+	return def
+}
+
+// Register the connection flags on the given flag set
+func cliconfigflags(fs *flag.FlagSet) *cliConfig {
+	c := &cliConfig{}
+
+	fs.StringVar(&c.Host, "host", clienv("HOST", ""), "SOLIDserver hostname or IP address (SOLIDSERVER_HOST)")
+	fs.BoolVar(&c.UseToken, "use_token", clienvbool("USE_TOKEN", false), "Username/password are token/secret (SOLIDSERVER_USE_TOKEN)")
+	fs.StringVar(&c.Username, "username", clienv("USERNAME", ""), "SOLIDserver API user ID or token ID (SOLIDSERVER_USERNAME)")
+	fs.StringVar(&c.Password, "password", clienv("PASSWORD", ""), "SOLIDserver API user password or token secret (SOLIDSERVER_PASSWORD)")
+	fs.BoolVar(&c.SSLVerify, "sslverify", clienvbool("SSLVERIFY", true), "Enable/Disable ssl verify (SOLIDSERVER_SSLVERIFY)")
+	fs.StringVar(&c.AdditionalTrustCertsFile, "additional_trust_certs_file", clienv("ADDITIONALTRUSTCERTSFILE", ""), "PEM formatted file with additional certificates to trust (SOLIDSERVER_ADDITIONALTRUSTCERTSFILE)")
+	fs.IntVar(&c.Timeout, "timeout", 10, "API call timeout value in seconds")
+	fs.StringVar(&c.Version, "solidserverversion", clienv("VERSION", ""), "SOLIDserver version in case API user does not have admin permissions (SOLIDSERVER_VERSION)")
+	fs.StringVar(&c.ProxyURL, "proxy_url", clienv("PROXY_URL", ""), "URL of the proxy used to reach the SOLIDserver (SOLIDSERVER_PROXY_URL)")
+
+	return c
+}
+
+// Check the mandatory connection settings
+func (c *cliConfig) validate() error {
+	missing := []string{}
+
+	if c.Host == "" {
+		missing = append(missing, "host")
+	}
+	if c.Username == "" {
+		missing = append(missing, "username")
+	}
+	if c.Password == "" {
+		missing = append(missing, "password")
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("missing connection setting(s): %s", strings.Join(missing, ", "))
+	}
+
+	if diags := validateProxyURLValue(c.ProxyURL, nil); diags.HasError() {
+		return fmt.Errorf("invalid proxy_url: %s", diags[0].Summary)
+	}
+
+	return nil
+}
+
+// Establish the connection the same way ProviderConfigure does
+func (c *cliConfig) connect(ctx context.Context) (*SOLIDserver, diag.Diagnostics) {
+	return NewSOLIDserver(ctx, c.Host, c.UseToken, c.Username, c.Password, c.SSLVerify, c.AdditionalTrustCertsFile, c.Timeout, c.Version, c.ProxyURL)
+}
+
+// Format diagnostics for a command line output
+func clidiagstring(diags diag.Diagnostics) string {
+	msgs := []string{}
+
+	for _, d := range diags {
+		if d.Detail != "" {
+			msgs = append(msgs, strings.TrimSpace(d.Summary)+": "+strings.TrimSpace(d.Detail))
+		} else {
+			msgs = append(msgs, strings.TrimSpace(d.Summary))
+		}
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// Split a comma separated list of names
+func clilist(value string) []string {
+	res := []string{}
+
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			res = append(res, v)
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,434 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"strconv"
+	"strings"
+)
+
+// Maximum length of a TXT record character-string (RFC 1035)
+const dnsTXTSegmentLength = 255
+
+// Maximum number of values of a RR
+const dnsRRMaxValues = 7
+
+// Field of a typed RR, mapped to a value (value1..value7) of the RR
+type dnsRRField struct {
+	Name        string
+	Description string
+	Integer     bool
+	Min         int
+	Max         int
+}
+
+// Fields of the RR types with several values, in the order of their values
+var dnsRRTypedFields = map[string][]dnsRRField{
+	"MX": {
+		{Name: "priority", Description: "The preference of the mail exchanger.", Integer: true, Min: 0, Max: 65535},
+		{Name: "target", Description: "The host name of the mail exchanger."},
+	},
+	"SRV": {
+		{Name: "priority", Description: "The priority of the target host.", Integer: true, Min: 0, Max: 65535},
+		{Name: "weight", Description: "The relative weight of the targets of the same priority.", Integer: true, Min: 0, Max: 65535},
+		{Name: "port", Description: "The port of the service on the target host.", Integer: true, Min: 0, Max: 65535},
+		{Name: "target", Description: "The host name of the target host."},
+	},
+	"CAA": {
+		{Name: "flags", Description: "The flags of the CAA record (ex: 128 for critical).", Integer: true, Min: 0, Max: 255},
+		{Name: "tag", Description: "The property tag (ex: issue, issuewild or iodef)."},
+		{Name: "value", Description: "The property value (ex: letsencrypt.org)."},
+	},
+	"NAPTR": {
+		{Name: "order", Description: "The order in which the records must be processed.", Integer: true, Min: 0, Max: 65535},
+		{Name: "preference", Description: "The preference of the records of the same order.", Integer: true, Min: 0, Max: 65535},
+		{Name: "flags", Description: "The flags controlling the rewriting (ex: U, S, A or P)."},
+		{Name: "services", Description: "The services available through the rewrite path (ex: E2U+sip)."},
+		{Name: "regexp", Description: "The substitution expression applied to the original string."},
+		{Name: "replacement", Description: "The next domain name to query."},
+	},
+	"SSHFP": {
+		{Name: "algorithm", Description: "The SSH key algorithm (1: RSA, 2: DSA, 3: ECDSA, 4: Ed25519).", Integer: true, Min: 0, Max: 255},
+		{Name: "fingerprint_type", Description: "The fingerprint type (1: SHA-1, 2: SHA-256).", Integer: true, Min: 0, Max: 255},
+		{Name: "fingerprint", Description: "The hexadecimal fingerprint of the SSH key."},
+	},
+	"TLSA": {
+		{Name: "usage", Description: "The certificate usage (0 to 3).", Integer: true, Min: 0, Max: 255},
+		{Name: "selector", Description: "The part of the certificate to match (0: full certificate, 1: public key).", Integer: true, Min: 0, Max: 255},
+		{Name: "matching_type", Description: "The matching type (0: exact, 1: SHA-256, 2: SHA-512).", Integer: true, Min: 0, Max: 255},
+		{Name: "certificate", Description: "The hexadecimal certificate association data."},
+	},
+	"HINFO": {
+		{Name: "cpu", Description: "The CPU type of the host."},
+		{Name: "os", Description: "The operating system of the host."},
+	},
+}
+
+// Return the sorted list of supported RR types
+func dnsrrtypes() []string {
+	return []string{"A", "AAAA", "PTR", "CNAME", "DNAME", "TXT", "NS", "MX", "SRV", "CAA", "NAPTR", "SSHFP", "TLSA", "HINFO"}
+}
+
+// Return the name of the attribute block of a typed RR (ex: mx)
+func dnsrrblockname(rrType string) string {
+	return strings.ToLower(rrType)
+}
+
+// Return the attribute blocks of the typed RRs
+func dnsrrtypedschema() map[string]*schema.Schema {
+	res := map[string]*schema.Schema{}
+
+	for rrType, fields := range dnsRRTypedFields {
+		fieldsSchema := map[string]*schema.Schema{}
+
+		for i, field := range fields {
+			fieldSchema := &schema.Schema{
+				Type:        schema.TypeString,
+				Description: fmt.Sprintf("%s (value%d)", strings.TrimSuffix(field.Description, "."), i+1),
+				Required:    true,
+			}
+
+			if field.Integer {
+				fieldSchema.Type = schema.TypeInt
+				fieldSchema.ValidateFunc = validation.IntBetween(field.Min, field.Max)
+			} else {
+				fieldSchema.ValidateFunc = validation.StringIsNotEmpty
+			}
+
+			fieldsSchema[field.Name] = fieldSchema
+		}
+
+		res[dnsrrblockname(rrType)] = &schema.Schema{
+			Type:         schema.TypeList,
+			Description:  fmt.Sprintf("The values of the %s RR to create, instead of value.", rrType),
+			Optional:     true,
+			ForceNew:     true,
+			MaxItems:     1,
+			ExactlyOneOf: dnsrrvalueattributes(),
+			Elem: &schema.Resource{
+				Schema: fieldsSchema,
+			},
+		}
+	}
+
+	return res
+}
+
+// Split a long TXT value into quoted character-strings of at most 255 characters
+// Values already quoted or short enough are kept as is
+func dnstxtsplit(value string) string {
+	if len(value) <= dnsTXTSegmentLength || strings.HasPrefix(value, "\"") {
+		return value
+	}
+
+	segments := []string{}
+
+	for len(value) > 0 {
+		length := dnsTXTSegmentLength
+
+		if len(value) < length {
+			length = len(value)
+		}
+
+		segments = append(segments, "\""+value[:length]+"\"")
+		value = value[length:]
+	}
+
+	return strings.Join(segments, " ")
+}
+
+// Join the quoted character-strings of a TXT value split by dnstxtsplit
+func dnstxtjoin(value string) string {
+	if !strings.HasPrefix(value, "\"") || !strings.HasSuffix(value, "\"") {
+		return value
+	}
+
+	segments := strings.Split(value[1:len(value)-1], "\" \"")
+
+	for _, segment := range segments {
+		if strings.Contains(segment, "\"") {
+			return value
+		}
+	}
+
+	joined := strings.Join(segments, "")
+
+	// Only values split because of their length are joined back
+	if len(segments) < 2 || len(joined) <= dnsTXTSegmentLength {
+		return value
+	}
+
+	return joined
+}
+
+// Remove the quotes of a TXT value made of a single character-string (ex: "v=spf1 -all")
+// Values made of several character-strings or holding inner quotes are kept as is
+func dnstxtunquote(value string) string {
+	if len(value) < 2 || !strings.HasPrefix(value, "\"") || !strings.HasSuffix(value, "\"") || strings.Contains(value[1:len(value)-1], "\"") {
+		return value
+	}
+
+	return value[1 : len(value)-1]
+}
+
+// Return the values (value1..value7) of a RR from its value or its typed attribute block
+func dnsrrvalues(d *schema.ResourceData) ([]string, error) {
+	rrType := strings.ToUpper(d.Get("type").(string))
+
+	fields, typed := dnsRRTypedFields[rrType]
+
+	if !typed {
+		if rrType == "TXT" {
+			return []string{dnstxtsplit(d.Get("value").(string))}, nil
+		}
+
+		return []string{d.Get("value").(string)}, nil
+	}
+
+	block := d.Get(dnsrrblockname(rrType)).([]interface{})
+
+	if len(block) == 0 || block[0] == nil {
+		return nil, fmt.Errorf("SOLIDServer - RR: %s of type %s requires a %s block\n", d.Get("name").(string), rrType, dnsrrblockname(rrType))
+	}
+
+	res := []string{}
+	values := block[0].(map[string]interface{})
+
+	for _, field := range fields {
+		if field.Integer {
+			res = append(res, strconv.Itoa(values[field.Name].(int)))
+		} else {
+			res = append(res, values[field.Name].(string))
+		}
+	}
+
+	return res, nil
+}
+
+// Set the value or the typed attribute block of a RR from its values (value1..value7)
+func dnsrrsetvalues(d *schema.ResourceData, rrType string, values []string) {
+	rrType = strings.ToUpper(rrType)
+
+	fields, typed := dnsRRTypedFields[rrType]
+
+	if !typed {
+		switch rrType {
+		case "AAAA":
+			d.Set("value", longip6toshortip6(values[0]))
+		case "TXT":
+			d.Set("value", dnstxtjoin(values[0]))
+		default:
+			d.Set("value", values[0])
+		}
+
+		return
+	}
+
+	block := map[string]interface{}{}
+
+	for i, field := range fields {
+		value := ""
+
+		if i < len(values) {
+			value = values[i]
+		}
+
+		if field.Integer {
+			block[field.Name], _ = strconv.Atoi(value)
+		} else {
+			block[field.Name] = value
+		}
+	}
+
+	d.Set(dnsrrblockname(rrType), []interface{}{block})
+}
+
+// Return the WHERE clause matching the values (value1..value7) of a RR
+func dnsrrvalueswhere(rrType string, values []string) string {
+	clauses := []string{}
+
+	for i, value := range values {
+		if i == 0 && strings.ToUpper(rrType) == "AAAA" {
+			value = shortip6tolongip6(value)
+		}
+
+		clauses = append(clauses, "value"+strconv.Itoa(i+1)+"='"+strings.ReplaceAll(value, "'", "''")+"'")
+	}
+
+	return strings.Join(clauses, " AND ")
+}
+
+// Return the values (value1..value7) of a RR from a rest/dns_rr_list or rest/dns_rr_info entry
+func dnsrrentryvalues(entry map[string]interface{}) []string {
+	res := []string{}
+
+	for i := 1; i <= dnsRRMaxValues; i++ {
+		value, _ := entry["value"+strconv.Itoa(i)].(string)
+		res = append(res, value)
+	}
+
+	// Trimming the unused values
+	for len(res) > 1 && res[len(res)-1] == "" {
+		res = res[:len(res)-1]
+	}
+
+	return res
+}
+
+// Check at plan time that the typed attribute block of a RR matches its type
+func resourcednsrrdiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	config := d.GetRawConfig()
+
+	if !d.NewValueKnown("type") || config.IsNull() || !config.IsWhollyKnown() {
+		return nil
+	}
+
+	rrType := strings.ToUpper(d.Get("type").(string))
+	expected := "value"
+
+	if _, typed := dnsRRTypedFields[rrType]; typed {
+		expected = dnsrrblockname(rrType)
+	}
+
+	if attr := config.GetAttr(expected); attr.IsNull() || (attr.Type().IsListType() && attr.LengthInt() == 0) {
+		return fmt.Errorf("RR of type %s requires the %s attribute", rrType, expected)
+	}
+
+	return nil
+}
+
+// Return the names of the value attribute and of the typed attribute blocks
+func dnsrrvalueattributes() []string {
+	res := []string{"value"}
+
+	for _, rrType := range dnsrrtypes() {
+		if _, typed := dnsRRTypedFields[rrType]; typed {
+			res = append(res, dnsrrblockname(rrType))
+		}
+	}
+
+	return res
+}
+
+// Split a RR presentation text into fields, supporting double-quoted fields
+func dnsrrtokenize(text string) []string {
+	res := []string{}
+	current := strings.Builder{}
+	quoted, started := false, false
+
+	for _, c := range text {
+		switch {
+		case c == '"':
+			quoted, started = !quoted, true
+		case (c == ' ' || c == '\t') && !quoted:
+			if started {
+				res = append(res, current.String())
+				current.Reset()
+				started = false
+			}
+		default:
+			current.WriteRune(c)
+			started = true
+		}
+	}
+
+	if started {
+		res = append(res, current.String())
+	}
+
+	return res
+}
+
+// Return the values (value1..value7) of a RR from its presentation text (ex: "10 mail.example.com" for a MX)
+func dnsrrvaluesfromtext(rrType string, text string) ([]string, error) {
+	rrType = strings.ToUpper(rrType)
+
+	fields, typed := dnsRRTypedFields[rrType]
+
+	if !typed {
+		if rrType == "TXT" {
+			return []string{dnstxtsplit(text)}, nil
+		}
+
+		return []string{strings.TrimSpace(text)}, nil
+	}
+
+	tokens := dnsrrtokenize(text)
+
+	if len(tokens) != len(fields) {
+		return nil, fmt.Errorf("SOLIDServer - Invalid %s RR value: %s, expecting %d fields\n", rrType, text, len(fields))
+	}
+
+	for i, field := range fields {
+		if field.Integer {
+			if v, err := strconv.Atoi(tokens[i]); err != nil || v < field.Min || v > field.Max {
+				return nil, fmt.Errorf("SOLIDServer - Invalid %s RR value: %s, invalid %s\n", rrType, text, field.Name)
+			}
+		}
+	}
+
+	return tokens, nil
+}
+
+// Return the presentation text of a RR from its values (value1..value7)
+func dnsrrtextfromvalues(rrType string, values []string) string {
+	rrType = strings.ToUpper(rrType)
+
+	if len(values) == 0 {
+		return ""
+	}
+
+	fields, typed := dnsRRTypedFields[rrType]
+
+	if !typed {
+		switch rrType {
+		case "AAAA":
+			if short := longip6toshortip6(values[0]); len(short) > 0 {
+				return short
+			}
+		case "TXT":
+			return dnstxtjoin(values[0])
+		}
+
+		return values[0]
+	}
+
+	tokens := []string{}
+
+	for i := range fields {
+		value := ""
+
+		if i < len(values) {
+			value = values[i]
+		}
+
+		if value == "" || strings.ContainsAny(value, " \t") {
+			value = "\"" + value + "\""
+		}
+
+		tokens = append(tokens, value)
+	}
+
+	return strings.Join(tokens, " ")
+}
+
+// Return the normalized presentation text of a RR, used to compare values
+func dnsrrnormalizetext(rrType string, text string) string {
+	values, err := dnsrrvaluesfromtext(rrType, text)
+
+	if err != nil {
+		return text
+	}
+
+	// Domain names and addresses are case insensitive
+	switch strings.ToUpper(rrType) {
+	case "A", "AAAA", "PTR", "CNAME", "DNAME", "NS", "MX", "SRV":
+		return strings.ToLower(dnsrrtextfromvalues(rrType, values))
+	case "TXT":
+		return dnstxtunquote(dnsrrtextfromvalues(rrType, values))
+	}
+
+	return dnsrrtextfromvalues(rrType, values)
+}
@@ -0,0 +1,258 @@
+package solidserver
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDnsTxtSplit(t *testing.T) {
+	long := strings.Repeat("a", 300)
+
+	testCases := map[string]string{
+		"short":  "v=spf1 -all",
+		"quoted": "\"" + long + "\"",
+		"long":   "\"" + strings.Repeat("a", 255) + "\" \"" + strings.Repeat("a", 45) + "\"",
+	}
+
+	values := map[string]string{
+		"short":  "v=spf1 -all",
+		"quoted": "\"" + long + "\"",
+		"long":   long,
+	}
+
+	for name, expected := range testCases {
+		t.Run(name, func(t *testing.T) {
+			split := dnstxtsplit(values[name])
+
+			if split != expected {
+				t.Errorf("unexpected split value: %q (expected: %q)", split, expected)
+			}
+
+			if joined := dnstxtjoin(split); joined != values[name] {
+				t.Errorf("unexpected joined value: %q (expected: %q)", joined, values[name])
+			}
+		})
+	}
+}
+
+func TestDnsRRValues(t *testing.T) {
+
+	type testCase struct {
+		Raw      map[string]interface{}
+		Expected []string
+		Block    string
+	}
+
+	testCases := map[string]testCase{
+		"a": {
+			Raw: map[string]interface{}{
+				"type":  "A",
+				"value": "10.0.0.1",
+			},
+			Expected: []string{"10.0.0.1"},
+		},
+		"mx": {
+			Raw: map[string]interface{}{
+				"type": "MX",
+				"mx": []interface{}{map[string]interface{}{
+					"priority": 10,
+					"target":   "mail.example.com",
+				}},
+			},
+			Expected: []string{"10", "mail.example.com"},
+			Block:    "mx",
+		},
+		"srv": {
+			Raw: map[string]interface{}{
+				"type": "srv",
+				"srv": []interface{}{map[string]interface{}{
+					"priority": 0,
+					"weight":   5,
+					"port":     5060,
+					"target":   "sip.example.com",
+				}},
+			},
+			Expected: []string{"0", "5", "5060", "sip.example.com"},
+			Block:    "srv",
+		},
+		"naptr": {
+			Raw: map[string]interface{}{
+				"type": "NAPTR",
+				"naptr": []interface{}{map[string]interface{}{
+					"order":       100,
+					"preference":  10,
+					"flags":       "U",
+					"services":    "E2U+sip",
+					"regexp":      "!^.*$!sip:info@example.com!",
+					"replacement": ".",
+				}},
+			},
+			Expected: []string{"100", "10", "U", "E2U+sip", "!^.*$!sip:info@example.com!", "."},
+			Block:    "naptr",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourcednsrr().Schema, tc.Raw)
+
+			values, err := dnsrrvalues(d)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(values, tc.Expected) {
+				t.Errorf("unexpected values: %v (expected: %v)", values, tc.Expected)
+			}
+
+			// Reading the values back
+			r := schema.TestResourceDataRaw(t, resourcednsrr().Schema, map[string]interface{}{"type": tc.Raw["type"]})
+			dnsrrsetvalues(r, tc.Raw["type"].(string), values)
+
+			if len(tc.Block) > 0 && !reflect.DeepEqual(r.Get(tc.Block), d.Get(tc.Block)) {
+				t.Errorf("unexpected block: %v (expected: %v)", r.Get(tc.Block), d.Get(tc.Block))
+			}
+
+			if len(tc.Block) == 0 && r.Get("value") != d.Get("value") {
+				t.Errorf("unexpected value: %v (expected: %v)", r.Get("value"), d.Get("value"))
+			}
+		})
+	}
+}
+
+func TestDnsRRValuesWhere(t *testing.T) {
+	testCases := map[string]string{
+		"MX":   "value1='10' AND value2='mail.example.com'",
+		"AAAA": "value1='2001:0db8:0000:0000:0000:0000:0000:0001'",
+		"TXT":  "value1='it''s'",
+	}
+
+	values := map[string][]string{
+		"MX":   {"10", "mail.example.com"},
+		"AAAA": {"2001:db8::1"},
+		"TXT":  {"it's"},
+	}
+
+	for rrType, expected := range testCases {
+		t.Run(rrType, func(t *testing.T) {
+			if where := dnsrrvalueswhere(rrType, values[rrType]); where != expected {
+				t.Errorf("unexpected clause: %q (expected: %q)", where, expected)
+			}
+		})
+	}
+}
+
+func TestDnsRRTextValues(t *testing.T) {
+
+	type testCase struct {
+		Type       string
+		Text       string
+		Values     []string
+		Normalized string
+		Error      bool
+	}
+
+	testCases := map[string]testCase{
+		"a": {
+			Type:       "A",
+			Text:       "10.0.0.1",
+			Values:     []string{"10.0.0.1"},
+			Normalized: "10.0.0.1",
+		},
+		"aaaa": {
+			Type:       "AAAA",
+			Text:       "2001:0db8:0000:0000:0000:0000:0000:0001",
+			Values:     []string{"2001:0db8:0000:0000:0000:0000:0000:0001"},
+			Normalized: "2001:db8::1",
+		},
+		"mx": {
+			Type:       "MX",
+			Text:       "10   Mail.Example.com",
+			Values:     []string{"10", "Mail.Example.com"},
+			Normalized: "10 mail.example.com",
+		},
+		"hinfo": {
+			Type:       "HINFO",
+			Text:       "\"Intel Xeon\" Linux",
+			Values:     []string{"Intel Xeon", "Linux"},
+			Normalized: "\"Intel Xeon\" Linux",
+		},
+		"txt": {
+			Type:       "TXT",
+			Text:       "v=spf1 -all",
+			Values:     []string{"v=spf1 -all"},
+			Normalized: "v=spf1 -all",
+		},
+		"txt_quoted": {
+			Type:       "TXT",
+			Text:       "\"v=spf1 -all\"",
+			Values:     []string{"\"v=spf1 -all\""},
+			Normalized: "v=spf1 -all",
+		},
+		"txt_segments": {
+			Type:       "TXT",
+			Text:       "\"v=spf1 mx\" \" -all\"",
+			Values:     []string{"\"v=spf1 mx\" \" -all\""},
+			Normalized: "\"v=spf1 mx\" \" -all\"",
+		},
+		"mx_missing_field": {
+			Type:  "MX",
+			Text:  "mail.example.com",
+			Error: true,
+		},
+		"srv_invalid_port": {
+			Type:  "SRV",
+			Text:  "0 5 70000 sip.example.com",
+			Error: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			values, err := dnsrrvaluesfromtext(tc.Type, tc.Text)
+
+			if (err != nil) != tc.Error {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(values, tc.Values) {
+				t.Errorf("unexpected values: %q (expected: %q)", values, tc.Values)
+			}
+
+			if normalized := dnsrrnormalizetext(tc.Type, tc.Text); normalized != tc.Normalized {
+				t.Errorf("unexpected normalized text: %q (expected: %q)", normalized, tc.Normalized)
+			}
+		})
+	}
+}
+
+func TestDnsRRSetTTL(t *testing.T) {
+	type testCase struct {
+		Current  int
+		TTLs     []int
+		Expected int
+	}
+
+	testCases := map[string]testCase{
+		"empty":     {Current: 3600, TTLs: []int{}, Expected: 3600},
+		"unchanged": {Current: 3600, TTLs: []int{3600, 3600}, Expected: 3600},
+		"changed":   {Current: 3600, TTLs: []int{300, 300}, Expected: 300},
+		"member":    {Current: 3600, TTLs: []int{3600, 3600, 60}, Expected: 60},
+		"imported":  {Current: 0, TTLs: []int{300, 3600}, Expected: 300},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if ttl := dnsrrsetttl(tc.Current, tc.TTLs); ttl != tc.Expected {
+				t.Errorf("unexpected TTL: %d (expected: %d)", ttl, tc.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,501 @@
+package solidserver
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DNS record of a zone content
+type dnsZoneRecord struct {
+	ID    string
+	Name  string
+	Type  string
+	Value string
+	TTL   int
+}
+
+// Rule ignoring the records of a zone content by type and name
+type dnsZoneIgnore struct {
+	Type string
+	Name string
+}
+
+// Return the FQDN of a record name relative to its zone ("@" being the zone apex)
+func dnszonerecordfqdn(name string, zone string) string {
+	name, zone = strings.ToLower(strings.TrimSpace(name)), strings.ToLower(strings.TrimSuffix(zone, "."))
+
+	if name == "" || name == "@" {
+		return zone
+	}
+
+	if strings.HasSuffix(name, ".") {
+		return strings.TrimSuffix(name, ".")
+	}
+
+	if name == zone || strings.HasSuffix(name, "."+zone) {
+		return name
+	}
+
+	return name + "." + zone
+}
+
+// Return the key identifying a record by its name, type and normalized value
+func dnszonerecordkey(record dnsZoneRecord, zone string) string {
+	rrType := strings.ToUpper(record.Type)
+	return dnszonerecordfqdn(record.Name, zone) + " " + rrType + " " + dnsrrnormalizetext(rrType, record.Value)
+}
+
+// Return whether a record of a zone is ignored from its content
+// The SOA and the types not supported by the provider (ex: DNSSEC records) are always ignored, as well as the apex NS records unless managed
+func dnszonerecordignored(record dnsZoneRecord, zone string, manageApexNS bool, ignores []dnsZoneIgnore) bool {
+	rrType := strings.ToUpper(record.Type)
+	fqdn := dnszonerecordfqdn(record.Name, zone)
+
+	if stringOffsetInSlice(rrType, dnsrrtypes()) < 0 || (rrType == "NS" && !manageApexNS && fqdn == dnszonerecordfqdn("@", zone)) {
+		return true
+	}
+
+	for _, ignore := range ignores {
+		if len(ignore.Type) > 0 && strings.ToUpper(ignore.Type) != rrType {
+			continue
+		}
+
+		if len(ignore.Name) == 0 {
+			return true
+		}
+
+		if matched, _ := path.Match(dnszonerecordfqdn(ignore.Name, zone), fqdn); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Compute the records to add, update (TTL) and delete to turn the current content of a zone into the desired one
+func dnszonerecordsdiff(current []dnsZoneRecord, desired []dnsZoneRecord, zone string) ([]dnsZoneRecord, []dnsZoneRecord, []dnsZoneRecord) {
+	add, update, del := []dnsZoneRecord{}, []dnsZoneRecord{}, []dnsZoneRecord{}
+	expected := map[string]dnsZoneRecord{}
+
+	for _, record := range desired {
+		expected[dnszonerecordkey(record, zone)] = record
+	}
+
+	for _, record := range current {
+		key := dnszonerecordkey(record, zone)
+
+		if wanted, wantedExist := expected[key]; wantedExist {
+			if wanted.TTL != record.TTL {
+				wanted.ID = record.ID
+				update = append(update, wanted)
+			}
+
+			delete(expected, key)
+		} else {
+			del = append(del, record)
+		}
+	}
+
+	keys := []string{}
+
+	for key := range expected {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		add = append(add, expected[key])
+	}
+
+	return add, update, del
+}
+
+// Return the records of a zone
+func dnszonerecordslist(serverName string, viewName string, zoneName string, meta interface{}) ([]dnsZoneRecord, error) {
+	// Building parameters
+	parameters := url.Values{}
+	whereClause := "dns_name='" + wherequote(serverName) + "' AND dnszone_name='" + wherequote(strings.ToLower(zoneName)) + "' "
+
+	if len(viewName) != 0 {
+		whereClause += "AND dnsview_name='" + wherequote(viewName) + "' "
+	} else {
+		whereClause += "AND dnsview_name='#' "
+	}
+
+	parameters.Add("WHERE", whereClause)
+
+	// Sending the read request(s)
+	buf, err := solidserverlist("dns_rr_list", parameters, 0, meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := []dnsZoneRecord{}
+
+	for _, entry := range buf {
+		attributes := restobjectattributes(entry)
+		ttl, _ := strconv.Atoi(attributes["ttl"])
+
+		res = append(res, dnsZoneRecord{
+			ID:    attributes["rr_id"],
+			Name:  attributes["rr_full_name"],
+			Type:  strings.ToUpper(attributes["rr_type"]),
+			Value: dnsrrtextfromvalues(attributes["rr_type"], dnsrrentryvalues(entry)),
+			TTL:   ttl,
+		})
+	}
+
+	return res, nil
+}
+
+// Logical line of a zone file, once comments and parentheses are resolved
+type dnsZoneFileLine struct {
+	Number int
+	Blank  bool
+	Tokens []string
+}
+
+// Fields of the RR types holding a domain name, qualified with the origin when relative
+var dnsZoneFileNameFields = map[string]int{
+	"NS":    0,
+	"CNAME": 0,
+	"DNAME": 0,
+	"PTR":   0,
+	"MX":    1,
+	"SRV":   3,
+	"NAPTR": 5,
+}
+
+// Split a zone file into logical lines, dropping the comments and joining the lines enclosed in parentheses
+// Tokens keep their double quotes to preserve the character strings
+func dnszonefilelines(content string) ([]dnsZoneFileLine, error) {
+	res := []dnsZoneFileLine{}
+	current := dnsZoneFileLine{Number: 1}
+	token := strings.Builder{}
+	quoted, escaped, comment, started, lineStart := false, false, false, false, true
+	depth, number := 0, 1
+
+	flush := func() {
+		if started {
+			current.Tokens = append(current.Tokens, token.String())
+			token.Reset()
+			started = false
+		}
+	}
+
+	for _, c := range content {
+		// Records starting with a blank reuse the owner of the previous record
+		if lineStart {
+			current.Blank = c == ' ' || c == '\t'
+			lineStart = false
+		}
+
+		switch {
+		case comment && c != '\n':
+			continue
+		case escaped:
+			token.WriteRune(c)
+			escaped = false
+		case c == '\\':
+			token.WriteRune(c)
+			escaped, started = true, true
+		case c == '"':
+			token.WriteRune(c)
+			quoted, started = !quoted, true
+		case quoted && c != '\n':
+			token.WriteRune(c)
+		case c == ';':
+			flush()
+			comment = true
+		case c == '(':
+			flush()
+			depth++
+		case c == ')':
+			flush()
+
+			if depth--; depth < 0 {
+				return nil, fmt.Errorf("SOLIDServer - Invalid zone file, unexpected ')' at line %d\n", number)
+			}
+		case c == ' ' || c == '\t' || c == '\r':
+			flush()
+		case c == '\n':
+			if quoted {
+				return nil, fmt.Errorf("SOLIDServer - Invalid zone file, unterminated string at line %d\n", number)
+			}
+
+			flush()
+			comment = false
+			number++
+
+			if depth == 0 {
+				if len(current.Tokens) > 0 {
+					res = append(res, current)
+				}
+
+				current = dnsZoneFileLine{Number: number}
+				lineStart = true
+			}
+		default:
+			token.WriteRune(c)
+			started = true
+		}
+	}
+
+	flush()
+
+	if quoted || depth != 0 {
+		return nil, fmt.Errorf("SOLIDServer - Invalid zone file, unterminated string or parenthesis at line %d\n", current.Number)
+	}
+
+	if len(current.Tokens) > 0 {
+		res = append(res, current)
+	}
+
+	return res, nil
+}
+
+// Parse a TTL in seconds or with BIND units (ex: 1h30m, 1W)
+func dnszonefilettl(value string) (int, bool) {
+	if ttl, err := strconv.Atoi(value); err == nil {
+		return ttl, ttl >= 0
+	}
+
+	units := map[byte]int{'s': 1, 'm': 60, 'h': 3600, 'd': 86400, 'w': 604800}
+	ttl, current, digits := 0, 0, 0
+
+	for i := 0; i < len(value); i++ {
+		c := value[i]
+
+		if c >= '0' && c <= '9' {
+			current, digits = current*10+int(c-'0'), digits+1
+			continue
+		}
+
+		unit, unitExist := units[c|0x20]
+
+		if !unitExist || digits == 0 {
+			return 0, false
+		}
+
+		ttl, current, digits = ttl+current*unit, 0, 0
+	}
+
+	return ttl, len(value) > 0 && digits == 0
+}
+
+// Return the absolute name (without trailing dot) of a zone file name relative to an origin
+func dnszonefilename(name string, origin string) string {
+	if name == "@" {
+		return origin
+	}
+
+	if strings.HasSuffix(name, ".") {
+		return strings.TrimSuffix(name, ".")
+	}
+
+	if len(origin) == 0 {
+		return name
+	}
+
+	return name + "." + origin
+}
+
+// Parse the records of a RFC 1035 zone file, supporting $ORIGIN, $TTL, relative names and multi-line records
+// The names and the domain names of the values are returned fully qualified, while the SOA is skipped
+func dnszonefileparse(content string, zone string, defaultTTL int) ([]dnsZoneRecord, error) {
+	lines, err := dnszonefilelines(content)
+
+	if err != nil {
+		return nil, err
+	}
+
+	origin, owner, ttl := strings.ToLower(strings.TrimSuffix(zone, ".")), "", defaultTTL
+	lastTTL, hasTTL := 0, false
+	res := []dnsZoneRecord{}
+
+	for _, line := range lines {
+		tokens := line.Tokens
+
+		// Handling the directives
+		switch strings.ToUpper(tokens[0]) {
+		case "$ORIGIN":
+			if len(tokens) != 2 {
+				return nil, fmt.Errorf("SOLIDServer - Invalid zone file $ORIGIN directive at line %d\n", line.Number)
+			}
+
+			origin = strings.ToLower(dnszonefilename(tokens[1], origin))
+			continue
+		case "$TTL":
+			value, valueOk := 0, false
+
+			if len(tokens) == 2 {
+				value, valueOk = dnszonefilettl(tokens[1])
+			}
+
+			if !valueOk {
+				return nil, fmt.Errorf("SOLIDServer - Invalid zone file $TTL directive at line %d\n", line.Number)
+			}
+
+			ttl, hasTTL = value, true
+			continue
+		case "$INCLUDE", "$GENERATE":
+			return nil, fmt.Errorf("SOLIDServer - Unsupported zone file %s directive at line %d\n", tokens[0], line.Number)
+		}
+
+		// Reading the owner, then the TTL and class in any order, then the type
+		if !line.Blank {
+			owner, tokens = dnszonefilename(tokens[0], origin), tokens[1:]
+		} else if len(owner) == 0 {
+			return nil, fmt.Errorf("SOLIDServer - Missing owner name of the record at line %d\n", line.Number)
+		}
+
+		recordTTL, recordHasTTL := 0, false
+
+		for len(tokens) > 0 {
+			class := strings.ToUpper(tokens[0])
+
+			if value, valueOk := dnszonefilettl(tokens[0]); valueOk && !recordHasTTL {
+				recordTTL, recordHasTTL = value, true
+			} else if class == "CH" || class == "HS" || class == "CS" {
+				return nil, fmt.Errorf("SOLIDServer - Unsupported record class %s at line %d\n", class, line.Number)
+			} else if class != "IN" {
+				break
+			}
+
+			tokens = tokens[1:]
+		}
+
+		if len(tokens) < 2 {
+			return nil, fmt.Errorf("SOLIDServer - Invalid record at line %d\n", line.Number)
+		}
+
+		rrType, rdata := strings.ToUpper(tokens[0]), tokens[1:]
+
+		// Without $TTL, the records inherit the TTL of the previous one
+		if !recordHasTTL {
+			recordTTL = ttl
+
+			if !hasTTL && lastTTL > 0 {
+				recordTTL = lastTTL
+			}
+		}
+
+		lastTTL = recordTTL
+
+		if rrType == "SOA" {
+			continue
+		}
+
+		if _, errs := resourcednsrrvalidatetype(rrType, "type"); len(errs) > 0 {
+			return nil, fmt.Errorf("SOLIDServer - Unsupported record type %s at line %d\n", rrType, line.Number)
+		}
+
+		if field, nameField := dnsZoneFileNameFields[rrType]; nameField && field < len(rdata) && rdata[field] != "." {
+			rdata[field] = dnszonefilename(rdata[field], origin)
+		}
+
+		value := strings.Join(rdata, " ")
+
+		// A TXT value made of a single character-string is expected without its quotes
+		if rrType == "TXT" {
+			value = dnstxtunquote(value)
+		}
+
+		if _, err := dnsrrvaluesfromtext(rrType, value); err != nil {
+			return nil, fmt.Errorf("SOLIDServer - Invalid %s record value at line %d: %s\n", rrType, line.Number, value)
+		}
+
+		res = append(res, dnsZoneRecord{
+			Name:  owner,
+			Type:  rrType,
+			Value: value,
+			TTL:   recordTTL,
+		})
+	}
+
+	return res, nil
+}
+
+// Return the SOLIDserver type of a zone (ex: slave for a secondary zone)
+func dnszonetype(zoneType string) string {
+	zoneType = strings.ToLower(zoneType)
+
+	if zoneType == "secondary" {
+		return "slave"
+	}
+
+	return zoneType
+}
+
+// Return whether a zone type transfers its content from masters
+func dnszonetypehasmasters(zoneType string) bool {
+	zoneType = dnszonetype(zoneType)
+	return zoneType == "slave" || zoneType == "stub"
+}
+
+// Build the dnszone_masters parameter of a zone (ex: "10.0.0.1 port 53 key tsig-key;")
+func dnszonemasters(masters []interface{}) string {
+	res := ""
+
+	for _, m := range masters {
+		master, ok := m.(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		res += master["address"].(string)
+
+		if port := master["port"].(int); port != 0 && port != 53 {
+			res += " port " + strconv.Itoa(port)
+		}
+
+		if key := master["tsig_key"].(string); len(key) > 0 {
+			res += " key " + key
+		}
+
+		res += ";"
+	}
+
+	return res
+}
+
+// Parse the dnszone_masters parameter of a zone
+func dnszonemastersparse(masters string) []interface{} {
+	res := []interface{}{}
+
+	for _, entry := range strings.Split(masters, ";") {
+		tokens := strings.Fields(entry)
+
+		if len(tokens) == 0 {
+			continue
+		}
+
+		master := map[string]interface{}{
+			"address":  tokens[0],
+			"port":     53,
+			"tsig_key": "",
+		}
+
+		for i := 1; i+1 < len(tokens); i += 2 {
+			switch strings.ToLower(tokens[i]) {
+			case "port":
+				if port, err := strconv.Atoi(tokens[i+1]); err == nil {
+					master["port"] = port
+				}
+			case "key":
+				master["tsig_key"] = strings.Trim(tokens[i+1], "\"")
+			}
+		}
+
+		res = append(res, master)
+	}
+
+	return res
+}
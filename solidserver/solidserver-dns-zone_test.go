@@ -0,0 +1,211 @@
+package solidserver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDnsZoneRecordFqdn(t *testing.T) {
+	testCases := map[string]string{
+		"@":                   "example.com",
+		"":                    "example.com",
+		"www":                 "www.example.com",
+		"WWW.Example.com":     "www.example.com",
+		"www.example.com.":    "www.example.com",
+		"mx.other.net.":       "mx.other.net",
+		"example.com":         "example.com",
+		"sub.www.example.com": "sub.www.example.com",
+	}
+
+	for name, expected := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if fqdn := dnszonerecordfqdn(name, "example.com."); fqdn != expected {
+				t.Errorf("unexpected FQDN: %s (expected: %s)", fqdn, expected)
+			}
+		})
+	}
+}
+
+func TestDnsZoneRecordIgnored(t *testing.T) {
+	ignores := []dnsZoneIgnore{{Type: "TXT", Name: "_acme-challenge.*"}, {Type: "", Name: "dyn"}}
+
+	type testCase struct {
+		Record       dnsZoneRecord
+		ManageApexNS bool
+		Expected     bool
+	}
+
+	testCases := map[string]testCase{
+		"soa":        {Record: dnsZoneRecord{Name: "example.com", Type: "SOA"}, Expected: true},
+		"dnssec":     {Record: dnsZoneRecord{Name: "example.com", Type: "DNSKEY"}, Expected: true},
+		"spf":        {Record: dnsZoneRecord{Name: "www.example.com", Type: "spf"}, Expected: true},
+		"loc":        {Record: dnsZoneRecord{Name: "www.example.com", Type: "LOC"}, Expected: true},
+		"apex ns":    {Record: dnsZoneRecord{Name: "example.com", Type: "NS"}, Expected: true},
+		"managed ns": {Record: dnsZoneRecord{Name: "example.com", Type: "NS"}, ManageApexNS: true, Expected: false},
+		"delegation": {Record: dnsZoneRecord{Name: "sub.example.com", Type: "NS"}, Expected: false},
+		"acme":       {Record: dnsZoneRecord{Name: "_acme-challenge.www.example.com", Type: "TXT"}, Expected: true},
+		"acme a":     {Record: dnsZoneRecord{Name: "_acme-challenge.www.example.com", Type: "A"}, Expected: false},
+		"any type":   {Record: dnsZoneRecord{Name: "dyn.example.com", Type: "AAAA"}, Expected: true},
+		"regular":    {Record: dnsZoneRecord{Name: "www.example.com", Type: "A"}, Expected: false},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if ignored := dnszonerecordignored(tc.Record, "example.com", tc.ManageApexNS, ignores); ignored != tc.Expected {
+				t.Errorf("unexpected ignore result: %t (expected: %t)", ignored, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestDnsZoneRecordsDiff(t *testing.T) {
+	current := []dnsZoneRecord{
+		{ID: "1", Name: "www.example.com", Type: "A", Value: "10.0.0.10", TTL: 3600},
+		{ID: "2", Name: "example.com", Type: "MX", Value: "10 mx1.example.com", TTL: 3600},
+		{ID: "3", Name: "old.example.com", Type: "CNAME", Value: "www.example.com", TTL: 3600},
+	}
+
+	desired := []dnsZoneRecord{
+		{Name: "WWW", Type: "a", Value: "10.0.0.10", TTL: 3600},
+		{Name: "@", Type: "MX", Value: "10 MX1.example.com", TTL: 300},
+		{Name: "new", Type: "A", Value: "10.0.0.11", TTL: 3600},
+	}
+
+	add, update, del := dnszonerecordsdiff(current, desired, "example.com")
+
+	if !reflect.DeepEqual(add, []dnsZoneRecord{{Name: "new", Type: "A", Value: "10.0.0.11", TTL: 3600}}) {
+		t.Errorf("unexpected added records: %v", add)
+	}
+
+	if !reflect.DeepEqual(update, []dnsZoneRecord{{ID: "2", Name: "@", Type: "MX", Value: "10 MX1.example.com", TTL: 300}}) {
+		t.Errorf("unexpected updated records: %v", update)
+	}
+
+	if !reflect.DeepEqual(del, []dnsZoneRecord{current[2]}) {
+		t.Errorf("unexpected deleted records: %v", del)
+	}
+}
+
+func TestDnsZoneFileTTL(t *testing.T) {
+	testCases := map[string]int{
+		"3600":  3600,
+		"1h":    3600,
+		"1h30m": 5400,
+		"1W":    604800,
+		"2d":    172800,
+	}
+
+	for value, expected := range testCases {
+		t.Run(value, func(t *testing.T) {
+			if ttl, ok := dnszonefilettl(value); !ok || ttl != expected {
+				t.Errorf("unexpected TTL: %d (expected: %d)", ttl, expected)
+			}
+		})
+	}
+
+	for _, value := range []string{"", "h", "1x", "10h5", "IN"} {
+		t.Run("invalid "+value, func(t *testing.T) {
+			if _, ok := dnszonefilettl(value); ok {
+				t.Errorf("unexpected valid TTL: %s", value)
+			}
+		})
+	}
+}
+
+func TestDnsZoneFileParse(t *testing.T) {
+	content := `$TTL 1h
+$ORIGIN example.com.
+@   IN  SOA ns1 hostmaster (
+            2024010101 ; serial
+            3600 900 604800 300 )
+    IN  NS  ns1
+    IN  MX  10 mail          ; primary exchanger
+www 300 IN A 10.0.0.10
+        IN A 10.0.0.11
+ftp     CNAME www.example.com.
+txt IN TXT ( "v=spf1 mx"
+             " -all" )
+spf IN TXT "v=spf1 -all"
+_sip._tcp IN 600 SRV 10 5 5060 sip
+$ORIGIN lab
+host    AAAA 2001:db8::1
+`
+
+	expected := []dnsZoneRecord{
+		{Name: "example.com", Type: "NS", Value: "ns1.example.com", TTL: 3600},
+		{Name: "example.com", Type: "MX", Value: "10 mail.example.com", TTL: 3600},
+		{Name: "www.example.com", Type: "A", Value: "10.0.0.10", TTL: 300},
+		{Name: "www.example.com", Type: "A", Value: "10.0.0.11", TTL: 3600},
+		{Name: "ftp.example.com", Type: "CNAME", Value: "www.example.com", TTL: 3600},
+		{Name: "txt.example.com", Type: "TXT", Value: "\"v=spf1 mx\" \" -all\"", TTL: 3600},
+		{Name: "spf.example.com", Type: "TXT", Value: "v=spf1 -all", TTL: 3600},
+		{Name: "_sip._tcp.example.com", Type: "SRV", Value: "10 5 5060 sip.example.com", TTL: 600},
+		{Name: "host.lab.example.com", Type: "AAAA", Value: "2001:db8::1", TTL: 3600},
+	}
+
+	records, err := dnszonefileparse(content, "example.com", 86400)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if !reflect.DeepEqual(records, expected) {
+		t.Errorf("unexpected records:\n%v\nexpected:\n%v", records, expected)
+	}
+}
+
+func TestDnsZoneFileParseErrors(t *testing.T) {
+	testCases := map[string]string{
+		"parenthesis": "www IN TXT ( \"unterminated\"\n",
+		"string":      "www IN TXT \"unterminated\n",
+		"owner":       "  IN A 10.0.0.1\n",
+		"type":        "www IN DNSKEY 256 3 8 AwEAAQ==\n",
+		"value":       "@ IN MX mail\n",
+		"include":     "$INCLUDE other.zone\n",
+		"class":       "www CH A 10.0.0.1\n",
+	}
+
+	for name, content := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if _, err := dnszonefileparse(content, "example.com", 3600); err == nil {
+				t.Errorf("unexpected success parsing: %q", content)
+			}
+		})
+	}
+}
+
+func TestDnsZoneMasters(t *testing.T) {
+	masters := []interface{}{
+		map[string]interface{}{"address": "10.0.0.1", "port": 53, "tsig_key": ""},
+		map[string]interface{}{"address": "10.0.0.2", "port": 5353, "tsig_key": "transfer-key"},
+		map[string]interface{}{"address": "2001:db8::1", "port": 53, "tsig_key": "transfer-key"},
+	}
+
+	param := dnszonemasters(masters)
+
+	if expected := "10.0.0.1;10.0.0.2 port 5353 key transfer-key;2001:db8::1 key transfer-key;"; param != expected {
+		t.Errorf("unexpected masters parameter: %s (expected: %s)", param, expected)
+	}
+
+	if parsed := dnszonemastersparse(param); !reflect.DeepEqual(parsed, masters) {
+		t.Errorf("unexpected parsed masters: %v (expected: %v)", parsed, masters)
+	}
+}
+
+func TestDnsZoneType(t *testing.T) {
+	testCases := map[string]string{
+		"Master":    "master",
+		"secondary": "slave",
+		"Slave":     "slave",
+		"stub":      "stub",
+		"HINT":      "hint",
+	}
+
+	for zoneType, expected := range testCases {
+		t.Run(zoneType, func(t *testing.T) {
+			if res := dnszonetype(zoneType); res != expected {
+				t.Errorf("unexpected zone type: %s (expected: %s)", res, expected)
+			}
+		})
+	}
+}
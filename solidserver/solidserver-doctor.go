@@ -0,0 +1,545 @@
+package solidserver
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/parnurzeal/gorequest"
+)
+
+const (
+	doctorOK      = "ok"
+	doctorWarning = "warning"
+	doctorError   = "error"
+	doctorSkipped = "skipped"
+)
+
+// Maximum tolerated difference between the local clock and the SOLIDserver one
+const doctorMaxClockDrift = 30 * time.Second
+
+type doctorCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+type doctorReport struct {
+	Host        string        `json:"host"`
+	ProxyURL    string        `json:"proxy_url,omitempty"`
+	AuthMode    string        `json:"auth_mode"`
+	Version     string        `json:"version,omitempty"`
+	Checks      []doctorCheck `json:"checks"`
+	Permissions []doctorCheck `json:"permissions"`
+	Errors      int           `json:"errors"`
+	Warnings    int           `json:"warnings"`
+}
+
+// The services required by each resource family, used to check the permissions
+// The write check updates a non-existing object (add_flag=edit_only) so that nothing gets created,
+// it is not sent to the services managing accounts and custom databases (empty WriteService)
+var doctorModules = []struct {
+	Name         string
+	Resources    string
+	ReadService  string
+	WriteService string
+	IDParameter  string
+}{
+	{"IPAM spaces", "solidserver_ip_space", "ip_site_list", "ip_site_add", "site_id"},
+	{"IPAM IPv4 subnets", "solidserver_ip_subnet", "ip_block_subnet_list", "ip_subnet_add", "subnet_id"},
+	{"IPAM IPv4 pools", "solidserver_ip_pool", "ip_pool_list", "ip_pool_add", "pool_id"},
+	{"IPAM IPv4 addresses", "solidserver_ip_address, solidserver_ip_mac", "ip_address_list", "ip_add", "ip_id"},
+	{"IPAM IPv4 aliases", "solidserver_ip_alias", "ip_alias_list", "ip_alias_add", "ip_name_id"},
+	{"IPAM IPv6 subnets", "solidserver_ip6_subnet", "ip6_block6_subnet6_list", "ip6_subnet6_add", "subnet6_id"},
+	{"IPAM IPv6 pools", "solidserver_ip6_pool", "ip6_pool6_list", "ip6_pool6_add", "pool6_id"},
+	{"IPAM IPv6 addresses", "solidserver_ip6_address, solidserver_ip6_mac", "ip6_address6_list", "ip6_address6_add", "ip6_id"},
+	{"IPAM IPv6 aliases", "solidserver_ip6_alias", "ip6_alias_list", "ip6_alias_add", "ip6_name_id"},
+	{"Devices", "solidserver_device", "hostdev_list", "hostdev_add", "hostdev_id"},
+	{"DNS servers", "solidserver_dns_server, solidserver_dns_smart", "dns_server_list", "dns_add", "dns_id"},
+	{"DNS views", "solidserver_dns_view", "dns_view_list", "dns_view_add", "dnsview_id"},
+	{"DNS zones", "solidserver_dns_zone, solidserver_dns_forward_zone", "dns_zone_list", "dns_zone_add", "dnszone_id"},
+	{"DNS records", "solidserver_dns_rr", "dns_rr_list", "dns_rr_add", "rr_id"},
+	{"VLAN domains", "solidserver_vlan_domain", "vlmdomain_list", "vlm_domain_add", "vlmdomain_id"},
+	{"VLAN ranges", "solidserver_vlan_range", "vlmrange_list", "vlm_range_add", "vlmrange_id"},
+	{"VLANs", "solidserver_vlan", "vlmvlan_list", "vlm_vlan_add", "vlmvlan_id"},
+	{"Applications", "solidserver_app_application", "app_application_list", "app_application_add", "appapplication_id"},
+	{"Application pools", "solidserver_app_pool", "app_pool_list", "app_pool_add", "apppool_id"},
+	{"Application nodes", "solidserver_app_node", "app_node_list", "app_node_add", "appnode_id"},
+	{"Users", "solidserver_user", "user_admin_list", "", "usr_id"},
+	{"Groups", "solidserver_usergroup", "group_admin_list", "", "grp_id"},
+	{"Custom DBs", "solidserver_cdb", "custom_db_name_list", "", "custom_db_name_id"},
+	{"Custom DB data", "solidserver_cdb_data", "custom_db_data_list", "", "custom_db_data_id"},
+}
+
+func (r *doctorReport) add(name string, status string, format string, args ...interface{}) string {
+	r.Checks = append(r.Checks, doctorCheck{Name: name, Status: status, Message: fmt.Sprintf(format, args...)})
+	r.count(status)
+
+	return status
+}
+
+func (r *doctorReport) count(status string) {
+	switch status {
+	case doctorError:
+		r.Errors++
+	case doctorWarning:
+		r.Warnings++
+	}
+}
+
+// Skip the remaining checks after a blocking failure
+func (r *doctorReport) skip(reason string, names ...string) {
+	for _, name := range names {
+		r.Checks = append(r.Checks, doctorCheck{Name: name, Status: doctorSkipped, Message: reason})
+	}
+}
+
+// Return the host and port to connect to, either the SOLIDserver or the proxy
+func doctorendpoint(host string, proxyURL string) (string, string, error) {
+	if proxyURL != "" {
+		if !strings.Contains(proxyURL, "://") {
+			proxyURL = "http://" + proxyURL
+		}
+
+		u, err := url.Parse(proxyURL)
+
+		if err != nil {
+			return "", "", err
+		}
+
+		port := u.Port()
+
+		if port == "" {
+			switch u.Scheme {
+			case "https":
+				port = "443"
+			case "socks5":
+				port = "1080"
+			default:
+				port = "80"
+			}
+		}
+
+		return u.Hostname(), port, nil
+	}
+
+	if h, p, err := net.SplitHostPort(host); err == nil {
+		return h, p, nil
+	}
+
+	return strings.Trim(host, "[]"), "443", nil
+}
+
+// Build the certificate pool the same way SubmitRequest does
+func doctorrootcas(certsFile string) (*x509.CertPool, int, error) {
+	rootCAs, x509err := x509.SystemCertPool()
+
+	if rootCAs == nil || x509err != nil {
+		rootCAs = x509.NewCertPool()
+	}
+
+	if certsFile == "" {
+		return rootCAs, 0, nil
+	}
+
+	certs, readErr := os.ReadFile(certsFile)
+
+	if readErr != nil {
+		return nil, 0, readErr
+	}
+
+	rootCAs.AppendCertsFromPEM(certs)
+
+	return rootCAs, doctorpemcount(certs), nil
+}
+
+// Count the certificates parsed from a PEM content
+func doctorpemcount(certs []byte) int {
+	count := 0
+
+	for block, rest := pem.Decode(certs); block != nil; block, rest = pem.Decode(rest) {
+		if block.Type != "CERTIFICATE" || len(block.Headers) != 0 {
+			continue
+		}
+
+		if _, err := x509.ParseCertificate(block.Bytes); err == nil {
+			count++
+		}
+	}
+
+	return count
+}
+
+// Compare the local clock with the Date header returned by the SOLIDserver
+func doctorclockdrift(local time.Time, date string) (time.Duration, error) {
+	remote, err := http.ParseTime(date)
+
+	if err != nil {
+		return 0, err
+	}
+
+	return local.Sub(remote).Round(time.Second), nil
+}
+
+// Messages of the answers proving that a request went past the permission check
+var doctorNotFoundRegexp = regexp.MustCompile(`(?i)(not found|does not exist|doesn't exist|no such|unknown object)`)
+
+// Check the access to a service: 2xx means granted, 401/403 denied,
+// the write access is also granted when the (non-existing) object is reported as not found
+// Requests are not retried on denied access, unlike the ones sent through Request
+func doctorpermission(s *SOLIDserver, method string, service string, parameters url.Values, write bool) (string, string) {
+	apiclient := gorequest.New()
+	apiclient.Proxy(s.ProxyURL)
+
+	resp, body, err := SubmitRequest(s, apiclient, method, restservicepath(service), parameters.Encode())
+
+	if err != nil {
+		return doctorError, strings.TrimSpace(err.Error())
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	errMsg := ""
+
+	if len(buf) > 0 {
+		errMsg, _ = buf[0]["errmsg"].(string)
+	}
+
+	return doctorpermissionstatus(resp.StatusCode, errMsg, write)
+}
+
+// Return the status of a permission check from the answer of the service
+// Any answer neither granting nor denying the access is reported as unknown
+func doctorpermissionstatus(statusCode int, errMsg string, write bool) (string, string) {
+	answer := fmt.Sprintf("%d", statusCode)
+
+	if errMsg != "" {
+		answer += ": " + errMsg
+	}
+
+	switch {
+	case statusCode == 401 || statusCode == 403:
+		return doctorError, fmt.Sprintf("denied (%s)", answer)
+	case statusCode >= 200 && statusCode < 300:
+		return doctorOK, "granted"
+	case write && statusCode < 500 && doctorNotFoundRegexp.MatchString(errMsg):
+		return doctorOK, fmt.Sprintf("granted (%s)", answer)
+	}
+
+	return doctorWarning, fmt.Sprintf("unknown (%s)", answer)
+}
+
+func doctorrun(config *cliConfig, writeChecks bool) *doctorReport {
+	report := &doctorReport{
+		Host:        config.Host,
+		ProxyURL:    config.ProxyURL,
+		AuthMode:    "basic",
+		Checks:      []doctorCheck{},
+		Permissions: []doctorCheck{},
+	}
+
+	if config.UseToken {
+		report.AuthMode = "token"
+	}
+
+	remaining := []string{"dns", "tcp", "tls", "clock", "auth", "version"}
+
+	// Configuration
+	if err := config.validate(); err != nil {
+		report.add("config", doctorError, "%s", err)
+		report.skip("invalid configuration", remaining...)
+		return report
+	}
+
+	report.add("config", doctorOK, "host=%s, auth=%s, sslverify=%t, timeout=%ds", config.Host, report.AuthMode, config.SSLVerify, config.Timeout)
+
+	host, port, err := doctorendpoint(config.Host, config.ProxyURL)
+
+	if err != nil {
+		report.add("dns", doctorError, "invalid proxy_url: %s", err)
+		report.skip("invalid proxy_url", remaining[1:]...)
+		return report
+	}
+
+	target := "SOLIDserver"
+	if config.ProxyURL != "" {
+		target = "proxy"
+	}
+
+	// Name resolution
+	if net.ParseIP(host) != nil {
+		report.add("dns", doctorOK, "%s %s is an IP address, no resolution required", target, host)
+	} else if addrs, err := net.LookupHost(host); err != nil {
+		report.add("dns", doctorError, "unable to resolve %s %s: %s", target, host, err)
+		report.skip("name resolution failed", remaining[1:]...)
+		return report
+	} else {
+		report.add("dns", doctorOK, "%s %s resolves to %s", target, host, strings.Join(addrs, ", "))
+	}
+
+	// TCP connectivity
+	start := time.Now()
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, port), time.Duration(config.Timeout)*time.Second)
+
+	if err != nil {
+		report.add("tcp", doctorError, "unable to connect to %s %s: %s", target, net.JoinHostPort(host, port), err)
+		report.skip("TCP connection failed", remaining[2:]...)
+		return report
+	}
+
+	conn.Close()
+	report.add("tcp", doctorOK, "connected to %s %s in %s", target, net.JoinHostPort(host, port), time.Since(start).Round(time.Millisecond))
+
+	// TLS handshake and certificate validation, through the proxy if any
+	rootCAs, appended, err := doctorrootcas(config.AdditionalTrustCertsFile)
+
+	if err != nil {
+		report.add("tls", doctorError, "unable to read additional_trust_certs_file %s: %s", config.AdditionalTrustCertsFile, err)
+		report.skip("TLS configuration failed", remaining[3:]...)
+		return report
+	}
+
+	if config.AdditionalTrustCertsFile != "" && appended == 0 {
+		report.add("tls", doctorWarning, "no certificate loaded from additional_trust_certs_file %s, using system certificates only", config.AdditionalTrustCertsFile)
+	}
+
+	transport := &http.Transport{TLSClientConfig: &tls.Config{RootCAs: rootCAs}}
+
+	if config.ProxyURL != "" {
+		proxyURL := config.ProxyURL
+		if !strings.Contains(proxyURL, "://") {
+			proxyURL = "http://" + proxyURL
+		}
+		if u, err := url.Parse(proxyURL); err == nil {
+			transport.Proxy = http.ProxyURL(u)
+		}
+	}
+
+	client := &http.Client{Transport: transport, Timeout: time.Duration(config.Timeout) * time.Second}
+	resp, err := client.Head("https://" + config.Host + "/")
+
+	if err != nil {
+		var unknownAuthority x509.UnknownAuthorityError
+		var hostnameErr x509.HostnameError
+		var invalidCert x509.CertificateInvalidError
+
+		certErr := errors.As(err, &unknownAuthority) || errors.As(err, &hostnameErr) || errors.As(err, &invalidCert)
+
+		if !certErr {
+			report.add("tls", doctorError, "TLS/HTTPS request failed: %s", err)
+			report.skip("TLS/HTTPS request failed", remaining[3:]...)
+			return report
+		}
+
+		if config.SSLVerify {
+			report.add("tls", doctorError, "certificate verification failed: %s (consider using additional_trust_certs_file)", err)
+			report.skip("certificate verification failed", remaining[3:]...)
+			return report
+		}
+
+		report.add("tls", doctorWarning, "certificate verification failed but sslverify is disabled: %s", err)
+
+		// Retry without verification to pursue the diagnostic
+		transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+		resp, err = client.Head("https://" + config.Host + "/")
+
+		if err != nil {
+			report.add("tls", doctorError, "TLS/HTTPS request failed: %s", err)
+			report.skip("TLS/HTTPS request failed", remaining[3:]...)
+			return report
+		}
+	} else if resp.TLS != nil && len(resp.TLS.PeerCertificates) > 0 {
+		cert := resp.TLS.PeerCertificates[0]
+		expiry := time.Until(cert.NotAfter)
+
+		if expiry < 30*24*time.Hour {
+			report.add("tls", doctorWarning, "certificate %s expires soon (%s)", cert.Subject.CommonName, cert.NotAfter.Format(time.RFC3339))
+		} else {
+			report.add("tls", doctorOK, "certificate %s trusted, %s, expires %s", cert.Subject.CommonName, tls.VersionName(resp.TLS.Version), cert.NotAfter.Format(time.RFC3339))
+		}
+	} else {
+		report.add("tls", doctorOK, "TLS handshake succeeded")
+	}
+
+	resp.Body.Close()
+
+	// Clock drift, relevant for the token authentication signature
+	if drift, err := doctorclockdrift(time.Now(), resp.Header.Get("Date")); err != nil {
+		report.add("clock", doctorWarning, "unable to retrieve the SOLIDserver date: %s", err)
+	} else if drift > doctorMaxClockDrift || drift < -doctorMaxClockDrift {
+		status := doctorWarning
+		if config.UseToken {
+			status = doctorError
+		}
+		report.add("clock", status, "local clock differs from the SOLIDserver one by %s (token authentication requires synchronized clocks)", drift)
+	} else {
+		report.add("clock", doctorOK, "local clock differs from the SOLIDserver one by %s", drift)
+	}
+
+	// Authentication, the connection is built without retrieving the version to report the failures in detail
+	s := &SOLIDserver{
+		Ctx:                      context.Background(),
+		Host:                     config.Host,
+		UseToken:                 config.UseToken,
+		Username:                 config.Username,
+		Password:                 config.Password,
+		BaseUrl:                  "https://" + config.Host,
+		SSLVerify:                config.SSLVerify,
+		AdditionalTrustCertsFile: config.AdditionalTrustCertsFile,
+		Timeout:                  config.Timeout,
+		Version:                  0,
+		Authenticated:            false,
+		ProxyURL:                 config.ProxyURL,
+	}
+
+	parameters := url.Values{}
+	parameters.Add("WHERE", "member_is_me='1'")
+
+	resp, body, err := s.Request("get", "rest/member_list", &parameters)
+
+	if err != nil {
+		report.add("auth", doctorError, "%s", strings.TrimSpace(err.Error()))
+		report.skip("authentication request failed", "version")
+		return report
+	}
+
+	memberVersion := ""
+
+	switch {
+	case resp.StatusCode == 200:
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		if len(buf) > 0 {
+			memberVersion, _ = buf[0]["member_version"].(string)
+		}
+
+		report.add("auth", doctorOK, "%s authentication succeeded as %s", report.AuthMode, config.Username)
+	case resp.StatusCode == 412:
+		report.add("auth", doctorError, "%s authentication rejected because of a time drift (412), check the clock synchronization", report.AuthMode)
+	case resp.StatusCode == 401:
+		if config.UseToken {
+			report.add("auth", doctorError, "token authentication rejected (401), check the token ID/secret and whether use_token should be disabled")
+		} else {
+			report.add("auth", doctorError, "basic authentication rejected (401), check the username/password (or enable use_token for API tokens) and the user's permission to read members")
+		}
+	case resp.StatusCode >= 400 && resp.StatusCode < 500:
+		report.add("auth", doctorWarning, "authenticated but not allowed to read the SOLIDserver members (%d)", resp.StatusCode)
+	default:
+		report.add("auth", doctorError, "unexpected answer (%d)", resp.StatusCode)
+	}
+
+	// Version detection
+	if memberVersion != "" {
+		report.Version = memberVersion
+
+		if config.Version != "" && !strings.HasPrefix(memberVersion, config.Version) {
+			report.add("version", doctorWarning, "detected version %s differs from the configured solidserverversion %s", memberVersion, config.Version)
+		} else {
+			report.add("version", doctorOK, "detected version %s", memberVersion)
+		}
+	} else if config.Version != "" {
+		report.Version = config.Version
+		report.add("version", doctorWarning, "unable to detect the version, using the configured solidserverversion %s", config.Version)
+	} else {
+		report.add("version", doctorError, "unable to detect the version, consider setting solidserverversion (SOLIDSERVER_VERSION)")
+	}
+
+	if resp.StatusCode == 401 || resp.StatusCode == 412 {
+		return report
+	}
+
+	// Per module permissions
+	for _, module := range doctorModules {
+		readParameters := url.Values{}
+		readParameters.Add("limit", "1")
+
+		status, message := doctorpermission(s, "get", module.ReadService, readParameters, false)
+		report.Permissions = append(report.Permissions, doctorCheck{Name: module.Name + " (read)", Status: status, Message: fmt.Sprintf("%s: %s [%s]", module.ReadService, message, module.Resources)})
+		report.count(status)
+
+		if writeChecks && module.WriteService == "" {
+			report.Permissions = append(report.Permissions, doctorCheck{Name: module.Name + " (write)", Status: doctorSkipped, Message: fmt.Sprintf("not probed [%s]", module.Resources)})
+		} else if writeChecks {
+			writeParameters := url.Values{}
+			writeParameters.Add("add_flag", "edit_only")
+			writeParameters.Add(module.IDParameter, "0")
+
+			status, message = doctorpermission(s, "put", module.WriteService, writeParameters, true)
+			report.Permissions = append(report.Permissions, doctorCheck{Name: module.Name + " (write)", Status: status, Message: fmt.Sprintf("%s: %s [%s]", module.WriteService, message, module.Resources)})
+			report.count(status)
+		}
+	}
+
+	return report
+}
+
+// Human readable report
+func (r *doctorReport) print(w io.Writer) {
+	labels := map[string]string{doctorOK: "OK", doctorWarning: "WARN", doctorError: "FAIL", doctorSkipped: "SKIP"}
+
+	fmt.Fprintf(w, "SOLIDserver doctor report for %s (%s authentication)\n\n", r.Host, r.AuthMode)
+
+	for _, c := range r.Checks {
+		fmt.Fprintf(w, "  [%-4s] %-8s %s\n", labels[c.Status], c.Name, c.Message)
+	}
+
+	if len(r.Permissions) > 0 {
+		fmt.Fprintf(w, "\nPermissions:\n")
+
+		for _, c := range r.Permissions {
+			fmt.Fprintf(w, "  [%-4s] %-30s %s\n", labels[c.Status], c.Name, c.Message)
+		}
+	}
+
+	fmt.Fprintf(w, "\n%d error(s), %d warning(s)\n", r.Errors, r.Warnings)
+}
+
+// Doctor command: diagnose the connectivity, authentication and permissions of the
+// provider configuration (same environment variables as the provider)
+func Doctor(args []string, stdout io.Writer, stderr io.Writer) int {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: terraform-provider-solidserver doctor [options]\n\n")
+		fmt.Fprintf(stderr, "Diagnose the connectivity, authentication and permissions of the provider configuration.\n\n")
+		fs.PrintDefaults()
+	}
+
+	config := cliconfigflags(fs)
+	jsonOutput := fs.Bool("json", false, "Print the report in JSON format")
+	writeChecks := fs.Bool("write", false, "Also check the write permissions by sending update (PUT) requests on non-existing objects")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	report := doctorrun(config, *writeChecks)
+
+	if *jsonOutput {
+		out, _ := json.MarshalIndent(report, "", "  ")
+		fmt.Fprintf(stdout, "%s\n", out)
+	} else {
+		report.print(stdout)
+	}
+
+	if report.Errors > 0 {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,155 @@
+package solidserver
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDoctorEndpoint(t *testing.T) {
+	type testCase struct {
+		Host     string
+		ProxyURL string
+		Expected string
+	}
+
+	testCases := map[string]testCase{
+		"host":          {Host: "sds.corp.lan", Expected: "sds.corp.lan:443"},
+		"host_and_port": {Host: "192.168.0.1:8443", Expected: "192.168.0.1:8443"},
+		"http_proxy":    {Host: "sds.corp.lan", ProxyURL: "http://proxy.corp.lan:3128", Expected: "proxy.corp.lan:3128"},
+		"no_scheme":     {Host: "sds.corp.lan", ProxyURL: "proxy.corp.lan", Expected: "proxy.corp.lan:80"},
+		"socks5_proxy":  {Host: "sds.corp.lan", ProxyURL: "socks5://10.0.0.1", Expected: "10.0.0.1:1080"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			host, port, err := doctorendpoint(tc.Host, tc.ProxyURL)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if result := host + ":" + port; result != tc.Expected {
+				t.Errorf("expected %q, got %q", tc.Expected, result)
+			}
+		})
+	}
+}
+
+func TestDoctorClockDrift(t *testing.T) {
+	local := time.Date(2024, 1, 1, 12, 0, 45, 0, time.UTC)
+
+	drift, err := doctorclockdrift(local, "Mon, 01 Jan 2024 12:00:00 GMT")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if drift != 45*time.Second {
+		t.Errorf("expected a 45s drift, got %s", drift)
+	}
+
+	if _, err := doctorclockdrift(local, ""); err == nil {
+		t.Errorf("expected an error on a missing date")
+	}
+}
+
+func TestDoctorRun(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/rest/member_list":
+			w.Write([]byte(`[{"member_version":"8.1.1"}]`))
+		case r.URL.Path == "/rest/dns_rr_list":
+			w.WriteHeader(401)
+			w.Write([]byte(`[{"errmsg":"Permission denied"}]`))
+		case r.Method == "PUT" && r.URL.Path == "/rest/dns_zone_add":
+			w.WriteHeader(400)
+			w.Write([]byte(`[{"errmsg":"Zone not found"}]`))
+		case r.Method == "PUT":
+			w.WriteHeader(400)
+		default:
+			w.WriteHeader(204)
+		}
+	}))
+	defer server.Close()
+
+	config := &cliConfig{
+		Host:     strings.TrimPrefix(server.URL, "https://"),
+		Username: "ipmadmin",
+		Password: "admin",
+		Timeout:  5,
+	}
+
+	// Self-signed certificate with sslverify enabled
+	config.SSLVerify = true
+	report := doctorrun(config, true)
+
+	if report.Errors != 1 || len(report.Permissions) != 0 {
+		t.Errorf("expected the certificate verification to fail, got: %+v", report.Checks)
+	}
+
+	// Self-signed certificate with sslverify disabled
+	config.SSLVerify = false
+	report = doctorrun(config, true)
+
+	if report.Version != "8.1.1" {
+		t.Errorf("expected version 8.1.1, got %q", report.Version)
+	}
+
+	if len(report.Permissions) != 2*len(doctorModules) {
+		t.Errorf("expected %d permission checks, got %d", 2*len(doctorModules), len(report.Permissions))
+	}
+
+	// Only the DNS records read permission is denied
+	if report.Errors != 1 {
+		t.Errorf("expected a single error, got %d: %+v %+v", report.Errors, report.Checks, report.Permissions)
+	}
+
+	for _, c := range report.Permissions {
+		if (c.Status == doctorError) != (c.Name == "DNS records (read)") {
+			t.Errorf("unexpected permission check result: %+v", c)
+		}
+
+		// Only the write check answering a not found object is granted
+		if strings.HasSuffix(c.Name, "(write)") && c.Status == doctorOK && c.Name != "DNS zones (write)" {
+			t.Errorf("unexpected granted write permission: %+v", c)
+		}
+	}
+
+	// Write checks are opt-in
+	report = doctorrun(config, false)
+
+	if len(report.Permissions) != len(doctorModules) {
+		t.Errorf("expected %d permission checks, got %d", len(doctorModules), len(report.Permissions))
+	}
+}
+
+func TestDoctorPermissionStatus(t *testing.T) {
+	type testCase struct {
+		StatusCode int
+		ErrMsg     string
+		Write      bool
+		Expected   string
+	}
+
+	testCases := map[string]testCase{
+		"read_granted":      {StatusCode: 200, Expected: doctorOK},
+		"read_empty":        {StatusCode: 204, Expected: doctorOK},
+		"read_denied":       {StatusCode: 403, ErrMsg: "Permission denied", Expected: doctorError},
+		"read_bad_request":  {StatusCode: 400, Expected: doctorWarning},
+		"write_not_found":   {StatusCode: 400, ErrMsg: "Object does not exist", Write: true, Expected: doctorOK},
+		"write_bad_request": {StatusCode: 400, ErrMsg: "Missing parameter", Write: true, Expected: doctorWarning},
+		"write_denied":      {StatusCode: 401, Write: true, Expected: doctorError},
+		"write_server":      {StatusCode: 500, ErrMsg: "Object not found", Write: true, Expected: doctorWarning},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if status, message := doctorpermissionstatus(tc.StatusCode, tc.ErrMsg, tc.Write); status != tc.Expected {
+				t.Errorf("expected %s, got %s (%s)", tc.Expected, status, message)
+			}
+		})
+	}
+}
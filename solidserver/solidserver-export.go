@@ -0,0 +1,739 @@
+package solidserver
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// Reference to an attribute of another exported resource (ex: solidserver_ip_space.prod.name)
+type exportRef struct {
+	Address   string
+	Attribute string
+}
+
+// Reference to an input variable of the generated configuration
+type exportVar struct {
+	Name string
+}
+
+// An attribute of an exported resource, attributes are written in declaration order
+type exportAttr struct {
+	Name  string
+	Value interface{}
+}
+
+type exporter struct {
+	s         *SOLIDserver
+	files     map[string]*hclwrite.File
+	fileOrder []string
+	addresses map[string]bool
+	refs      map[string]string
+	variables map[string]bool
+	count     int
+}
+
+func newexporter(s *SOLIDserver) *exporter {
+	return &exporter{
+		s:         s,
+		files:     map[string]*hclwrite.File{},
+		fileOrder: []string{},
+		addresses: map[string]bool{},
+		refs:      map[string]string{},
+		variables: map[string]bool{},
+		count:     0,
+	}
+}
+
+var exportLabelInvalidChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// Convert an object name into a valid terraform resource name
+func exportlabel(name string) string {
+	label := strings.Trim(exportLabelInvalidChars.ReplaceAllString(strings.ToLower(name), "_"), "_")
+
+	if label == "" {
+		return "object"
+	}
+
+	if label[0] >= '0' && label[0] <= '9' {
+		return "_" + label
+	}
+
+	return label
+}
+
+// Return the value of a listed field, SOLIDserver uses '#' for unset references
+func exportfield(attributes map[string]string, key string) string {
+	if v := attributes[key]; v != "#" {
+		return v
+	}
+
+	return ""
+}
+
+// Return the class parameters of a listed object, skipping the ones managed through dedicated attributes
+func exportclassparameters(encoded string, ignored ...string) map[string]string {
+	res := map[string]string{}
+	retrievedClassParameters, _ := url.ParseQuery(encoded)
+
+	for ck, cv := range retrievedClassParameters {
+		if stringOffsetInSlice(ck, ignored) < 0 && len(cv) > 0 {
+			res[ck] = cv[0]
+		}
+	}
+
+	return res
+}
+
+func (e *exporter) file(name string) *hclwrite.File {
+	if f, exist := e.files[name]; exist {
+		return f
+	}
+
+	e.files[name] = hclwrite.NewEmptyFile()
+	e.fileOrder = append(e.fileOrder, name)
+
+	return e.files[name]
+}
+
+// Return a reference to a previously exported object or the fallback literal value
+func (e *exporter) ref(key string, attribute string, fallback string) interface{} {
+	if address, exist := e.refs[key]; exist {
+		return exportRef{Address: address, Attribute: attribute}
+	}
+
+	return fallback
+}
+
+// Declare a variable within the given file, once
+func (e *exporter) variable(file string, name string, description string, sensitive bool) exportVar {
+	if !e.variables[name] {
+		body := e.file(file).Body()
+		block := body.AppendNewBlock("variable", []string{name}).Body()
+		block.SetAttributeRaw("type", hclwrite.TokensForIdentifier("string"))
+		block.SetAttributeValue("description", cty.StringVal(description))
+		if sensitive {
+			block.SetAttributeValue("sensitive", cty.True)
+		}
+		body.AppendNewline()
+		e.variables[name] = true
+	}
+
+	return exportVar{Name: name}
+}
+
+// Append a resource and its import block to the given file, registering the object under the given keys
+func (e *exporter) resource(file string, rtype string, name string, oid string, attrs []exportAttr, keys ...string) string {
+	label := exportlabel(name)
+
+	for i := 2; e.addresses[rtype+"."+label]; i++ {
+		label = exportlabel(name) + "_" + strconv.Itoa(i)
+	}
+
+	address := rtype + "." + label
+	e.addresses[address] = true
+
+	for _, key := range keys {
+		e.refs[key] = address
+	}
+
+	body := e.file(file).Body()
+	block := body.AppendNewBlock("resource", []string{rtype, label}).Body()
+
+	for _, attr := range attrs {
+		exportsetattribute(block, attr.Name, attr.Value)
+	}
+
+	body.AppendNewline()
+
+	imp := body.AppendNewBlock("import", nil).Body()
+	imp.SetAttributeTraversal("to", hcl.Traversal{hcl.TraverseRoot{Name: rtype}, hcl.TraverseAttr{Name: label}})
+	imp.SetAttributeValue("id", cty.StringVal(oid))
+	body.AppendNewline()
+
+	e.count++
+
+	return address
+}
+
+// Write an attribute, empty values are skipped to keep the resource defaults
+func exportsetattribute(body *hclwrite.Body, name string, value interface{}) {
+	switch v := value.(type) {
+	case exportRef:
+		parts := strings.SplitN(v.Address, ".", 2)
+		body.SetAttributeTraversal(name, hcl.Traversal{hcl.TraverseRoot{Name: parts[0]}, hcl.TraverseAttr{Name: parts[1]}, hcl.TraverseAttr{Name: v.Attribute}})
+	case exportVar:
+		body.SetAttributeTraversal(name, hcl.Traversal{hcl.TraverseRoot{Name: "var"}, hcl.TraverseAttr{Name: v.Name}})
+	case string:
+		if v != "" {
+			body.SetAttributeValue(name, cty.StringVal(v))
+		}
+	case int:
+		body.SetAttributeValue(name, cty.NumberIntVal(int64(v)))
+	case bool:
+		body.SetAttributeValue(name, cty.BoolVal(v))
+	case []string:
+		if len(v) > 0 {
+			values := []cty.Value{}
+			for _, item := range v {
+				values = append(values, cty.StringVal(item))
+			}
+			body.SetAttributeValue(name, cty.ListVal(values))
+		}
+	case map[string]string:
+		if len(v) > 0 {
+			values := map[string]cty.Value{}
+			for k, item := range v {
+				values[k] = cty.StringVal(item)
+			}
+			body.SetAttributeValue(name, cty.MapVal(values))
+		}
+	}
+}
+
+// List objects and sort them by the given numeric field to create parents first
+func (e *exporter) list(service string, where string, levelField string) ([]map[string]string, error) {
+	parameters := url.Values{}
+	parameters.Add("WHERE", where)
+
+	buf, err := solidserverlist(service, parameters, 0, e.s)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]map[string]string, 0, len(buf))
+
+	for _, entry := range buf {
+		res = append(res, restobjectattributes(entry))
+	}
+
+	if levelField != "" {
+		sort.SliceStable(res, func(i, j int) bool {
+			li, _ := strconv.Atoi(res[i][levelField])
+			lj, _ := strconv.Atoi(res[j][levelField])
+			return li < lj
+		})
+	}
+
+	return res, nil
+}
+
+func (e *exporter) exportspace(name string) error {
+	spaces, err := e.list("ip_site_list", "site_name='"+wherequote(name)+"'", "")
+
+	if err != nil {
+		return err
+	}
+
+	if len(spaces) == 0 {
+		return fmt.Errorf("SOLIDServer - Unable to find IP space: %s\n", name)
+	}
+
+	space := spaces[0]
+	spaceKey := "ip_space:" + space["site_id"]
+
+	e.resource("ipam.tf", "solidserver_ip_space", space["site_name"], space["site_id"], []exportAttr{
+		{"name", space["site_name"]},
+		{"class", space["site_class_name"]},
+		{"class_parameters", exportclassparameters(space["site_class_parameters"])},
+	}, spaceKey, "ip_space:name:"+space["site_name"])
+
+	// IPv4 blocks and subnets
+	subnets, err := e.list("ip_block_subnet_list", "site_id='"+space["site_id"]+"'", "subnet_level")
+
+	if err != nil {
+		return err
+	}
+
+	for _, subnet := range subnets {
+		subnetSize, _ := strconv.Atoi(subnet["subnet_size"])
+		attrs := []exportAttr{
+			{"space", e.ref(spaceKey, "name", space["site_name"])},
+		}
+
+		if subnet["subnet_level"] != "0" {
+			attrs = append(attrs, exportAttr{"block", e.ref("ip_subnet:"+subnet["parent_subnet_id"], "name", exportfield(subnet, "parent_subnet_name"))})
+		}
+
+		attrs = append(attrs,
+			exportAttr{"request_ip", hexiptoip(subnet["start_ip_addr"])},
+			exportAttr{"prefix_size", sizetoprefixlength(subnetSize)},
+			exportAttr{"name", subnet["subnet_name"]},
+			exportAttr{"terminal", subnet["is_terminal"] == "1"},
+		)
+
+		if vlanDomain := exportfield(subnet, "vlmdomain_name"); vlanDomain != "" {
+			vlanID, _ := strconv.Atoi(subnet["vlmvlan_vlan_id"])
+			attrs = append(attrs,
+				exportAttr{"vlan_domain", e.ref("vlan_domain:name:"+vlanDomain, "name", vlanDomain)},
+				exportAttr{"vlan_id", vlanID},
+			)
+		}
+
+		attrs = append(attrs,
+			exportAttr{"class", subnet["subnet_class_name"]},
+			exportAttr{"class_parameters", exportclassparameters(subnet["subnet_class_parameters"], "gateway")},
+		)
+
+		e.resource("ipam.tf", "solidserver_ip_subnet", subnet["subnet_name"], subnet["subnet_id"], attrs, "ip_subnet:"+subnet["subnet_id"])
+	}
+
+	// IPv4 pools
+	pools, err := e.list("ip_pool_list", "site_id='"+space["site_id"]+"'", "")
+
+	if err != nil {
+		return err
+	}
+
+	for _, pool := range pools {
+		poolSize, _ := strconv.Atoi(pool["pool_size"])
+
+		e.resource("ipam.tf", "solidserver_ip_pool", pool["pool_name"], pool["pool_id"], []exportAttr{
+			{"space", e.ref(spaceKey, "name", space["site_name"])},
+			{"subnet", e.ref("ip_subnet:"+pool["subnet_id"], "name", exportfield(pool, "subnet_name"))},
+			{"start", hexiptoip(pool["start_ip_addr"])},
+			{"size", poolSize},
+			{"name", pool["pool_name"]},
+			{"class", pool["pool_class_name"]},
+			{"class_parameters", exportclassparameters(pool["pool_class_parameters"])},
+		}, "ip_pool:"+pool["pool_id"])
+	}
+
+	// IPv4 addresses
+	addresses, err := e.list("ip_address_list", "site_id='"+space["site_id"]+"'", "")
+
+	if err != nil {
+		return err
+	}
+
+	for _, address := range addresses {
+		if address["ip_id"] == "" || address["ip_id"] == "0" {
+			continue
+		}
+
+		attrs := []exportAttr{
+			{"space", e.ref(spaceKey, "name", space["site_name"])},
+			{"subnet", e.ref("ip_subnet:"+address["subnet_id"], "name", exportfield(address, "subnet_name"))},
+		}
+
+		if address["pool_id"] != "" && address["pool_id"] != "0" {
+			attrs = append(attrs, exportAttr{"pool", e.ref("ip_pool:"+address["pool_id"], "name", exportfield(address, "pool_name"))})
+		}
+
+		attrs = append(attrs,
+			exportAttr{"request_ip", hexiptoip(address["ip_addr"])},
+			exportAttr{"name", address["name"]},
+			exportAttr{"device", exportfield(address, "hostdev_name")},
+		)
+
+		if !strings.HasPrefix(address["mac_addr"], "EIP:") {
+			attrs = append(attrs, exportAttr{"mac", address["mac_addr"]})
+		}
+
+		attrs = append(attrs,
+			exportAttr{"class", address["ip_class_name"]},
+			exportAttr{"class_parameters", exportclassparameters(address["ip_class_parameters"])},
+		)
+
+		e.resource("ipam.tf", "solidserver_ip_address", address["name"], address["ip_id"], attrs)
+	}
+
+	// IPv6 blocks and subnets
+	subnets6, err := e.list("ip6_block6_subnet6_list", "site_id='"+space["site_id"]+"'", "subnet_level")
+
+	if err != nil {
+		return err
+	}
+
+	for _, subnet := range subnets6 {
+		prefixSize, _ := strconv.Atoi(subnet["subnet6_prefix"])
+		attrs := []exportAttr{
+			{"space", e.ref(spaceKey, "name", space["site_name"])},
+		}
+
+		if subnet["subnet_level"] != "0" {
+			attrs = append(attrs, exportAttr{"block", e.ref("ip6_subnet:"+subnet["parent_subnet6_id"], "name", exportfield(subnet, "parent_subnet6_name"))})
+		}
+
+		attrs = append(attrs,
+			exportAttr{"request_ip", hexip6toip6(subnet["start_ip6_addr"])},
+			exportAttr{"prefix_size", prefixSize},
+			exportAttr{"name", subnet["subnet6_name"]},
+			exportAttr{"terminal", subnet["is_terminal"] == "1"},
+		)
+
+		if vlanDomain := exportfield(subnet, "vlmdomain_name"); vlanDomain != "" {
+			vlanID, _ := strconv.Atoi(subnet["vlmvlan_vlan_id"])
+			attrs = append(attrs,
+				exportAttr{"vlan_domain", e.ref("vlan_domain:name:"+vlanDomain, "name", vlanDomain)},
+				exportAttr{"vlan_id", vlanID},
+			)
+		}
+
+		attrs = append(attrs,
+			exportAttr{"class", subnet["subnet6_class_name"]},
+			exportAttr{"class_parameters", exportclassparameters(subnet["subnet6_class_parameters"], "gateway")},
+		)
+
+		e.resource("ipam.tf", "solidserver_ip6_subnet", subnet["subnet6_name"], subnet["subnet6_id"], attrs, "ip6_subnet:"+subnet["subnet6_id"])
+	}
+
+	// IPv6 pools
+	pools6, err := e.list("ip6_pool6_list", "site_id='"+space["site_id"]+"'", "")
+
+	if err != nil {
+		return err
+	}
+
+	for _, pool := range pools6 {
+		e.resource("ipam.tf", "solidserver_ip6_pool", pool["pool6_name"], pool["pool6_id"], []exportAttr{
+			{"space", e.ref(spaceKey, "name", space["site_name"])},
+			{"subnet", e.ref("ip6_subnet:"+pool["subnet6_id"], "name", exportfield(pool, "subnet6_name"))},
+			{"start", hexip6toip6(pool["start_ip6_addr"])},
+			{"end", hexip6toip6(pool["end_ip6_addr"])},
+			{"name", pool["pool6_name"]},
+			{"class", pool["pool6_class_name"]},
+			{"class_parameters", exportclassparameters(pool["pool6_class_parameters"])},
+		}, "ip6_pool:"+pool["pool6_id"])
+	}
+
+	// IPv6 addresses
+	addresses6, err := e.list("ip6_address6_list", "site_id='"+space["site_id"]+"'", "")
+
+	if err != nil {
+		return err
+	}
+
+	for _, address := range addresses6 {
+		if address["ip6_id"] == "" || address["ip6_id"] == "0" {
+			continue
+		}
+
+		attrs := []exportAttr{
+			{"space", e.ref(spaceKey, "name", space["site_name"])},
+			{"subnet", e.ref("ip6_subnet:"+address["subnet6_id"], "name", exportfield(address, "subnet6_name"))},
+		}
+
+		if address["pool6_id"] != "" && address["pool6_id"] != "0" {
+			attrs = append(attrs, exportAttr{"pool", e.ref("ip6_pool:"+address["pool6_id"], "name", exportfield(address, "pool6_name"))})
+		}
+
+		attrs = append(attrs,
+			exportAttr{"request_ip", hexip6toip6(address["ip6_addr"])},
+			exportAttr{"name", address["ip6_name"]},
+			exportAttr{"device", exportfield(address, "hostdev_name")},
+		)
+
+		if !strings.HasPrefix(address["ip6_mac_addr"], "EIP:") {
+			attrs = append(attrs, exportAttr{"mac", address["ip6_mac_addr"]})
+		}
+
+		attrs = append(attrs,
+			exportAttr{"class", address["ip6_class_name"]},
+			exportAttr{"class_parameters", exportclassparameters(address["ip6_class_parameters"])},
+		)
+
+		e.resource("ipam.tf", "solidserver_ip6_address", address["ip6_name"], address["ip6_id"], attrs)
+	}
+
+	return nil
+}
+
+func (e *exporter) exportdnsserver(name string) error {
+	servers, err := e.list("dns_server_list", "dns_name='"+wherequote(name)+"'", "")
+
+	if err != nil {
+		return err
+	}
+
+	if len(servers) == 0 {
+		return fmt.Errorf("SOLIDServer - Unable to find DNS server: %s\n", name)
+	}
+
+	server := servers[0]
+	serverKey := "dns_server:" + server["dns_id"]
+
+	if server["dns_type"] == "vdns" {
+		e.resource("dns.tf", "solidserver_dns_smart", server["dns_name"], server["dns_id"], []exportAttr{
+			{"name", server["dns_name"]},
+			{"arch", server["vdns_arch"]},
+			{"comment", server["dns_comment"]},
+			{"class", server["dns_class_name"]},
+			{"class_parameters", exportclassparameters(server["dns_class_parameters"])},
+		}, serverKey)
+	} else {
+		e.resource("dns.tf", "solidserver_dns_server", server["dns_name"], server["dns_id"], []exportAttr{
+			{"name", server["dns_name"]},
+			{"address", hexiptoip(server["ip_addr"])},
+			{"login", e.variable("dns.tf", "dns_server_login", "The login used to manage the exported DNS servers.", false)},
+			{"password", e.variable("dns.tf", "dns_server_password", "The password used to manage the exported DNS servers.", true)},
+			{"comment", server["dns_comment"]},
+			{"class", server["dns_class_name"]},
+			{"class_parameters", exportclassparameters(server["dns_class_parameters"])},
+		}, serverKey)
+	}
+
+	// DNS views
+	views, err := e.list("dns_view_list", "dns_id='"+server["dns_id"]+"'", "")
+
+	if err != nil {
+		return err
+	}
+
+	for _, view := range views {
+		e.resource("dns.tf", "solidserver_dns_view", server["dns_name"]+"_"+view["dnsview_name"], view["dnsview_id"], []exportAttr{
+			{"dnsserver", e.ref(serverKey, "name", server["dns_name"])},
+			{"name", view["dnsview_name"]},
+			{"class", view["dnsview_class_name"]},
+			{"class_parameters", exportclassparameters(view["dnsview_class_parameters"])},
+		}, "dns_view:"+view["dnsview_id"])
+	}
+
+	// DNS zones
+	zones, err := e.list("dns_zone_list", "dns_id='"+server["dns_id"]+"' AND dnszone_type='master'", "")
+
+	if err != nil {
+		return err
+	}
+
+	for _, zone := range zones {
+		attrs := []exportAttr{
+			{"dnsserver", e.ref(serverKey, "name", server["dns_name"])},
+		}
+
+		if view := exportfield(zone, "dnsview_name"); view != "" {
+			attrs = append(attrs, exportAttr{"dnsview", e.ref("dns_view:"+zone["dnsview_id"], "name", view)})
+		}
+
+		attrs = append(attrs, exportAttr{"name", zone["dnszone_name"]})
+
+		if space := exportfield(zone, "dnszone_site_name"); space != "" {
+			attrs = append(attrs, exportAttr{"space", e.ref("ip_space:name:"+space, "name", space)})
+		}
+
+		attrs = append(attrs,
+			exportAttr{"class", zone["dnszone_class_name"]},
+			exportAttr{"class_parameters", exportclassparameters(zone["dnszone_class_parameters"])},
+		)
+
+		e.resource("dns.tf", "solidserver_dns_zone", server["dns_name"]+"_"+zone["dnsview_name"]+"_"+zone["dnszone_name"], zone["dnszone_id"], attrs, "dns_zone:"+zone["dnszone_id"])
+
+		// DNS RRs supported by the solidserver_dns_rr resource, skipping the apex NS records
+		rrs, err := e.list("dns_rr_list", "dnszone_id='"+zone["dnszone_id"]+"' AND rr_type IN ('A','AAAA','PTR','CNAME','DNAME','NS')", "")
+
+		if err != nil {
+			return err
+		}
+
+		for _, rr := range rrs {
+			if rr["rr_type"] == "NS" && strings.TrimSuffix(rr["rr_full_name"], ".") == strings.TrimSuffix(zone["dnszone_name"], ".") {
+				continue
+			}
+
+			value := rr["value1"]
+			if rr["rr_type"] == "AAAA" {
+				value = longip6toshortip6(value)
+			}
+
+			ttl, _ := strconv.Atoi(rr["ttl"])
+
+			rrAttrs := []exportAttr{
+				{"dnsserver", e.ref(serverKey, "name", server["dns_name"])},
+			}
+
+			if view := exportfield(zone, "dnsview_name"); view != "" {
+				rrAttrs = append(rrAttrs, exportAttr{"dnsview", e.ref("dns_view:"+zone["dnsview_id"], "name", view)})
+			}
+
+			rrAttrs = append(rrAttrs,
+				exportAttr{"dnszone", e.ref("dns_zone:"+zone["dnszone_id"], "name", zone["dnszone_name"])},
+				exportAttr{"name", rr["rr_full_name"]},
+				exportAttr{"type", rr["rr_type"]},
+				exportAttr{"value", value},
+				exportAttr{"ttl", ttl},
+			)
+
+			e.resource("dns.tf", "solidserver_dns_rr", rr["rr_full_name"]+"_"+rr["rr_type"], rr["rr_id"], rrAttrs)
+		}
+	}
+
+	return nil
+}
+
+func (e *exporter) exportvlandomain(name string) error {
+	domains, err := e.list("vlmdomain_list", "vlmdomain_name='"+wherequote(name)+"'", "")
+
+	if err != nil {
+		return err
+	}
+
+	if len(domains) == 0 {
+		return fmt.Errorf("SOLIDServer - Unable to find VLAN domain: %s\n", name)
+	}
+
+	domain := domains[0]
+	domainKey := "vlan_domain:" + domain["vlmdomain_id"]
+	vxlan, _ := strconv.ParseBool(domain["support_vxlan"])
+
+	e.resource("vlan.tf", "solidserver_vlan_domain", domain["vlmdomain_name"], domain["vlmdomain_id"], []exportAttr{
+		{"name", domain["vlmdomain_name"]},
+		{"vxlan", vxlan},
+		{"class", domain["vlmdomain_class_name"]},
+		{"class_parameters", exportclassparameters(domain["vlmdomain_class_parameters"])},
+	}, domainKey, "vlan_domain:name:"+domain["vlmdomain_name"])
+
+	// VLAN ranges
+	ranges, err := e.list("vlmrange_list", "vlmdomain_id='"+domain["vlmdomain_id"]+"'", "")
+
+	if err != nil {
+		return err
+	}
+
+	for _, vlanRange := range ranges {
+		start, _ := strconv.Atoi(vlanRange["vlmrange_start_vlan_id"])
+		end, _ := strconv.Atoi(vlanRange["vlmrange_end_vlan_id"])
+
+		e.resource("vlan.tf", "solidserver_vlan_range", domain["vlmdomain_name"]+"_"+vlanRange["vlmrange_name"], vlanRange["vlmrange_id"], []exportAttr{
+			{"vlan_domain", e.ref(domainKey, "name", domain["vlmdomain_name"])},
+			{"name", vlanRange["vlmrange_name"]},
+			{"start", start},
+			{"end", end},
+			{"class", vlanRange["vlmrange_class_name"]},
+			{"class_parameters", exportclassparameters(vlanRange["vlmrange_class_parameters"])},
+		}, "vlan_range:"+vlanRange["vlmrange_id"])
+	}
+
+	// VLANs
+	vlans, err := e.list("vlmvlan_list", "vlmdomain_id='"+domain["vlmdomain_id"]+"'", "")
+
+	if err != nil {
+		return err
+	}
+
+	for _, vlan := range vlans {
+		if vlan["vlmvlan_id"] == "" || vlan["vlmvlan_id"] == "0" {
+			continue
+		}
+
+		vlanID, _ := strconv.Atoi(vlan["vlmvlan_vlan_id"])
+		attrs := []exportAttr{
+			{"vlan_domain", e.ref(domainKey, "name", domain["vlmdomain_name"])},
+		}
+
+		if vlanRange := exportfield(vlan, "vlmrange_name"); vlanRange != "" {
+			attrs = append(attrs, exportAttr{"vlan_range", e.ref("vlan_range:"+vlan["vlmrange_id"], "name", vlanRange)})
+		}
+
+		attrs = append(attrs,
+			exportAttr{"request_id", vlanID},
+			exportAttr{"name", vlan["vlmvlan_name"]},
+			exportAttr{"class", vlan["vlmvlan_class_name"]},
+			exportAttr{"class_parameters", exportclassparameters(vlan["vlmvlan_class_parameters"])},
+		)
+
+		e.resource("vlan.tf", "solidserver_vlan", domain["vlmdomain_name"]+"_"+vlan["vlmvlan_vlan_id"]+"_"+vlan["vlmvlan_name"], vlan["vlmvlan_id"], attrs)
+	}
+
+	return nil
+}
+
+// Write the generated files into the output directory
+func (e *exporter) write(dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	for _, name := range e.fileOrder {
+		if err := os.WriteFile(filepath.Join(dir, name), e.files[name].Bytes(), 0644); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Export command: crawl the selected SOLIDserver objects and generate the matching
+// terraform configuration along with import blocks (terraform >= 1.5)
+func Export(args []string, stdout io.Writer, stderr io.Writer) int {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: terraform-provider-solidserver export [options]\n\n")
+		fmt.Fprintf(stderr, "Generate terraform configuration and import blocks from existing SOLIDserver objects.\n\n")
+		fs.PrintDefaults()
+	}
+
+	config := cliconfigflags(fs)
+	spaces := fs.String("spaces", "", "Comma separated list of IP spaces to export (including blocks, subnets, pools and addresses)")
+	dnsServers := fs.String("dnsservers", "", "Comma separated list of DNS servers/SMARTs to export (including views, zones and records)")
+	vlanDomains := fs.String("vlandomains", "", "Comma separated list of VLAN domains to export (including ranges and VLANs)")
+	output := fs.String("output", ".", "Directory into which writing the generated .tf files")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if err := config.validate(); err != nil {
+		fmt.Fprintf(stderr, "Error: %s\n", err)
+		return 2
+	}
+
+	if len(clilist(*spaces))+len(clilist(*dnsServers))+len(clilist(*vlanDomains)) == 0 {
+		fmt.Fprintf(stderr, "Error: nothing to export, use -spaces, -dnsservers and/or -vlandomains\n")
+		return 2
+	}
+
+	s, diags := config.connect(context.Background())
+
+	if diags.HasError() {
+		fmt.Fprintf(stderr, "Error: unable to connect to SOLIDserver: %s\n", clidiagstring(diags))
+		return 1
+	}
+
+	e := newexporter(s)
+
+	// VLAN domains are exported first so that subnets can reference them
+	for _, name := range clilist(*vlanDomains) {
+		if err := e.exportvlandomain(name); err != nil {
+			fmt.Fprintf(stderr, "Error: %s\n", strings.TrimSpace(err.Error()))
+			return 1
+		}
+	}
+
+	// Spaces are exported before DNS servers so that zones can reference them
+	for _, name := range clilist(*spaces) {
+		if err := e.exportspace(name); err != nil {
+			fmt.Fprintf(stderr, "Error: %s\n", strings.TrimSpace(err.Error()))
+			return 1
+		}
+	}
+
+	for _, name := range clilist(*dnsServers) {
+		if err := e.exportdnsserver(name); err != nil {
+			fmt.Fprintf(stderr, "Error: %s\n", strings.TrimSpace(err.Error()))
+			return 1
+		}
+	}
+
+	if err := e.write(*output); err != nil {
+		fmt.Fprintf(stderr, "Error: unable to write the generated files: %s\n", err)
+		return 1
+	}
+
+	fmt.Fprintf(stdout, "Exported %d object(s) into %s (%s)\n", e.count, *output, strings.Join(e.fileOrder, ", "))
+
+	return 0
+}
@@ -0,0 +1,78 @@
+package solidserver
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExportLabel(t *testing.T) {
+	testCases := map[string]string{
+		"prod":          "prod",
+		"Prod Block-A":  "prod_block_a",
+		"10.0.0.0/8":    "_10_0_0_0_8",
+		"www.corp.lan.": "www_corp_lan",
+		"###":           "object",
+	}
+
+	for name, expected := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if result := exportlabel(name); result != expected {
+				t.Errorf("expected %q, got %q", expected, result)
+			}
+		})
+	}
+}
+
+func TestExportResourceReferences(t *testing.T) {
+	e := newexporter(nil)
+
+	e.resource("ipam.tf", "solidserver_ip_space", "prod", "2", []exportAttr{
+		{"name", "prod"},
+	}, "ip_space:2")
+
+	e.resource("ipam.tf", "solidserver_ip_subnet", "block", "10", []exportAttr{
+		{"space", e.ref("ip_space:2", "name", "prod")},
+		{"name", "block"},
+		{"terminal", false},
+	}, "ip_subnet:10")
+
+	e.resource("ipam.tf", "solidserver_ip_subnet", "block", "11", []exportAttr{
+		{"space", e.ref("ip_space:2", "name", "prod")},
+		{"block", e.ref("ip_subnet:10", "name", "block")},
+		{"prefix_size", 24},
+		{"class", ""},
+		{"class_parameters", map[string]string{"vnid": "12"}},
+	}, "ip_subnet:11")
+
+	e.resource("ipam.tf", "solidserver_ip_subnet", "orphan", "12", []exportAttr{
+		{"block", e.ref("ip_subnet:99", "name", "unknown")},
+	})
+
+	// Ignoring the attributes alignment
+	result := strings.Join(strings.Fields(string(e.files["ipam.tf"].Bytes())), " ")
+
+	expected := []string{
+		`resource "solidserver_ip_space" "prod" {`,
+		`space = solidserver_ip_space.prod.name`,
+		`resource "solidserver_ip_subnet" "block_2" {`,
+		`block = solidserver_ip_subnet.block.name`,
+		`prefix_size = 24`,
+		`class_parameters = { vnid = "12" }`,
+		`import { to = solidserver_ip_subnet.block_2 id = "11" }`,
+		`block = "unknown"`,
+	}
+
+	for _, line := range expected {
+		if !strings.Contains(result, line) {
+			t.Errorf("expected generated configuration to contain %q, got: %s", line, result)
+		}
+	}
+
+	if strings.Contains(result, "class ") {
+		t.Errorf("expected empty attributes to be skipped, got: %s", result)
+	}
+
+	if e.count != 4 {
+		t.Errorf("expected 4 exported objects, got %d", e.count)
+	}
+}
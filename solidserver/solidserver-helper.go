@@ -1,13 +1,16 @@
 package solidserver
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"inet.af/netaddr"
 	"math/big"
+	"math/rand"
 	"net/url"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -268,6 +271,11 @@ func longtoip(iplong uint32) string {
 	return fmt.Sprintf("%d.%d.%d.%d", a, b, c, d)
 }
 
+// Escape a value to be used within a quoted string of a WHERE clause
+func wherequote(value string) string {
+	return strings.ReplaceAll(value, "'", "''")
+}
+
 // Ignore Case When comparing remote and local value
 func resourcediffsuppresscase(k, old, new string, d *schema.ResourceData) bool {
 	if strings.ToLower(old) == strings.ToLower(new) {
@@ -277,6 +285,33 @@ func resourcediffsuppresscase(k, old, new string, d *schema.ResourceData) bool {
 	return false
 }
 
+// Ignore the changes of the parent block when it is picked among candidate blocks
+func resourceipsubnetdiffsuppressblock(k, old, new string, d *schema.ResourceData) bool {
+	return len(d.Get("blocks").([]interface{})) > 0 || d.Get("block_query").(string) != ""
+}
+
+// Validate at plan time the in place resize of an IP subnet against its block's free map
+func resourceipsubnetresizediff(ipv6 bool) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		// Resize only applies to existing subnets not being replaced
+		if d.Id() == "" || !d.HasChange("prefix_size") || d.HasChange("request_ip") || d.HasChange("space") || meta == nil {
+			return nil
+		}
+
+		d.SetNewComputed("prefix")
+
+		if !ipv6 {
+			d.SetNewComputed("netmask")
+		}
+
+		if d.Get("gateway_offset").(int) < 0 {
+			d.SetNewComputed("gateway")
+		}
+
+		return ipsubnetresizecheck(d.Id(), d.Get("prefix_size").(int), ipv6, meta)
+	}
+}
+
 // Ignore Different IPv6 Format
 func resourcediffsuppressIPv6Format(k, old, new string, d *schema.ResourceData) bool {
 	oldipv6, _ := netaddr.ParseIP(old)
@@ -356,6 +391,35 @@ func urlfromclassparams(parameters interface{}) url.Values {
 	return classParameters
 }
 
+// Build the path of a SOLIDserver service from its name
+// Return the name prefixed with 'rest/' unless it already includes its API type (ex: rpc/)
+func restservicepath(service string) string {
+	if strings.Contains(service, "/") {
+		return service
+	}
+
+	return "rest/" + service
+}
+
+// Convert the fields of a SOLIDserver answer into a map of strings
+// Return a map[string]string object
+func restobjectattributes(fields map[string]interface{}) map[string]string {
+	attributes := map[string]string{}
+
+	for k, v := range fields {
+		switch value := v.(type) {
+		case nil:
+			attributes[k] = ""
+		case string:
+			attributes[k] = value
+		default:
+			attributes[k] = fmt.Sprintf("%v", value)
+		}
+	}
+
+	return attributes
+}
+
 // Return the oid of a device from hostdev_name
 // Or an empty string in case of failure
 func hostdevidbyname(hostdevName string, meta interface{}) (string, error) {
@@ -387,9 +451,13 @@ func hostdevidbyname(hostdevName string, meta interface{}) (string, error) {
 
 // Return an available IP addresses from site_id, block_id and expected subnet_size
 // Or an empty table of string in case of failure
-func ipaddressfindfree(subnetID string, poolID string, meta interface{}) ([]string, error) {
+func ipaddressfindfree(subnetID string, poolID string, policy ipAddressPolicy, meta interface{}) ([]string, error) {
 	s := meta.(*SOLIDserver)
 
+	if !policy.isdefault() {
+		return ipaddressfindfreebypolicy(subnetID, poolID, policy, false, meta)
+	}
+
 	// Building parameters
 	parameters := url.Values{}
 	parameters.Add("subnet_id", subnetID)
@@ -427,9 +495,13 @@ func ipaddressfindfree(subnetID string, poolID string, meta interface{}) ([]stri
 
 // Return an available IP addresses from site_id, block_id and expected subnet_size
 // Or an empty table of string in case of failure
-func ip6addressfindfree(subnetID string, poolID string, meta interface{}) ([]string, error) {
+func ip6addressfindfree(subnetID string, poolID string, policy ipAddressPolicy, meta interface{}) ([]string, error) {
 	s := meta.(*SOLIDserver)
 
+	if !policy.isdefault() {
+		return ipaddressfindfreebypolicy(subnetID, poolID, policy, true, meta)
+	}
+
 	// Building parameters
 	parameters := url.Values{}
 	parameters.Add("subnet6_id", subnetID)
@@ -552,6 +624,98 @@ func ipsiteidbyname(siteName string, meta interface{}) (string, error) {
 	return "", err
 }
 
+// Return the oid of the parent space (VLSM) of a space from its oid
+// Or an empty string if the space has no parent space
+func ipsiteparentid(siteID string, meta interface{}) (string, error) {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("site_id", siteID)
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/ip_site_info", &parameters)
+
+	if err != nil {
+		return "", err
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	// Checking the answer
+	if resp.StatusCode == 200 && len(buf) > 0 {
+		if parentSiteID, parentSiteIDExist := buf[0]["parent_site_id"].(string); parentSiteIDExist && parentSiteID != "0" && parentSiteID != "#" {
+			return parentSiteID, nil
+		}
+
+		return "", nil
+	}
+
+	return "", fmt.Errorf("SOLIDServer - Unable to find IP space (oid): %s\n", siteID)
+}
+
+// Return the information of the subnet of the parent space (VLSM) of a space from its name
+// Terminal subnets are looked up first, then the non-terminal ones
+func ipsubnetvlsmparentinfo(siteID string, parentSubnet string, meta interface{}) (map[string]interface{}, error) {
+	parentSiteID, err := ipsiteparentid(siteID, meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(parentSiteID) == 0 {
+		return nil, fmt.Errorf("SOLIDServer - Unable to find the parent IP space of IP space (oid): %s\n", siteID)
+	}
+
+	parentInfo, err := ipsubnetinfobyname(parentSiteID, parentSubnet, true, meta)
+
+	if err != nil {
+		parentInfo, err = ipsubnetinfobyname(parentSiteID, parentSubnet, false, meta)
+	}
+
+	return parentInfo, err
+}
+
+// Return the name of the subnet of the parent space (VLSM) an IP block is delegated from,
+// using its rest/ip_block_subnet_info entry, or an empty string if it is not delegated
+func ipsubnetvlsmparentname(entry map[string]interface{}, meta interface{}) (string, error) {
+	vlsmSubnetID, _ := entry["vlsm_subnet_id"].(string)
+
+	if len(vlsmSubnetID) == 0 || vlsmSubnetID == "0" || vlsmSubnetID == "#" {
+		return "", nil
+	}
+
+	if vlsmSubnetName, vlsmSubnetNameExist := entry["vlsm_subnet_name"].(string); vlsmSubnetNameExist && len(vlsmSubnetName) > 0 && vlsmSubnetName != "#" {
+		return vlsmSubnetName, nil
+	}
+
+	parentInfo, err := ipsubnetinfobyid(vlsmSubnetID, false, meta)
+
+	if err != nil {
+		return "", err
+	}
+
+	return parentInfo["name"], nil
+}
+
+// Check that an IP block can mirror the delegated subnet of the parent space
+func ipsubnetvlsmcheck(parentInfo map[string]interface{}, requestedIP string, prefixSize int) error {
+	parentName, _ := parentInfo["name"].(string)
+	parentAddress, _ := parentInfo["start_addr"].(string)
+	parentPrefixSize, _ := parentInfo["prefix_length"].(int)
+
+	if len(requestedIP) > 0 && requestedIP != parentAddress {
+		return fmt.Errorf("SOLIDServer - Requested IP: %s does not match the address of parent subnet: %s (%s)\n", requestedIP, parentName, parentAddress)
+	}
+
+	if prefixSize != parentPrefixSize {
+		return fmt.Errorf("SOLIDServer - Prefix size: %d does not match the prefix size of parent subnet: %s (%d)\n", prefixSize, parentName, parentPrefixSize)
+	}
+
+	return nil
+}
+
 // Return the oid of a vlan domain from vlmdomain_name
 // Or an empty string in case of failure
 func vlandomainidbyname(vlmdomainName string, meta interface{}) (string, error) {
@@ -605,7 +769,7 @@ func vlanidbyinfo(vlmdomainName string, vlmvlanvlanID int, meta interface{}) (st
 		}
 	}
 
-	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find VLAN ID %d within VLAN Domain\n", vlmvlanvlanID, vlmdomainName))
+	tflog.Debug(s.Ctx, fmt.Sprintf("Unable to find VLAN ID %d within VLAN Domain: %s\n", vlmvlanvlanID, vlmdomainName))
 
 	return "", err
 }
@@ -1676,3 +1840,1134 @@ func dnsdeletefromsmart(smartName string, serverName string, meta interface{}) b
 
 	return false
 }
+
+// Retrieve all the objects returned by a list service, walking through the result pages
+// Stop once limit objects are retrieved (0 means no limit)
+// Return an empty list in case of failure along with the error
+func solidserverlist(service string, parameters url.Values, limit int, meta interface{}) ([]map[string]interface{}, error) {
+	s := meta.(*SOLIDserver)
+	res := []map[string]interface{}{}
+	pageSize := 1000
+	offset := 0
+
+	for limit <= 0 || len(res) < limit {
+		size := pageSize
+
+		if limit > 0 && limit-len(res) < size {
+			size = limit - len(res)
+		}
+
+		// Building page parameters
+		pageParameters := url.Values{}
+
+		for k, v := range parameters {
+			pageParameters[k] = v
+		}
+
+		pageParameters.Set("offset", strconv.Itoa(offset))
+		pageParameters.Set("limit", strconv.Itoa(size))
+
+		// Sending the read request
+		resp, body, err := s.Request("get", restservicepath(service), &pageParameters)
+
+		if err != nil {
+			return []map[string]interface{}{}, err
+		}
+
+		// No (more) matching objects
+		if resp.StatusCode == 204 {
+			break
+		}
+
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if resp.StatusCode != 200 {
+			if len(buf) > 0 {
+				if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+					return []map[string]interface{}{}, fmt.Errorf("SOLIDServer - Unable to list objects using service: %s (%s)\n", service, errMsg)
+				}
+			}
+
+			return []map[string]interface{}{}, fmt.Errorf("SOLIDServer - Unable to list objects using service: %s\n", service)
+		}
+
+		res = append(res, buf...)
+		offset += len(buf)
+
+		tflog.Debug(s.Ctx, fmt.Sprintf("Retrieved %d object(s) from service: %s (offset: %d)\n", len(buf), service, offset))
+
+		// Last page reached
+		if len(buf) < size {
+			break
+		}
+	}
+
+	return res, nil
+}
+
+// Convert a glob pattern (using * and ?) into a SQL LIKE pattern
+// The LIKE wildcards (% and _) of the glob are escaped to match literally
+// Return the LIKE pattern
+func globtolike(glob string) string {
+	return strings.NewReplacer("\\", "\\\\", "%", "\\%", "_", "\\_", "*", "%", "?", "_", "'", "''").Replace(glob)
+}
+
+// Class parameter names allowed within the filters of the plural data-sources
+var classParameterFilterRegexp = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// Build the schema of the objects returned by a plural data-source from the singular one
+// Every attribute becomes computed, an id attribute is added to hold the object oid
+func dataSourcecomputedschema(in map[string]*schema.Schema) map[string]*schema.Schema {
+	out := map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Description: "The ID of the object.",
+			Computed:    true,
+		},
+	}
+
+	for k, v := range in {
+		out[k] = &schema.Schema{
+			Type:        v.Type,
+			Description: v.Description,
+			Computed:    true,
+			Elem:        v.Elem,
+		}
+	}
+
+	return out
+}
+
+// Build the WHERE and TAGS parameters of an IPAM list service from the structured filters
+// of a plural data-source (space, parent, name glob, class and class parameters)
+// Return an url.Values{} object
+func ipamfilterparameters(d *schema.ResourceData, parentKey string, parentField string, nameField string, classField string, tagPrefix string) url.Values {
+	parameters := url.Values{}
+	clauses := []string{}
+	tags := []string{}
+
+	if space, spaceExist := d.GetOk("space"); spaceExist {
+		clauses = append(clauses, "site_name='"+wherequote(space.(string))+"'")
+	}
+
+	if parent, parentExist := d.GetOk(parentKey); parentExist {
+		clauses = append(clauses, parentField+"='"+wherequote(parent.(string))+"'")
+	}
+
+	if name, nameExist := d.GetOk("name"); nameExist {
+		clauses = append(clauses, nameField+" LIKE '"+globtolike(name.(string))+"'")
+	}
+
+	if class, classExist := d.GetOk("class"); classExist {
+		clauses = append(clauses, classField+"='"+wherequote(class.(string))+"'")
+	}
+
+	if classParameters, classParametersExist := d.GetOk("class_parameters"); classParametersExist {
+		keys := []string{}
+
+		// The names not allowed are rejected at plan time, and never used within the WHERE clause
+		for k := range classParameters.(map[string]interface{}) {
+			if classParameterFilterRegexp.MatchString(k) {
+				keys = append(keys, k)
+			}
+		}
+
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			tags = append(tags, tagPrefix+"."+k)
+			clauses = append(clauses, "tag_"+tagPrefix+"_"+k+"='"+wherequote(classParameters.(map[string]interface{})[k].(string))+"'")
+		}
+	}
+
+	if len(clauses) > 0 {
+		parameters.Add("WHERE", strings.Join(clauses, " AND "))
+	}
+
+	if len(tags) > 0 {
+		parameters.Add("TAGS", strings.Join(tags, ","))
+	}
+
+	return parameters
+}
+
+// An inclusive range of IPv4 or IPv6 addresses
+type ipRange struct {
+	Start *big.Int
+	End   *big.Int
+}
+
+// A free range of addresses within a parent block/subnet
+type ipHole struct {
+	Block map[string]interface{}
+	Range ipRange
+}
+
+// A candidate subnet address (hexa) within a parent block/subnet
+type ipCandidate struct {
+	Block   map[string]interface{}
+	Address string
+}
+
+// Convert hexa IPv4 or IPv6 address string into a Big Integer
+// Return nil in case of failure
+func hexiptobig(hexip string) *big.Int {
+	res, ok := new(big.Int).SetString(hexip, 16)
+
+	if !ok {
+		return nil
+	}
+
+	return res
+}
+
+// Convert a Big Integer into hexa IPv4 (8 digits) or IPv6 (32 digits) address string
+func bigtohexip(value *big.Int, ipv6 bool) string {
+	if ipv6 {
+		return fmt.Sprintf("%032x", value)
+	}
+
+	return fmt.Sprintf("%08x", value)
+}
+
+// Compute the size of an IPv4 or IPv6 prefix from its length
+func prefixlengthtobigsize(length int, ipv6 bool) *big.Int {
+	if ipv6 {
+		return new(big.Int).Lsh(big.NewInt(1), uint(128-length))
+	}
+
+	return new(big.Int).Lsh(big.NewInt(1), uint(32-length))
+}
+
+// Compute the free ranges of [start, end] once the used ranges are removed
+func ipfreeranges(start *big.Int, end *big.Int, used []ipRange) []ipRange {
+	res := []ipRange{}
+	sorted := append([]ipRange{}, used...)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Start.Cmp(sorted[j].Start) < 0
+	})
+
+	cursor := new(big.Int).Set(start)
+
+	for _, r := range sorted {
+		if r.End.Cmp(cursor) < 0 {
+			continue
+		}
+
+		if r.Start.Cmp(end) > 0 {
+			break
+		}
+
+		if r.Start.Cmp(cursor) > 0 {
+			res = append(res, ipRange{Start: new(big.Int).Set(cursor), End: new(big.Int).Sub(r.Start, big.NewInt(1))})
+		}
+
+		cursor = new(big.Int).Add(r.End, big.NewInt(1))
+	}
+
+	if cursor.Cmp(end) <= 0 {
+		res = append(res, ipRange{Start: cursor, End: new(big.Int).Set(end)})
+	}
+
+	return res
+}
+
+// Return the first and last addresses aligned on size fitting within the range
+// Return nil values if no aligned prefix fits
+func ipalignedbounds(r ipRange, size *big.Int) (*big.Int, *big.Int) {
+	first := new(big.Int).Add(r.Start, new(big.Int).Sub(size, big.NewInt(1)))
+	first.Div(first, size).Mul(first, size)
+
+	last := new(big.Int).Add(r.End, big.NewInt(1))
+	last.Div(last, size).Mul(last, size).Sub(last, size)
+
+	if first.Cmp(last) > 0 || last.Sign() < 0 {
+		return nil, nil
+	}
+
+	return first, last
+}
+
+// Return up to max candidate subnet addresses of the given size within the holes,
+// ordered according to the allocation strategy (first-fit, best-fit, last-fit, random)
+func ipsubnetcandidates(holes []ipHole, size *big.Int, ipv6 bool, strategy string, max int) []ipCandidate {
+	res := []ipCandidate{}
+	fitting := []ipHole{}
+
+	for _, h := range holes {
+		if first, _ := ipalignedbounds(h.Range, size); first != nil {
+			fitting = append(fitting, h)
+		}
+	}
+
+	switch strategy {
+	case "best-fit":
+		sort.SliceStable(fitting, func(i, j int) bool {
+			si := new(big.Int).Sub(fitting[i].Range.End, fitting[i].Range.Start)
+			sj := new(big.Int).Sub(fitting[j].Range.End, fitting[j].Range.Start)
+			return si.Cmp(sj) < 0
+		})
+	case "last-fit":
+		sort.SliceStable(fitting, func(i, j int) bool {
+			return fitting[i].Range.End.Cmp(fitting[j].Range.End) > 0
+		})
+	case "random":
+		seen := map[string]bool{}
+
+		for attempt := 0; len(fitting) > 0 && len(res) < max && attempt < max*4; attempt++ {
+			h := fitting[rand.Intn(len(fitting))]
+			first, last := ipalignedbounds(h.Range, size)
+			slots := new(big.Int).Div(new(big.Int).Sub(last, first), size)
+			slots.Add(slots, big.NewInt(1))
+
+			address := new(big.Int).Mul(new(big.Int).Rand(rand.New(rand.NewSource(rand.Int63())), slots), size)
+			address.Add(address, first)
+
+			if key := bigtohexip(address, ipv6); !seen[key] {
+				seen[key] = true
+				res = append(res, ipCandidate{Block: h.Block, Address: key})
+			}
+		}
+
+		return res
+	}
+
+	for _, h := range fitting {
+		first, last := ipalignedbounds(h.Range, size)
+
+		if strategy == "last-fit" {
+			for address := last; address.Cmp(first) >= 0 && len(res) < max; address = new(big.Int).Sub(address, size) {
+				res = append(res, ipCandidate{Block: h.Block, Address: bigtohexip(address, ipv6)})
+			}
+		} else {
+			for address := first; address.Cmp(last) <= 0 && len(res) < max; address = new(big.Int).Add(address, size) {
+				res = append(res, ipCandidate{Block: h.Block, Address: bigtohexip(address, ipv6)})
+			}
+		}
+
+		if len(res) >= max {
+			break
+		}
+	}
+
+	return res
+}
+
+// Return the free ranges of an IPv4 or IPv6 block/subnet from its children
+func ipsubnetholes(block map[string]interface{}, ipv6 bool, meta interface{}) ([]ipHole, error) {
+	service, parentField, startField, endField := "ip_block_subnet_list", "parent_subnet_id", "start_ip_addr", "end_ip_addr"
+
+	if ipv6 {
+		service, parentField, startField, endField = "ip6_block6_subnet6_list", "parent_subnet6_id", "start_ip6_addr", "end_ip6_addr"
+	}
+
+	startHex, _ := block["start_hex_addr"].(string)
+	endHex, _ := block["end_hex_addr"].(string)
+	start, end := hexiptobig(startHex), hexiptobig(endHex)
+
+	if start == nil || end == nil {
+		return nil, fmt.Errorf("SOLIDServer - Unable to compute the free ranges of block: %s\n", block["name"])
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("WHERE", parentField+"='"+block["id"].(string)+"'")
+
+	// Sending the read request(s)
+	children, err := solidserverlist(service, parameters, 0, meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	used := []ipRange{}
+
+	for _, child := range children {
+		childStart, _ := child[startField].(string)
+		childEnd, _ := child[endField].(string)
+
+		if cs, ce := hexiptobig(childStart), hexiptobig(childEnd); cs != nil && ce != nil {
+			used = append(used, ipRange{Start: cs, End: ce})
+		}
+	}
+
+	res := []ipHole{}
+
+	for _, r := range ipfreeranges(start, end, used) {
+		res = append(res, ipHole{Block: block, Range: r})
+	}
+
+	return res, nil
+}
+
+// Return the information of the non terminal blocks/subnets matching a WHERE clause within a space
+func ipsubnetblocksbyquery(siteID string, query string, ipv6 bool, meta interface{}) ([]map[string]interface{}, error) {
+	service, prefix, suffix := "ip_block_subnet_list", "subnet", ""
+
+	if ipv6 {
+		service, prefix, suffix = "ip6_block6_subnet6_list", "subnet6", "6"
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("WHERE", "site_id='"+siteID+"' AND is_terminal='0' AND ("+query+")")
+
+	// Sending the read request(s)
+	buf, err := solidserverlist(service, parameters, 0, meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := []map[string]interface{}{}
+
+	for _, entry := range buf {
+		attributes := restobjectattributes(entry)
+
+		res = append(res, map[string]interface{}{
+			"id":             attributes[prefix+"_id"],
+			"name":           attributes[prefix+"_name"],
+			"start_hex_addr": attributes["start_ip"+suffix+"_addr"],
+			"end_hex_addr":   attributes["end_ip"+suffix+"_addr"],
+			"terminal":       attributes["is_terminal"],
+			"level":          attributes["subnet_level"],
+		})
+	}
+
+	if len(res) == 0 {
+		return nil, fmt.Errorf("SOLIDServer - Unable to find any block matching query: %s\n", query)
+	}
+
+	return res, nil
+}
+
+// Return the candidate subnet addresses within the given blocks according to the allocation strategy
+// The first-fit strategy relies on the SOLIDserver suggestions, the other ones on the blocks' free ranges
+func ipsubnetallocationcandidates(siteID string, blocks []map[string]interface{}, requestedIP string, prefixSize int, strategy string, ipv6 bool, meta interface{}) ([]ipCandidate, error) {
+	res := []ipCandidate{}
+
+	// A requested IP address is only tried within the block containing it
+	if len(requestedIP) > 0 && len(blocks) > 1 {
+		hexip := iptohexip(requestedIP)
+
+		if ipv6 {
+			hexip = ip6tohexip6(requestedIP)
+		}
+
+		for _, block := range blocks {
+			startHex, _ := block["start_hex_addr"].(string)
+			endHex, _ := block["end_hex_addr"].(string)
+			start, end, address := hexiptobig(startHex), hexiptobig(endHex), hexiptobig(hexip)
+
+			if start != nil && end != nil && address != nil && start.Cmp(address) <= 0 && address.Cmp(end) <= 0 {
+				blocks = []map[string]interface{}{block}
+				break
+			}
+		}
+	}
+
+	if len(requestedIP) > 0 || strategy == "" || strategy == "first-fit" || blocks[0]["id"].(string) == "" {
+		for _, block := range blocks {
+			var addresses []string
+			var err error
+
+			if ipv6 {
+				addresses, err = ip6subnetfindbysize(siteID, block["id"].(string), requestedIP, prefixSize, meta)
+			} else {
+				addresses, err = ipsubnetfindbysize(siteID, block["id"].(string), requestedIP, prefixSize, meta)
+			}
+
+			if err != nil {
+				return nil, err
+			}
+
+			for _, address := range addresses {
+				res = append(res, ipCandidate{Block: block, Address: address})
+			}
+
+			if len(requestedIP) > 0 {
+				break
+			}
+		}
+
+		return res, nil
+	}
+
+	holes := []ipHole{}
+
+	for _, block := range blocks {
+		blockHoles, err := ipsubnetholes(block, ipv6, meta)
+
+		if err != nil {
+			return nil, err
+		}
+
+		holes = append(holes, blockHoles...)
+	}
+
+	return ipsubnetcandidates(holes, prefixlengthtobigsize(prefixSize, ipv6), ipv6, strategy, 16), nil
+}
+
+// Return the information of an IPv4 or IPv6 block/subnet from its oid
+// Or an error in case of failure
+func ipsubnetinfobyid(subnetID string, ipv6 bool, meta interface{}) (map[string]string, error) {
+	info, err := ipsubnetlookupbyid(subnetID, ipv6, meta)
+
+	if err == nil && info == nil {
+		return nil, fmt.Errorf("SOLIDServer - Unable to find IP subnet (oid): %s\n", subnetID)
+	}
+
+	return info, err
+}
+
+// Return the information of an IPv4 or IPv6 block/subnet from its oid
+// Return nil if the block/subnet does not exist, or an error in case of failure
+func ipsubnetlookupbyid(subnetID string, ipv6 bool, meta interface{}) (map[string]string, error) {
+	s := meta.(*SOLIDserver)
+	service, prefix, suffix := "rest/ip_block_subnet_info", "subnet", ""
+
+	if ipv6 {
+		service, prefix, suffix = "rest/ip6_block6_subnet6_info", "subnet6", "6"
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add(prefix+"_id", subnetID)
+
+	// Sending the read request
+	resp, body, err := s.Request("get", service, &parameters)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	// Checking the answer
+	if resp.StatusCode == 200 && len(buf) > 0 {
+		attributes := restobjectattributes(buf[0])
+
+		return map[string]string{
+			"id":               subnetID,
+			"site_id":          attributes["site_id"],
+			"name":             attributes[prefix+"_name"],
+			"start_hex_addr":   attributes["start_ip"+suffix+"_addr"],
+			"end_hex_addr":     attributes["end_ip"+suffix+"_addr"],
+			"parent_id":        attributes["parent_"+prefix+"_id"],
+			"terminal":         attributes["is_terminal"],
+			"level":            attributes["subnet_level"],
+			"class":            attributes[prefix+"_class_name"],
+			"class_parameters": attributes[prefix+"_class_parameters"],
+		}, nil
+	}
+
+	if resp.StatusCode == 200 || resp.StatusCode == 204 || resp.StatusCode == 404 {
+		return nil, nil
+	}
+
+	if len(buf) > 0 {
+		if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+			return nil, fmt.Errorf("SOLIDServer - Unable to read IP subnet (oid): %s (%s)\n", subnetID, errMsg)
+		}
+	}
+
+	return nil, fmt.Errorf("SOLIDServer - Unable to read IP subnet (oid): %s\n", subnetID)
+}
+
+// Return the first range overlapping r, or nil if none
+func iprangeoverlap(r ipRange, others []ipRange) *ipRange {
+	for i := range others {
+		if others[i].Start.Cmp(r.End) <= 0 && r.Start.Cmp(others[i].End) <= 0 {
+			return &others[i]
+		}
+	}
+
+	return nil
+}
+
+// Compute the prefix resulting from the merge of contiguous ranges
+// Return an error if the ranges do not form a single aligned prefix
+func ipmergedrange(ranges []ipRange) (ipRange, error) {
+	if len(ranges) < 2 {
+		return ipRange{}, fmt.Errorf("at least two subnets are required")
+	}
+
+	sorted := append([]ipRange{}, ranges...)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Start.Cmp(sorted[j].Start) < 0
+	})
+
+	for i := 1; i < len(sorted); i++ {
+		if new(big.Int).Add(sorted[i-1].End, big.NewInt(1)).Cmp(sorted[i].Start) != 0 {
+			return ipRange{}, fmt.Errorf("subnets are not contiguous")
+		}
+	}
+
+	res := ipRange{Start: sorted[0].Start, End: sorted[len(sorted)-1].End}
+	size := new(big.Int).Sub(res.End, res.Start)
+	size.Add(size, big.NewInt(1))
+
+	// The merged range must be a power of two aligned on its size
+	if new(big.Int).And(size, new(big.Int).Sub(size, big.NewInt(1))).Sign() != 0 {
+		return ipRange{}, fmt.Errorf("merged subnets size is not a power of two")
+	}
+
+	if new(big.Int).Mod(res.Start, size).Sign() != 0 {
+		return ipRange{}, fmt.Errorf("merged subnets are not aligned on a prefix boundary")
+	}
+
+	return res, nil
+}
+
+// Check that an IPv4 or IPv6 subnet can be resized in place to the given prefix length
+// The subnet must remain aligned, within its parent and must not overlap its siblings (growth),
+// while its addresses, pools and child subnets must fit within the new boundaries (shrink)
+func ipsubnetresizecheck(subnetID string, prefixLength int, ipv6 bool, meta interface{}) error {
+	subnet, err := ipsubnetinfobyid(subnetID, ipv6, meta)
+
+	if err != nil {
+		return err
+	}
+
+	listService, addressService, poolService := "ip_block_subnet_list", "ip_address_list", "ip_pool_list"
+	idField, parentField, startField, endField, addrField := "subnet_id", "parent_subnet_id", "start_ip_addr", "end_ip_addr", "ip_addr"
+
+	if ipv6 {
+		listService, addressService, poolService = "ip6_block6_subnet6_list", "ip6_address6_list", "ip6_pool6_list"
+		idField, parentField, startField, endField, addrField = "subnet6_id", "parent_subnet6_id", "start_ip6_addr", "end_ip6_addr", "ip6_addr"
+	}
+
+	start := hexiptobig(subnet["start_hex_addr"])
+	currentEnd := hexiptobig(subnet["end_hex_addr"])
+
+	if start == nil || currentEnd == nil {
+		return fmt.Errorf("SOLIDServer - Unable to compute the boundaries of IP subnet: %s\n", subnet["name"])
+	}
+
+	size := prefixlengthtobigsize(prefixLength, ipv6)
+	resized := ipRange{Start: start, End: new(big.Int).Sub(new(big.Int).Add(start, size), big.NewInt(1))}
+	resizedEnd := bigtohexip(resized.End, ipv6)
+
+	if new(big.Int).Mod(start, size).Sign() != 0 {
+		return fmt.Errorf("SOLIDServer - Unable to resize IP subnet: %s, its address is not aligned on a /%d boundary\n", subnet["name"], prefixLength)
+	}
+
+	// Growing: checking the parent and the siblings
+	if resized.End.Cmp(currentEnd) > 0 {
+		parameters := url.Values{}
+
+		if subnet["parent_id"] != "" && subnet["parent_id"] != "0" {
+			parent, err := ipsubnetinfobyid(subnet["parent_id"], ipv6, meta)
+
+			if err != nil {
+				return err
+			}
+
+			if parentEnd := hexiptobig(parent["end_hex_addr"]); parentEnd != nil && resized.End.Cmp(parentEnd) > 0 {
+				return fmt.Errorf("SOLIDServer - Unable to resize IP subnet: %s, it would exceed its parent block: %s\n", subnet["name"], parent["name"])
+			}
+
+			parameters.Add("WHERE", parentField+"='"+subnet["parent_id"]+"' AND "+idField+"!='"+subnetID+"'")
+		} else {
+			parameters.Add("WHERE", "site_id='"+subnet["site_id"]+"' AND subnet_level='0' AND "+idField+"!='"+subnetID+"'")
+		}
+
+		siblings, err := solidserverlist(listService, parameters, 0, meta)
+
+		if err != nil {
+			return err
+		}
+
+		used := []ipRange{}
+
+		for _, sibling := range siblings {
+			attributes := restobjectattributes(sibling)
+
+			if ss, se := hexiptobig(attributes[startField]), hexiptobig(attributes[endField]); ss != nil && se != nil {
+				used = append(used, ipRange{Start: ss, End: se})
+			}
+		}
+
+		if overlap := iprangeoverlap(resized, used); overlap != nil {
+			return fmt.Errorf("SOLIDServer - Unable to resize IP subnet: %s, the range %s is not free\n", subnet["name"], iprangestring(*overlap, ipv6))
+		}
+	}
+
+	// Shrinking: checking the objects beyond the new boundaries
+	if resized.End.Cmp(currentEnd) < 0 {
+		checks := []struct {
+			service string
+			where   string
+			kind    string
+		}{
+			{addressService, idField + "='" + subnetID + "' AND " + addrField + ">'" + resizedEnd + "'", "address"},
+			{poolService, idField + "='" + subnetID + "' AND " + endField + ">'" + resizedEnd + "'", "pool"},
+			{listService, parentField + "='" + subnetID + "' AND " + endField + ">'" + resizedEnd + "'", "subnet"},
+		}
+
+		for _, check := range checks {
+			parameters := url.Values{}
+			parameters.Add("WHERE", check.where)
+
+			objects, err := solidserverlist(check.service, parameters, 1, meta)
+
+			if err != nil {
+				return err
+			}
+
+			if len(objects) > 0 {
+				return fmt.Errorf("SOLIDServer - Unable to resize IP subnet: %s, at least one %s lies beyond the new boundaries\n", subnet["name"], check.kind)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Format an IPv4 or IPv6 range for messages
+func iprangestring(r ipRange, ipv6 bool) string {
+	if ipv6 {
+		return hexip6toip6(bigtohexip(r.Start, true)) + "-" + hexip6toip6(bigtohexip(r.End, true))
+	}
+
+	return hexiptoip(bigtohexip(r.Start, false)) + "-" + hexiptoip(bigtohexip(r.End, false))
+}
+
+// Address allocation policy of IPv4 and IPv6 addresses
+type ipAddressPolicy struct {
+	Strategy  string
+	SkipFirst int
+	SkipLast  int
+	Exclude   []ipRange
+}
+
+// Build the address allocation policy from the resource attributes
+func ipaddresspolicyfromresource(d *schema.ResourceData, ipv6 bool) (ipAddressPolicy, error) {
+	policy := ipAddressPolicy{
+		Strategy:  d.Get("allocation_strategy").(string),
+		SkipFirst: d.Get("skip_first").(int),
+		SkipLast:  d.Get("skip_last").(int),
+	}
+
+	for _, exclude := range toStringArray(d.Get("exclude").([]interface{})) {
+		r, err := iprangeparse(exclude, ipv6)
+
+		if err != nil {
+			return policy, err
+		}
+
+		policy.Exclude = append(policy.Exclude, r)
+	}
+
+	return policy, nil
+}
+
+// Return true if the policy matches the native behavior of the find free address service
+func (p ipAddressPolicy) isdefault() bool {
+	return (p.Strategy == "" || p.Strategy == "lowest") && p.SkipFirst == 0 && p.SkipLast == 0 && len(p.Exclude) == 0
+}
+
+// Parse an IPv4 or IPv6 address, prefix (CIDR) or range (first-last)
+func iprangeparse(value string, ipv6 bool) (ipRange, error) {
+	var r netaddr.IPRange
+
+	if strings.Contains(value, "-") {
+		tmp, err := netaddr.ParseIPRange(value)
+
+		if err != nil {
+			return ipRange{}, fmt.Errorf("SOLIDServer - Unsupported IP range: %s\n", value)
+		}
+
+		r = tmp
+	} else if strings.Contains(value, "/") {
+		tmp, err := netaddr.ParseIPPrefix(value)
+
+		if err != nil {
+			return ipRange{}, fmt.Errorf("SOLIDServer - Unsupported IP prefix: %s\n", value)
+		}
+
+		r = tmp.Masked().Range()
+	} else {
+		tmp, err := netaddr.ParseIP(value)
+
+		if err != nil {
+			return ipRange{}, fmt.Errorf("SOLIDServer - Unsupported IP address: %s\n", value)
+		}
+
+		r = netaddr.IPRangeFrom(tmp, tmp)
+	}
+
+	if r.From().Is6() != ipv6 || r.To().Is6() != ipv6 {
+		return ipRange{}, fmt.Errorf("SOLIDServer - Unexpected IP family for: %s\n", value)
+	}
+
+	from, to := r.From().As16(), r.To().As16()
+
+	if !ipv6 {
+		return ipRange{Start: new(big.Int).SetBytes(from[12:]), End: new(big.Int).SetBytes(to[12:])}, nil
+	}
+
+	return ipRange{Start: new(big.Int).SetBytes(from[:]), End: new(big.Int).SetBytes(to[:])}, nil
+}
+
+// Validate an IPv4 or IPv6 address, prefix or range
+func validateiprange(ipv6 bool) schema.SchemaValidateFunc {
+	return func(i interface{}, k string) ([]string, []error) {
+		value, ok := i.(string)
+
+		if !ok {
+			return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+		}
+
+		if _, err := iprangeparse(value, ipv6); err != nil {
+			return nil, []error{fmt.Errorf("%s: %s", k, strings.TrimSpace(strings.TrimPrefix(err.Error(), "SOLIDServer - ")))}
+		}
+
+		return nil, nil
+	}
+}
+
+// Return up to max free addresses from the free ranges according to the strategy (lowest, highest or random)
+func ipaddresscandidates(free []ipRange, strategy string, max int) []*big.Int {
+	res := []*big.Int{}
+	one := big.NewInt(1)
+
+	switch strategy {
+	case "highest":
+		for i := len(free) - 1; i >= 0 && len(res) < max; i-- {
+			for address := free[i].End; address.Cmp(free[i].Start) >= 0 && len(res) < max; address = new(big.Int).Sub(address, one) {
+				res = append(res, address)
+			}
+		}
+	case "random":
+		total := big.NewInt(0)
+
+		for _, r := range free {
+			total.Add(total, new(big.Int).Add(new(big.Int).Sub(r.End, r.Start), one))
+		}
+
+		if total.Sign() == 0 {
+			return res
+		}
+
+		rng := rand.New(rand.NewSource(rand.Int63()))
+		seen := map[string]bool{}
+
+		for attempt := 0; len(res) < max && attempt < max*4; attempt++ {
+			offset := new(big.Int).Rand(rng, total)
+
+			for _, r := range free {
+				size := new(big.Int).Add(new(big.Int).Sub(r.End, r.Start), one)
+
+				if offset.Cmp(size) < 0 {
+					address := new(big.Int).Add(r.Start, offset)
+
+					if !seen[address.String()] {
+						seen[address.String()] = true
+						res = append(res, address)
+					}
+
+					break
+				}
+
+				offset.Sub(offset, size)
+			}
+		}
+	default:
+		for _, r := range free {
+			for address := r.Start; address.Cmp(r.End) <= 0 && len(res) < max; address = new(big.Int).Add(address, one) {
+				res = append(res, address)
+			}
+
+			if len(res) >= max {
+				break
+			}
+		}
+	}
+
+	return res
+}
+
+// Return the range of a pool from its ID
+func ippoolrangebyid(poolID string, ipv6 bool, meta interface{}) (ipRange, error) {
+	s := meta.(*SOLIDserver)
+	service, idField, startField, endField := "rest/ip_pool_info", "pool_id", "start_ip_addr", "end_ip_addr"
+
+	if ipv6 {
+		service, idField, startField, endField = "rest/ip6_pool6_info", "pool6_id", "start_ip6_addr", "end_ip6_addr"
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add(idField, poolID)
+
+	// Sending the read request
+	resp, body, err := s.Request("get", service, &parameters)
+
+	if err != nil {
+		return ipRange{}, err
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	// Checking the answer
+	if resp.StatusCode == 200 && len(buf) > 0 {
+		attributes := restobjectattributes(buf[0])
+
+		if start, end := hexiptobig(attributes[startField]), hexiptobig(attributes[endField]); start != nil && end != nil {
+			return ipRange{Start: start, End: end}, nil
+		}
+	}
+
+	return ipRange{}, fmt.Errorf("SOLIDServer - Unable to find IP pool (oid): %s\n", poolID)
+}
+
+// Return the free ranges of a subnet (and optionally a pool) according to an allocation policy
+// The free ranges are computed from the addresses already in use, the skipped offsets and the excluded ranges
+func ipaddressfreeranges(subnetID string, poolID string, policy ipAddressPolicy, ipv6 bool, meta interface{}) ([]ipRange, error) {
+	one := big.NewInt(1)
+
+	subnet, err := ipsubnetinfobyid(subnetID, ipv6, meta)
+
+	if err != nil {
+		return []ipRange{}, err
+	}
+
+	start, end := hexiptobig(subnet["start_hex_addr"]), hexiptobig(subnet["end_hex_addr"])
+
+	if start == nil || end == nil {
+		return []ipRange{}, fmt.Errorf("SOLIDServer - Unable to compute the boundaries of IP subnet: %s\n", subnet["name"])
+	}
+
+	// The network and broadcast addresses of IPv4 subnets are never allocated
+	if !ipv6 && new(big.Int).Sub(end, start).Cmp(big.NewInt(2)) > 0 {
+		start = new(big.Int).Add(start, one)
+		end = new(big.Int).Sub(end, one)
+	}
+
+	if len(poolID) > 0 {
+		pool, poolErr := ippoolrangebyid(poolID, ipv6, meta)
+
+		if poolErr != nil {
+			return []ipRange{}, poolErr
+		}
+
+		if pool.Start.Cmp(start) > 0 {
+			start = pool.Start
+		}
+
+		if pool.End.Cmp(end) < 0 {
+			end = pool.End
+		}
+	}
+
+	start = new(big.Int).Add(start, big.NewInt(int64(policy.SkipFirst)))
+	end = new(big.Int).Sub(end, big.NewInt(int64(policy.SkipLast)))
+
+	if start.Cmp(end) > 0 {
+		return []ipRange{}, fmt.Errorf("SOLIDServer - No address left in IP subnet: %s once the skipped offsets are applied\n", subnet["name"])
+	}
+
+	// Retrieving the addresses in use
+	service, addrField, idField := "ip_address_list", "ip_addr", "subnet_id"
+
+	if ipv6 {
+		service, addrField, idField = "ip6_address6_list", "ip6_addr", "subnet6_id"
+	}
+
+	parameters := url.Values{}
+	parameters.Add("WHERE", idField+"='"+subnetID+"' AND "+addrField+">='"+bigtohexip(start, ipv6)+"' AND "+addrField+"<='"+bigtohexip(end, ipv6)+"'")
+
+	addresses, err := solidserverlist(service, parameters, 0, meta)
+
+	if err != nil {
+		return []ipRange{}, err
+	}
+
+	used := append([]ipRange{}, policy.Exclude...)
+
+	for _, address := range addresses {
+		attributes := restobjectattributes(address)
+
+		if attributes["type"] == "free" {
+			continue
+		}
+
+		if addr := hexiptobig(attributes[addrField]); addr != nil {
+			used = append(used, ipRange{Start: addr, End: addr})
+		}
+	}
+
+	return ipfreeranges(start, end, used), nil
+}
+
+// Return available IPv4 or IPv6 addresses of a subnet (and optionally a pool) according to an allocation policy
+func ipaddressfindfreebypolicy(subnetID string, poolID string, policy ipAddressPolicy, ipv6 bool, meta interface{}) ([]string, error) {
+	s := meta.(*SOLIDserver)
+
+	free, err := ipaddressfreeranges(subnetID, poolID, policy, ipv6, meta)
+
+	if err != nil {
+		return []string{}, err
+	}
+
+	res := []string{}
+
+	for _, candidate := range ipaddresscandidates(free, policy.Strategy, 32) {
+		if ipv6 {
+			res = append(res, hexip6toip6(bigtohexip(candidate, true)))
+		} else {
+			res = append(res, hexiptoip(bigtohexip(candidate, false)))
+		}
+
+		tflog.Debug(s.Ctx, fmt.Sprintf("Suggested IP address: %s\n", res[len(res)-1]))
+	}
+
+	if len(res) == 0 {
+		return res, fmt.Errorf("SOLIDServer - Unable to find a free IP address in subnet (oid): %s matching the allocation policy\n", subnetID)
+	}
+
+	return res, nil
+}
+
+// Return the first address of a run of count contiguous free addresses
+// When requested is set, the run must start at the requested address
+// Or nil in case of failure
+func ipcontiguousrun(free []ipRange, count int, requested *big.Int) *big.Int {
+	size := big.NewInt(int64(count))
+
+	for _, r := range free {
+		start := r.Start
+
+		if requested != nil {
+			if requested.Cmp(r.Start) < 0 || requested.Cmp(r.End) > 0 {
+				continue
+			}
+
+			start = requested
+		}
+
+		if new(big.Int).Add(start, size).Cmp(new(big.Int).Add(r.End, big.NewInt(1))) <= 0 {
+			return new(big.Int).Set(start)
+		}
+
+		if requested != nil {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// Format an IPv4 or IPv6 counter according to the data-source schema
+func ipcounter(value *big.Int, ipv6 bool) interface{} {
+	if ipv6 {
+		return value.String()
+	}
+
+	return int(value.Int64())
+}
+
+// Format an IPv4 or IPv6 address from a Big Integer
+func ipfrombig(value *big.Int, ipv6 bool) string {
+	if ipv6 {
+		return hexip6toip6(bigtohexip(value, true))
+	}
+
+	return hexiptoip(bigtohexip(value, false))
+}
+
+// Return the largest aligned prefix within the free ranges along with its prefix length
+// Or nil if there is no free range
+func iplargestfreeprefix(free []ipRange, ipv6 bool) (*big.Int, int) {
+	var res *big.Int = nil
+	resSize := big.NewInt(0)
+	bits := 32
+
+	if ipv6 {
+		bits = 128
+	}
+
+	one := big.NewInt(1)
+
+	for _, r := range free {
+		cursor := new(big.Int).Set(r.Start)
+
+		for cursor.Cmp(r.End) <= 0 {
+			// The largest block aligned on the cursor
+			size := new(big.Int).Lsh(one, uint(bits))
+
+			if cursor.Sign() != 0 {
+				size = new(big.Int).Lsh(one, cursor.TrailingZeroBits())
+			}
+
+			// Shrinking it to fit within the range
+			for new(big.Int).Add(cursor, size).Cmp(new(big.Int).Add(r.End, one)) > 0 {
+				size.Rsh(size, 1)
+			}
+
+			if size.Cmp(resSize) > 0 {
+				res, resSize = new(big.Int).Set(cursor), size
+			}
+
+			cursor.Add(cursor, size)
+		}
+	}
+
+	if res == nil {
+		return nil, 0
+	}
+
+	return res, bits - (resSize.BitLen() - 1)
+}
+
+// Return the prefix length matching a number of addresses
+func bitsprefixlength(size *big.Int, ipv6 bool) int {
+	if ipv6 {
+		return 128 - (size.BitLen() - 1)
+	}
+
+	return 32 - (size.BitLen() - 1)
+}
+
+// Return the information of the candidate blocks into which looking for a free IPv4 or IPv6 subnet
+// from the block, blocks or block_query attributes, or a single block with an empty ID when none is set
+func ipsubnetcandidateblocks(siteID string, d *schema.ResourceData, ipv6 bool, meta interface{}) ([]map[string]interface{}, error) {
+	blocks := []map[string]interface{}{}
+	names := []string{}
+
+	if len(d.Get("block").(string)) > 0 {
+		names = append(names, d.Get("block").(string))
+	} else if len(d.Get("blocks").([]interface{})) > 0 {
+		names = toStringArray(d.Get("blocks").([]interface{}))
+	} else if len(d.Get("block_query").(string)) > 0 {
+		return ipsubnetblocksbyquery(siteID, d.Get("block_query").(string), ipv6, meta)
+	} else {
+		return append(blocks, map[string]interface{}{"id": ""}), nil
+	}
+
+	for _, name := range names {
+		var blockInfo map[string]interface{} = nil
+		var blockErr error = nil
+
+		if ipv6 {
+			blockInfo, blockErr = ip6subnetinfobyname(siteID, name, false, meta)
+		} else {
+			blockInfo, blockErr = ipsubnetinfobyname(siteID, name, false, meta)
+		}
+
+		if blockErr != nil {
+			return nil, blockErr
+		}
+
+		blocks = append(blocks, blockInfo)
+	}
+
+	return blocks, nil
+}
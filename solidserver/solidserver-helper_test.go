@@ -0,0 +1,520 @@
+package solidserver
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestIpamFilterParameters(t *testing.T) {
+
+	type testCase struct {
+		Filters map[string]interface{}
+		Where   string
+		Tags    string
+	}
+
+	testCases := map[string]testCase{
+		"no_filter": {
+			Filters: map[string]interface{}{},
+		},
+		"space_and_parent": {
+			Filters: map[string]interface{}{"space": "prod", "block": "block-a"},
+			Where:   "site_name='prod' AND parent_subnet_name='block-a'",
+		},
+		"name_glob": {
+			Filters: map[string]interface{}{"name": "web-*-0?"},
+			Where:   "subnet_name LIKE 'web-%-0_'",
+		},
+		"name_literal_wildcards": {
+			Filters: map[string]interface{}{"name": "web_01-100%"},
+			Where:   "subnet_name LIKE 'web\\_01-100\\%'",
+		},
+		"class_parameters": {
+			Filters: map[string]interface{}{"class": "VPC", "class_parameters": map[string]interface{}{"vnid": "12", "env": "o'neil"}},
+			Where:   "subnet_class_name='VPC' AND tag_network_env='o''neil' AND tag_network_vnid='12'",
+			Tags:    "network.env,network.vnid",
+		},
+		"class_parameters_invalid_name": {
+			Filters: map[string]interface{}{"class_parameters": map[string]interface{}{"vnid": "12", "x='1' OR tag_network_y": "1"}},
+			Where:   "tag_network_vnid='12'",
+			Tags:    "network.vnid",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, dataSourceipsubnets().Schema, tc.Filters)
+			result := ipamfilterparameters(d, "block", "parent_subnet_name", "subnet_name", "subnet_class_name", "network")
+
+			if result.Get("WHERE") != tc.Where {
+				t.Errorf("unexpected WHERE: %q (expected: %q)", result.Get("WHERE"), tc.Where)
+			}
+
+			if result.Get("TAGS") != tc.Tags {
+				t.Errorf("unexpected TAGS: %q (expected: %q)", result.Get("TAGS"), tc.Tags)
+			}
+		})
+	}
+}
+
+func TestAddressLookupParameters(t *testing.T) {
+
+	type testCase struct {
+		Filters map[string]interface{}
+		IPv6    bool
+		Where   string
+	}
+
+	testCases := map[string]testCase{
+		"mac": {
+			Filters: map[string]interface{}{"mac": "00-50-56-AA-BB-CC"},
+			Where:   "mac_addr LIKE '%00:50:56:aa:bb:cc'",
+		},
+		"mac_ipv6": {
+			Filters: map[string]interface{}{"mac": "00:50:56:aa:bb:cc"},
+			IPv6:    true,
+			Where:   "ip6_mac_addr LIKE '%00:50:56:aa:bb:cc'",
+		},
+		"space_name_device": {
+			Filters: map[string]interface{}{"space": "prod", "name": "web-*", "device": "srv01"},
+			Where:   "site_name='prod' AND name LIKE 'web-%' AND hostdev_name='srv01'",
+		},
+		"device_quoted": {
+			Filters: map[string]interface{}{"device": "o'neil"},
+			Where:   "hostdev_name='o''neil'",
+		},
+		"class_parameters_ipv6": {
+			Filters: map[string]interface{}{"class_parameters": map[string]interface{}{"env": "prod"}},
+			IPv6:    true,
+			Where:   "tag_ip6_env='prod'",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, dataSourceaddresslookup().Schema, tc.Filters)
+			result := dataSourceaddresslookupparameters(d, tc.IPv6)
+
+			if result.Get("WHERE") != tc.Where {
+				t.Errorf("unexpected WHERE: %q (expected: %q)", result.Get("WHERE"), tc.Where)
+			}
+		})
+	}
+}
+
+func TestIpSubnetCandidates(t *testing.T) {
+	block := map[string]interface{}{"id": "3"}
+
+	// 10.0.0.0/24 with 10.0.0.0/26 and 10.0.0.128/27 already used
+	holes := []ipHole{}
+	used := []ipRange{
+		{Start: hexiptobig("0a000000"), End: hexiptobig("0a00003f")},
+		{Start: hexiptobig("0a000080"), End: hexiptobig("0a00009f")},
+	}
+
+	for _, r := range ipfreeranges(hexiptobig("0a000000"), hexiptobig("0a0000ff"), used) {
+		holes = append(holes, ipHole{Block: block, Range: r})
+	}
+
+	if len(holes) != 2 {
+		t.Fatalf("expected 2 free ranges, got %d", len(holes))
+	}
+
+	type testCase struct {
+		Prefix   int
+		Strategy string
+		Expected []string
+	}
+
+	testCases := map[string]testCase{
+		"first_fit": {
+			Prefix:   27,
+			Strategy: "first-fit",
+			Expected: []string{"10.0.0.64", "10.0.0.96", "10.0.0.160", "10.0.0.192", "10.0.0.224"},
+		},
+		"last_fit": {
+			Prefix:   27,
+			Strategy: "last-fit",
+			Expected: []string{"10.0.0.224", "10.0.0.192", "10.0.0.160", "10.0.0.96", "10.0.0.64"},
+		},
+		"best_fit": {
+			Prefix:   27,
+			Strategy: "best-fit",
+			Expected: []string{"10.0.0.64", "10.0.0.96", "10.0.0.160", "10.0.0.192", "10.0.0.224"},
+		},
+		"best_fit_larger": {
+			Prefix:   26,
+			Strategy: "best-fit",
+			Expected: []string{"10.0.0.64", "10.0.0.192"},
+		},
+		"no_fit": {
+			Prefix:   25,
+			Strategy: "first-fit",
+			Expected: []string{},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			candidates := ipsubnetcandidates(holes, prefixlengthtobigsize(tc.Prefix, false), false, tc.Strategy, 16)
+			result := []string{}
+
+			for _, c := range candidates {
+				result = append(result, hexiptoip(c.Address))
+			}
+
+			if strings.Join(result, ",") != strings.Join(tc.Expected, ",") {
+				t.Errorf("expected %v, got %v", tc.Expected, result)
+			}
+		})
+	}
+
+	t.Run("random", func(t *testing.T) {
+		candidates := ipsubnetcandidates(holes, prefixlengthtobigsize(27, false), false, "random", 3)
+
+		if len(candidates) == 0 || len(candidates) > 3 {
+			t.Fatalf("expected between 1 and 3 candidates, got %d", len(candidates))
+		}
+
+		for _, c := range candidates {
+			address := hexiptobig(c.Address)
+
+			if new(big.Int).Mod(address, big.NewInt(32)).Sign() != 0 || (address.Cmp(hexiptobig("0a000040")) < 0) || (address.Cmp(hexiptobig("0a000080")) >= 0 && address.Cmp(hexiptobig("0a0000a0")) < 0) {
+				t.Errorf("unexpected random candidate: %s", hexiptoip(c.Address))
+			}
+		}
+	})
+
+	t.Run("ipv6", func(t *testing.T) {
+		start := hexiptobig("20010db8000000000000000000000000")
+		end := hexiptobig("20010db80000ffffffffffffffffffff")
+		used := []ipRange{{Start: start, End: hexiptobig("20010db80000000fffffffffffffffff")}}
+		holes := []ipHole{}
+
+		for _, r := range ipfreeranges(start, end, used) {
+			holes = append(holes, ipHole{Block: block, Range: r})
+		}
+
+		candidates := ipsubnetcandidates(holes, prefixlengthtobigsize(64, true), true, "last-fit", 1)
+
+		if len(candidates) != 1 || hexip6toip6(candidates[0].Address) != "2001:0db8:0000:ffff:0000:0000:0000:0000" {
+			t.Errorf("unexpected IPv6 candidates: %v", candidates)
+		}
+	})
+}
+
+func TestIpMergedRange(t *testing.T) {
+	type testCase struct {
+		Ranges   [][2]string
+		Expected string
+		Error    bool
+	}
+
+	testCases := map[string]testCase{
+		"two_halves": {
+			Ranges:   [][2]string{{"0a000080", "0a0000ff"}, {"0a000000", "0a00007f"}},
+			Expected: "10.0.0.0-10.0.0.255",
+		},
+		"four_quarters": {
+			Ranges:   [][2]string{{"0a000000", "0a00003f"}, {"0a000040", "0a00007f"}, {"0a000080", "0a0000bf"}, {"0a0000c0", "0a0000ff"}},
+			Expected: "10.0.0.0-10.0.0.255",
+		},
+		"not_contiguous": {
+			Ranges: [][2]string{{"0a000000", "0a00003f"}, {"0a000080", "0a0000bf"}},
+			Error:  true,
+		},
+		"not_power_of_two": {
+			Ranges: [][2]string{{"0a000000", "0a00007f"}, {"0a000080", "0a0000bf"}},
+			Error:  true,
+		},
+		"not_aligned": {
+			Ranges: [][2]string{{"0a000040", "0a00007f"}, {"0a000080", "0a0000bf"}},
+			Error:  true,
+		},
+		"single": {
+			Ranges: [][2]string{{"0a000000", "0a0000ff"}},
+			Error:  true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ranges := []ipRange{}
+
+			for _, r := range tc.Ranges {
+				ranges = append(ranges, ipRange{Start: hexiptobig(r[0]), End: hexiptobig(r[1])})
+			}
+
+			result, err := ipmergedrange(ranges)
+
+			if tc.Error {
+				if err == nil {
+					t.Errorf("expected an error, got %s", iprangestring(result, false))
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if s := iprangestring(result, false); s != tc.Expected {
+				t.Errorf("expected %s, got %s", tc.Expected, s)
+			}
+		})
+	}
+}
+
+func TestIpSubnetSplitCheck(t *testing.T) {
+	if err := ipsubnetsplitcheck(24, 26, 4); err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	if err := ipsubnetsplitcheck(24, 24, 0); err == nil {
+		t.Errorf("expected an error when the prefix length is not greater")
+	}
+
+	if err := ipsubnetsplitcheck(24, 26, 5); err == nil {
+		t.Errorf("expected an error when too many names are provided")
+	}
+
+	if err := ipsubnetsplitcheck(16, 25, 0); err == nil {
+		t.Errorf("expected an error when splitting into more than 256 subnets")
+	}
+}
+
+func TestIpAddressCandidates(t *testing.T) {
+	// 10.0.0.1-10.0.0.254 with 10.0.0.1-10.0.0.9 skipped and 10.0.0.100-10.0.0.200 excluded
+	free := ipfreeranges(hexiptobig("0a000001"), hexiptobig("0a0000fe"), []ipRange{
+		{Start: hexiptobig("0a000001"), End: hexiptobig("0a000009")},
+		{Start: hexiptobig("0a000064"), End: hexiptobig("0a0000c8")},
+	})
+
+	lowest := ipaddresscandidates(free, "lowest", 3)
+
+	if len(lowest) != 3 || hexiptoip(bigtohexip(lowest[0], false)) != "10.0.0.10" || hexiptoip(bigtohexip(lowest[2], false)) != "10.0.0.12" {
+		t.Errorf("unexpected lowest candidates: %v", lowest)
+	}
+
+	highest := ipaddresscandidates(free, "highest", 2)
+
+	if len(highest) != 2 || hexiptoip(bigtohexip(highest[0], false)) != "10.0.0.254" || hexiptoip(bigtohexip(highest[1], false)) != "10.0.0.253" {
+		t.Errorf("unexpected highest candidates: %v", highest)
+	}
+
+	random := ipaddresscandidates(free, "random", 16)
+	seen := map[string]bool{}
+
+	for _, address := range random {
+		if iprangeoverlap(ipRange{Start: address, End: address}, free) == nil {
+			t.Errorf("random candidate %s is not free", hexiptoip(bigtohexip(address, false)))
+		}
+
+		if seen[address.String()] {
+			t.Errorf("random candidate %s returned twice", hexiptoip(bigtohexip(address, false)))
+		}
+
+		seen[address.String()] = true
+	}
+}
+
+func TestIpRangeParse(t *testing.T) {
+	type testCase struct {
+		Value    string
+		IPv6     bool
+		Expected string
+		Error    bool
+	}
+
+	testCases := map[string]testCase{
+		"address":      {Value: "10.0.0.1", Expected: "10.0.0.1-10.0.0.1"},
+		"prefix":       {Value: "10.0.0.0/30", Expected: "10.0.0.0-10.0.0.3"},
+		"range":        {Value: "10.0.0.10-10.0.0.20", Expected: "10.0.0.10-10.0.0.20"},
+		"ipv6_range":   {Value: "2001:db8::10-2001:db8::1f", IPv6: true, Expected: "2001:0db8:0000:0000:0000:0000:0000:0010-2001:0db8:0000:0000:0000:0000:0000:001f"},
+		"wrong_family": {Value: "2001:db8::1", Error: true},
+		"invalid":      {Value: "10.0.0.300", Error: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result, err := iprangeparse(tc.Value, tc.IPv6)
+
+			if tc.Error {
+				if err == nil {
+					t.Errorf("expected an error, got %s", iprangestring(result, tc.IPv6))
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if s := iprangestring(result, tc.IPv6); s != tc.Expected {
+				t.Errorf("expected %s, got %s", tc.Expected, s)
+			}
+		})
+	}
+}
+
+func TestIpContiguousRun(t *testing.T) {
+	// 10.0.0.10-10.0.0.12 and 10.0.0.20-10.0.0.29 are free
+	free := []ipRange{
+		{Start: hexiptobig("0a00000a"), End: hexiptobig("0a00000c")},
+		{Start: hexiptobig("0a000014"), End: hexiptobig("0a00001d")},
+	}
+
+	type testCase struct {
+		Count     int
+		Requested string
+		Expected  string
+	}
+
+	testCases := map[string]testCase{
+		"first_range":       {Count: 3, Expected: "10.0.0.10"},
+		"second_range":      {Count: 4, Expected: "10.0.0.20"},
+		"too_large":         {Count: 11},
+		"requested":         {Count: 5, Requested: "0a000019", Expected: "10.0.0.25"},
+		"requested_overrun": {Count: 6, Requested: "0a000019"},
+		"requested_used":    {Count: 1, Requested: "0a00000d"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var requested *big.Int = nil
+
+			if tc.Requested != "" {
+				requested = hexiptobig(tc.Requested)
+			}
+
+			result := ipcontiguousrun(free, tc.Count, requested)
+
+			if tc.Expected == "" {
+				if result != nil {
+					t.Errorf("expected no run, got %s", hexiptoip(bigtohexip(result, false)))
+				}
+				return
+			}
+
+			if result == nil || hexiptoip(bigtohexip(result, false)) != tc.Expected {
+				t.Errorf("expected %s, got %v", tc.Expected, result)
+			}
+		})
+	}
+}
+
+func TestIpLargestFreePrefix(t *testing.T) {
+	type testCase struct {
+		Ranges   [][2]string
+		Expected string
+	}
+
+	testCases := map[string]testCase{
+		"aligned": {
+			Ranges:   [][2]string{{"0a000000", "0a0000ff"}},
+			Expected: "10.0.0.0/24",
+		},
+		"unaligned": {
+			// 10.0.0.64-10.0.1.127 contains 10.0.0.128/25 and 10.0.1.0/25
+			Ranges:   [][2]string{{"0a000040", "0a00017f"}},
+			Expected: "10.0.0.128/25",
+		},
+		"several": {
+			Ranges:   [][2]string{{"0a000004", "0a000007"}, {"0a000010", "0a00001f"}},
+			Expected: "10.0.0.16/28",
+		},
+		"none": {
+			Ranges:   [][2]string{},
+			Expected: "",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			ranges := []ipRange{}
+
+			for _, r := range tc.Ranges {
+				ranges = append(ranges, ipRange{Start: hexiptobig(r[0]), End: hexiptobig(r[1])})
+			}
+
+			result := ""
+
+			if address, length := iplargestfreeprefix(ranges, false); address != nil {
+				result = ipfrombig(address, false) + "/" + strconv.Itoa(length)
+			}
+
+			if result != tc.Expected {
+				t.Errorf("expected %q, got %q", tc.Expected, result)
+			}
+		})
+	}
+}
+
+func TestIpSubnetVlsmCheck(t *testing.T) {
+	type testCase struct {
+		RequestedIP string
+		PrefixSize  int
+		Error       bool
+	}
+
+	parentInfo := map[string]interface{}{
+		"name":          "tenant-a",
+		"start_addr":    "10.1.0.0",
+		"prefix_length": 24,
+	}
+
+	testCases := map[string]testCase{
+		"mirrored": {
+			PrefixSize: 24,
+		},
+		"requested_ip": {
+			RequestedIP: "10.1.0.0",
+			PrefixSize:  24,
+		},
+		"other_ip": {
+			RequestedIP: "10.2.0.0",
+			PrefixSize:  24,
+			Error:       true,
+		},
+		"other_prefix_size": {
+			PrefixSize: 25,
+			Error:      true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if err := ipsubnetvlsmcheck(parentInfo, tc.RequestedIP, tc.PrefixSize); (err != nil) != tc.Error {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestIpSubnetVlsmParentName(t *testing.T) {
+	type testCase struct {
+		Entry    map[string]interface{}
+		Expected string
+	}
+
+	testCases := map[string]testCase{
+		"missing":   {Entry: map[string]interface{}{"subnet_name": "block"}, Expected: ""},
+		"zero":      {Entry: map[string]interface{}{"vlsm_subnet_id": "0"}, Expected: ""},
+		"unset":     {Entry: map[string]interface{}{"vlsm_subnet_id": "#"}, Expected: ""},
+		"delegated": {Entry: map[string]interface{}{"vlsm_subnet_id": "12", "vlsm_subnet_name": "vlsm-eu"}, Expected: "vlsm-eu"},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			parent, err := ipsubnetvlsmparentname(tc.Entry, nil)
+
+			if err != nil || parent != tc.Expected {
+				t.Errorf("unexpected parent subnet: %q (expected: %q, error: %v)", parent, tc.Expected, err)
+			}
+		})
+	}
+}
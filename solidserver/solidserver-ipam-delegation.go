@@ -0,0 +1,194 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"math/big"
+	"net/url"
+	"strconv"
+)
+
+// Class parameter of the IPv6 blocks acting as prefix delegation pools, holding the delegated prefix length
+const ip6DelegatedPrefixSizeParameter = "delegated_prefix_size"
+
+// Register an IPv6 subnet of the given prefix length within a block
+// Return the oid of the subnet and its network address
+func ip6subnetallocate(ctx context.Context, siteID string, block map[string]interface{}, requestedIP string, prefixSize int, name string, class string, classParameters url.Values, terminal bool, meta interface{}) (string, string, error) {
+	s := meta.(*SOLIDserver)
+
+	// Serializing the allocations within the block
+	unlock := s.Allocations.lock("ip6_block:" + block["id"].(string))
+	defer unlock()
+
+	candidates, err := ipsubnetallocationcandidates(siteID, []map[string]interface{}{block}, requestedIP, prefixSize, "first-fit", true, meta)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	for _, candidate := range candidates {
+		prefix := hexip6toip6(candidate.Address) + "/" + strconv.Itoa(prefixSize)
+
+		// Skipping the prefixes already allocated by parallel creations
+		if s.Allocations.isclaimed("ip6_space:"+siteID, candidate.Address+"/"+strconv.Itoa(prefixSize)) {
+			continue
+		}
+
+		// Building parameters
+		parameters := url.Values{}
+		parameters.Add("site_id", siteID)
+		parameters.Add("add_flag", "new_only")
+		parameters.Add("subnet6_name", name)
+		parameters.Add("subnet6_addr", hexip6toip6(candidate.Address))
+		parameters.Add("subnet6_prefix", strconv.Itoa(prefixSize))
+		parameters.Add("subnet6_class_name", class)
+		parameters.Add("use_reversed_relative_position", "1")
+		parameters.Add("relative_position", "0")
+		parameters.Add("subnet6_class_parameters", classParameters.Encode())
+
+		if terminal {
+			parameters.Add("is_terminal", "1")
+		} else {
+			parameters.Add("is_terminal", "0")
+		}
+
+		// Sending the creation request
+		resp, body, err := s.Request("post", "rest/ip6_subnet6_add", &parameters)
+
+		if err != nil {
+			return "", "", err
+		}
+
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
+				tflog.Debug(ctx, fmt.Sprintf("Created IPv6 subnet (oid): %s with prefix: %s\n", oid, prefix))
+				s.Allocations.claim("ip6_space:"+siteID, candidate.Address+"/"+strconv.Itoa(prefixSize))
+				return oid, hexip6toip6(candidate.Address), nil
+			}
+		}
+
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				tflog.Debug(ctx, fmt.Sprintf("Failed IP subnet registration for IPv6 subnet: %s with prefix: %s (%s)\n", name, prefix, errMsg))
+				continue
+			}
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Failed IP subnet registration for IPv6 subnet: %s with prefix: %s\n", name, prefix))
+	}
+
+	return "", "", fmt.Errorf("SOLIDServer - Unable to create IPv6 subnet: %s, unable to find a suitable prefix\n", name)
+}
+
+// Update the name, class and class parameters of an IPv6 subnet
+func ip6subnetedit(subnetID string, name string, class string, classParameters url.Values, meta interface{}) error {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("subnet6_id", subnetID)
+	parameters.Add("add_flag", "edit_only")
+	parameters.Add("subnet6_name", name)
+	parameters.Add("subnet6_class_name", class)
+	parameters.Add("subnet6_class_parameters", classParameters.Encode())
+
+	// Sending the update request
+	resp, body, err := s.Request("put", "rest/ip6_subnet6_add", &parameters)
+
+	if err != nil {
+		return err
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	// Checking the answer
+	if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+		if _, oidExist := buf[0]["ret_oid"].(string); oidExist {
+			tflog.Debug(s.Ctx, fmt.Sprintf("Updated IPv6 subnet (oid): %s\n", subnetID))
+			return nil
+		}
+	}
+
+	if len(buf) > 0 {
+		if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+			return fmt.Errorf("SOLIDServer - Unable to update IPv6 subnet: %s (%s)\n", name, errMsg)
+		}
+	}
+
+	return fmt.Errorf("SOLIDServer - Unable to update IPv6 subnet: %s\n", name)
+}
+
+// Delete an IPv6 subnet from its oid
+func ip6subnetdeletebyid(subnetID string, meta interface{}) error {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("subnet6_id", subnetID)
+
+	// Sending the deletion request
+	resp, body, err := s.Request("delete", "rest/ip6_subnet6_delete", &parameters)
+
+	if err != nil {
+		return err
+	}
+
+	// Checking the answer
+	if resp.StatusCode != 200 && resp.StatusCode != 204 {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return fmt.Errorf("SOLIDServer - Unable to delete IPv6 subnet (oid): %s (%s)\n", subnetID, errMsg)
+			}
+		}
+
+		return fmt.Errorf("SOLIDServer - Unable to delete IPv6 subnet (oid): %s\n", subnetID)
+	}
+
+	tflog.Debug(s.Ctx, fmt.Sprintf("Deleted IPv6 subnet (oid): %s\n", subnetID))
+
+	return nil
+}
+
+// Return the number of prefixes of a delegated length within a delegation pool (decimal string)
+func ip6delegationcapacity(prefixSize int, delegatedPrefixSize int) string {
+	if delegatedPrefixSize < prefixSize {
+		return "0"
+	}
+
+	return new(big.Int).Lsh(big.NewInt(1), uint(delegatedPrefixSize-prefixSize)).String()
+}
+
+// Return the information of an IPv6 prefix delegation pool from its space and name
+// The delegated prefix length is stored within the class parameters of the pool
+func ip6delegationpoolinfobyname(siteID string, poolName string, meta interface{}) (map[string]interface{}, int, error) {
+	poolInfo, err := ip6subnetinfobyname(siteID, poolName, false, meta)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	details, err := ipsubnetinfobyid(poolInfo["id"].(string), true, meta)
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	classParameters, _ := url.ParseQuery(details["class_parameters"])
+	delegatedPrefixSize, convErr := strconv.Atoi(classParameters.Get(ip6DelegatedPrefixSizeParameter))
+
+	if convErr != nil || delegatedPrefixSize < 1 || delegatedPrefixSize > 128 {
+		return nil, 0, fmt.Errorf("SOLIDServer - IPv6 block: %s is not a prefix delegation pool\n", poolName)
+	}
+
+	return poolInfo, delegatedPrefixSize, nil
+}
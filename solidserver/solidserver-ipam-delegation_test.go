@@ -0,0 +1,45 @@
+package solidserver
+
+import (
+	"testing"
+)
+
+func TestIp6DelegationCapacity(t *testing.T) {
+
+	type testCase struct {
+		PrefixSize          int
+		DelegatedPrefixSize int
+		Capacity            string
+	}
+
+	testCases := map[string]testCase{
+		"48_to_56": {
+			PrefixSize:          48,
+			DelegatedPrefixSize: 56,
+			Capacity:            "256",
+		},
+		"44_to_60": {
+			PrefixSize:          44,
+			DelegatedPrefixSize: 60,
+			Capacity:            "65536",
+		},
+		"32_to_128": {
+			PrefixSize:          32,
+			DelegatedPrefixSize: 128,
+			Capacity:            "79228162514264337593543950336",
+		},
+		"shorter_delegation": {
+			PrefixSize:          56,
+			DelegatedPrefixSize: 48,
+			Capacity:            "0",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if capacity := ip6delegationcapacity(tc.PrefixSize, tc.DelegatedPrefixSize); capacity != tc.Capacity {
+				t.Errorf("unexpected capacity: %s (expected: %s)", capacity, tc.Capacity)
+			}
+		})
+	}
+}
@@ -0,0 +1,414 @@
+package solidserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Return the DNS attributes shared by the IPv4 and IPv6 address resources
+func ipaddressdnsschema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"dns_zone": {
+			Type:        schema.TypeString,
+			Description: "The DNS zone into which registering the forward record (A/AAAA) of the IP address. Setting it enables the DNS records management.",
+			Optional:    true,
+			ForceNew:    false,
+			Default:     "",
+		},
+		"dns_name": {
+			Type:        schema.TypeString,
+			Description: "The short name or FQDN of the forward record, completed with the dns_zone when needed (Default: the name of the IP address).",
+			Optional:    true,
+			ForceNew:    false,
+			Default:     "",
+		},
+		"dns_view": {
+			Type:        schema.TypeString,
+			Description: "The DNS view of the dns_zone, required if the DNS server hosting it has views.",
+			Optional:    true,
+			ForceNew:    false,
+			Default:     "",
+		},
+		"create_ptr": {
+			Type:        schema.TypeBool,
+			Description: "Register the reverse record (PTR) of the IP address along with the forward record (Default: true).",
+			Optional:    true,
+			ForceNew:    false,
+			Default:     true,
+		},
+		"ttl": {
+			Type:        schema.TypeInt,
+			Description: "The DNS Time To Live of the forward and reverse records (Default: 3600).",
+			Optional:    true,
+			ForceNew:    false,
+			Default:     3600,
+		},
+		"dns_fqdn": {
+			Type:        schema.TypeString,
+			Description: "The FQDN of the forward record.",
+			Computed:    true,
+		},
+		"dns_rr_id": {
+			Type:        schema.TypeString,
+			Description: "The ID of the forward record.",
+			Computed:    true,
+		},
+		"dns_ptr_rr_id": {
+			Type:        schema.TypeString,
+			Description: "The ID of the reverse record.",
+			Computed:    true,
+		},
+	}
+}
+
+// Return true if the DNS records of an IP address must be maintained again
+func ipaddressdnshaschange(d *schema.ResourceData) bool {
+	return d.HasChanges("name", "address", "dns_zone", "dns_name", "dns_view", "create_ptr", "ttl")
+}
+
+// Compute the FQDN of the forward record of an IP address
+func ipaddressdnsfqdn(d *schema.ResourceData) string {
+	zone := strings.TrimSuffix(strings.ToLower(d.Get("dns_zone").(string)), ".")
+	name := strings.TrimSuffix(d.Get("dns_name").(string), ".")
+
+	if name == "" {
+		name = strings.TrimSuffix(d.Get("name").(string), ".")
+	}
+
+	if zone == "" || strings.HasSuffix(strings.ToLower(name), "."+zone) || strings.ToLower(name) == zone {
+		return name
+	}
+
+	return name + "." + zone
+}
+
+// Return the server name and view name hosting a DNS zone
+func dnszoneserverbyname(zoneName string, viewName string, meta interface{}) (string, string, error) {
+	// Building parameters
+	parameters := url.Values{}
+	whereClause := "dnszone_name='" + wherequote(strings.ToLower(zoneName)) + "'"
+
+	if len(viewName) > 0 {
+		whereClause += " AND dnsview_name='" + wherequote(strings.ToLower(viewName)) + "'"
+	}
+
+	parameters.Add("WHERE", whereClause)
+
+	zones, err := solidserverlist("dns_zone_list", parameters, 1, meta)
+
+	if err != nil {
+		return "", "", err
+	}
+
+	if len(zones) == 0 {
+		return "", "", fmt.Errorf("SOLIDServer - Unable to find DNS zone: %s\n", zoneName)
+	}
+
+	attributes := restobjectattributes(zones[0])
+	view := attributes["dnsview_name"]
+
+	if view == "#" {
+		view = ""
+	}
+
+	return attributes["dns_name"], view, nil
+}
+
+// Create a DNS record and return its oid
+func dnsrradd(serverName string, viewName string, zoneName string, rrName string, rrType string, values []string, ttl int, meta interface{}) (string, error) {
+	return dnsrrsave("", serverName, viewName, zoneName, rrName, rrType, values, ttl, meta)
+}
+
+// Update the values and TTL of a DNS record from its oid
+func dnsrrupdate(rrID string, serverName string, viewName string, zoneName string, rrName string, rrType string, values []string, ttl int, meta interface{}) error {
+	_, err := dnsrrsave(rrID, serverName, viewName, zoneName, rrName, rrType, values, ttl, meta)
+	return err
+}
+
+// Create a DNS record, or update it when its oid is provided
+// Return the oid of the record
+func dnsrrsave(rrID string, serverName string, viewName string, zoneName string, rrName string, rrType string, values []string, ttl int, meta interface{}) (string, error) {
+	s := meta.(*SOLIDserver)
+	method, action := "post", "create"
+
+	// Building parameters
+	parameters := url.Values{}
+
+	if len(rrID) > 0 {
+		method, action = "put", "update"
+		parameters.Add("rr_id", rrID)
+		parameters.Add("add_flag", "edit_only")
+	} else {
+		parameters.Add("add_flag", "new_only")
+	}
+
+	parameters.Add("dns_name", serverName)
+	parameters.Add("rr_name", rrName)
+	parameters.Add("rr_type", rrType)
+	parameters.Add("rr_ttl", strconv.Itoa(ttl))
+
+	for i, value := range values {
+		parameters.Add("value"+strconv.Itoa(i+1), value)
+	}
+
+	if len(viewName) > 0 {
+		parameters.Add("dnsview_name", viewName)
+	}
+
+	if len(zoneName) > 0 {
+		parameters.Add("dnszone_name", strings.ToLower(zoneName))
+	}
+
+	// Sending the request
+	resp, body, err := s.Request(method, "rest/dns_rr_add", &parameters)
+
+	if err != nil {
+		return "", err
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	// Checking the answer
+	if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+		if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
+			tflog.Debug(s.Ctx, fmt.Sprintf("Saved RR (oid): %s\n", oid))
+			return oid, nil
+		}
+	}
+
+	if len(buf) > 0 {
+		if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+			return "", fmt.Errorf("SOLIDServer - Unable to %s %s RR: %s (%s)\n", action, rrType, rrName, errMsg)
+		}
+	}
+
+	return "", fmt.Errorf("SOLIDServer - Unable to %s %s RR: %s\n", action, rrType, rrName)
+}
+
+// Delete a DNS record from its oid, a record already deleted is not an error
+func dnsrrdelete(rrID string, meta interface{}) error {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("rr_id", rrID)
+
+	// Sending the deletion request
+	resp, body, err := s.Request("delete", "rest/dns_rr_delete", &parameters)
+
+	if err != nil {
+		return err
+	}
+
+	// Checking the answer
+	if resp.StatusCode != 200 && resp.StatusCode != 204 && resp.StatusCode != 404 {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return fmt.Errorf("SOLIDServer - Unable to delete RR (oid): %s (%s)\n", rrID, errMsg)
+			}
+		}
+
+		return fmt.Errorf("SOLIDServer - Unable to delete RR (oid): %s\n", rrID)
+	}
+
+	tflog.Debug(s.Ctx, fmt.Sprintf("Deleted RR (oid): %s\n", rrID))
+
+	return nil
+}
+
+// Delete the DNS records of an IP address
+func ipaddressdnsclear(d *schema.ResourceData, meta interface{}) error {
+	for _, key := range []string{"dns_ptr_rr_id", "dns_rr_id"} {
+		if rrID := d.Get(key).(string); len(rrID) > 0 {
+			if err := dnsrrdelete(rrID, meta); err != nil {
+				return err
+			}
+
+			d.Set(key, "")
+		}
+	}
+
+	d.Set("dns_fqdn", "")
+
+	return nil
+}
+
+// Return the attributes of a DNS record from its oid, or nil if it does not exist anymore
+func dnsrrinfobyid(rrID string, meta interface{}) (map[string]string, error) {
+	s := meta.(*SOLIDserver)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("rr_id", rrID)
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/dns_rr_info", &parameters)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	// Checking the answer
+	if resp.StatusCode == 200 && len(buf) > 0 {
+		return restobjectattributes(buf[0]), nil
+	}
+
+	// The record does not exist anymore
+	if resp.StatusCode == 200 || resp.StatusCode == 204 || resp.StatusCode == 404 {
+		return nil, nil
+	}
+
+	if len(buf) > 0 {
+		if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+			return nil, fmt.Errorf("SOLIDServer - Unable to read RR (oid): %s (%s)\n", rrID, errMsg)
+		}
+	}
+
+	return nil, fmt.Errorf("SOLIDServer - Unable to read RR (oid): %s (%d)\n", rrID, resp.StatusCode)
+}
+
+// Update a DNS record in place when it still exists, or replace it otherwise
+// A record changing of name is replaced unless it can be renamed in place (ex: within the same zone)
+// Return the oid of the record
+func dnsrrreplace(rrID string, serverName string, viewName string, zoneName string, rrName string, rrType string, values []string, ttl int, rename bool, meta interface{}) (string, error) {
+	if len(rrID) > 0 {
+		info, err := dnsrrinfobyid(rrID, meta)
+
+		if err != nil {
+			return "", err
+		}
+
+		if info != nil && (rename || strings.EqualFold(strings.TrimSuffix(info["rr_full_name"], "."), strings.TrimSuffix(rrName, "."))) {
+			return rrID, dnsrrupdate(rrID, serverName, viewName, zoneName, rrName, rrType, values, ttl, meta)
+		}
+
+		if info != nil {
+			if err := dnsrrdelete(rrID, meta); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	return dnsrradd(serverName, viewName, zoneName, rrName, rrType, values, ttl, meta)
+}
+
+// Maintain the forward (A/AAAA) and reverse (PTR) records of an IP address
+// The existing records are updated in place, unless their name, zone or view changes
+func ipaddressdnsapply(d *schema.ResourceData, address string, ipv6 bool, meta interface{}) error {
+	if len(d.Get("dns_zone").(string)) == 0 {
+		return ipaddressdnsclear(d, meta)
+	}
+
+	server, view, err := dnszoneserverbyname(d.Get("dns_zone").(string), d.Get("dns_view").(string), meta)
+
+	if err != nil {
+		return err
+	}
+
+	fqdn := ipaddressdnsfqdn(d)
+	rrType, ptrName := "A", iptoptr(address)
+
+	if ipv6 {
+		rrType, ptrName = "AAAA", ip6toptr(shortip6tolongip6(address))
+	}
+
+	// Records moving to another zone or view are recreated, the other ones are renamed in place
+	rrID := d.Get("dns_rr_id").(string)
+
+	if len(rrID) > 0 && d.HasChanges("dns_zone", "dns_view") {
+		if err := dnsrrdelete(rrID, meta); err != nil {
+			return err
+		}
+
+		d.Set("dns_rr_id", "")
+		rrID = ""
+	}
+
+	rrID, err = dnsrrreplace(rrID, server, view, d.Get("dns_zone").(string), fqdn, rrType, []string{address}, d.Get("ttl").(int), true, meta)
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("dns_rr_id", rrID)
+	d.Set("dns_fqdn", fqdn)
+
+	ptrID := d.Get("dns_ptr_rr_id").(string)
+
+	if !d.Get("create_ptr").(bool) {
+		if len(ptrID) > 0 {
+			if err := dnsrrdelete(ptrID, meta); err != nil {
+				return err
+			}
+
+			d.Set("dns_ptr_rr_id", "")
+		}
+
+		return nil
+	}
+
+	ptrID, err = dnsrrreplace(ptrID, server, view, "", ptrName, "PTR", []string{fqdn}, d.Get("ttl").(int), false, meta)
+
+	if err != nil {
+		return err
+	}
+
+	d.Set("dns_ptr_rr_id", ptrID)
+
+	return nil
+}
+
+// Refresh the DNS records of an IP address, the records deleted outside of Terraform
+// are reported as drift on the dns_zone (forward record) and create_ptr (reverse record) attributes
+func ipaddressdnsread(d *schema.ResourceData, meta interface{}) error {
+	if len(d.Get("dns_zone").(string)) == 0 {
+		return nil
+	}
+
+	if rrID := d.Get("dns_rr_id").(string); len(rrID) > 0 {
+		info, err := dnsrrinfobyid(rrID, meta)
+
+		if err != nil {
+			return err
+		}
+
+		if info == nil {
+			d.Set("dns_rr_id", "")
+			d.Set("dns_fqdn", "")
+			d.Set("dns_zone", "")
+		} else {
+			if ttl, ttlErr := strconv.Atoi(info["ttl"]); ttlErr == nil {
+				d.Set("ttl", ttl)
+			}
+
+			d.Set("dns_fqdn", strings.TrimSuffix(info["rr_full_name"], "."))
+		}
+	}
+
+	if ptrID := d.Get("dns_ptr_rr_id").(string); len(ptrID) > 0 {
+		info, err := dnsrrinfobyid(ptrID, meta)
+
+		if err != nil {
+			return err
+		}
+
+		if info == nil {
+			d.Set("dns_ptr_rr_id", "")
+			d.Set("create_ptr", false)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestIpAddressDNSFqdn(t *testing.T) {
+
+	type testCase struct {
+		Config map[string]interface{}
+		Fqdn   string
+	}
+
+	testCases := map[string]testCase{
+		"address_name": {
+			Config: map[string]interface{}{"name": "web01", "dns_zone": "mycompany.priv"},
+			Fqdn:   "web01.mycompany.priv",
+		},
+		"short_name": {
+			Config: map[string]interface{}{"name": "web01", "dns_zone": "mycompany.priv.", "dns_name": "www"},
+			Fqdn:   "www.mycompany.priv",
+		},
+		"fqdn_name": {
+			Config: map[string]interface{}{"name": "web01", "dns_zone": "MyCompany.priv", "dns_name": "www.mycompany.priv."},
+			Fqdn:   "www.mycompany.priv",
+		},
+		"apex_name": {
+			Config: map[string]interface{}{"name": "web01", "dns_zone": "mycompany.priv", "dns_name": "mycompany.priv"},
+			Fqdn:   "mycompany.priv",
+		},
+		"no_zone": {
+			Config: map[string]interface{}{"name": "web01.mycompany.priv"},
+			Fqdn:   "web01.mycompany.priv",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, resourceipaddress().Schema, tc.Config)
+
+			if fqdn := ipaddressdnsfqdn(d); fqdn != tc.Fqdn {
+				t.Errorf("unexpected FQDN: %q (expected: %q)", fqdn, tc.Fqdn)
+			}
+		})
+	}
+}
+
+func TestDnsRRInfoByID(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("rr_id") {
+		case "1":
+			w.Write([]byte(`[{"rr_id":"1","rr_full_name":"www.example.com"}]`))
+		case "2":
+			w.WriteHeader(204)
+		case "3":
+			w.WriteHeader(404)
+		case "4":
+			w.WriteHeader(400)
+			w.Write([]byte(`[{"errmsg":"Invalid parameter"}]`))
+		case "5":
+			w.WriteHeader(403)
+		default:
+			w.Write([]byte(`[{"member_version":"8.1.1"}]`))
+		}
+	}))
+	defer server.Close()
+
+	s, diags := NewSOLIDserver(context.Background(), strings.TrimPrefix(server.URL, "https://"), false, "ipmadmin", "admin", false, "", 5, "", "")
+
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	type testCase struct {
+		ID    string
+		Found bool
+		Error bool
+	}
+
+	testCases := map[string]testCase{
+		"found":       {ID: "1", Found: true},
+		"no_content":  {ID: "2"},
+		"not_found":   {ID: "3"},
+		"bad_request": {ID: "4", Error: true},
+		"forbidden":   {ID: "5", Error: true},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			info, err := dnsrrinfobyid(tc.ID, s)
+
+			if (err != nil) != tc.Error {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if (info != nil) != tc.Found {
+				t.Errorf("unexpected record: %v", info)
+			}
+		})
+	}
+}
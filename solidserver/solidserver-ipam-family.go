@@ -0,0 +1,103 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"net"
+	"strings"
+)
+
+// Return the schema of the family attribute of the dual-stack IPAM resources
+func ipfamilyschema(object string) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Description:  "The IP family of the " + object + ", either ipv4 or ipv6 (Default: guessed from the requested address or the parent, ipv4 otherwise).",
+		ValidateFunc: validation.StringInSlice([]string{"ipv4", "ipv6"}, false),
+		Optional:     true,
+		Computed:     true,
+	}
+}
+
+// Return the IP family of an address, an empty string if not an IP address
+func ipfamilyofaddress(address string) string {
+	ip := net.ParseIP(address)
+
+	if ip == nil {
+		return ""
+	}
+
+	if ip.To4() == nil {
+		return "ipv6"
+	}
+
+	return "ipv4"
+}
+
+// Return the IP family of a named subnet (or block) of a space, looking for IPv4 first
+func ipfamilyofsubnet(siteID string, subnetName string, terminal bool, meta interface{}) (string, error) {
+	if subnetInfo, _ := ipsubnetinfobyname(siteID, subnetName, terminal, meta); subnetInfo != nil {
+		return "ipv4", nil
+	}
+
+	if subnetInfo, _ := ip6subnetinfobyname(siteID, subnetName, terminal, meta); subnetInfo != nil {
+		return "ipv6", nil
+	}
+
+	return "", fmt.Errorf("SOLIDServer - Unable to find IP subnet: %s\n", subnetName)
+}
+
+// Return the opposite IP family
+func ipfamilyother(family string) string {
+	if family == "ipv6" {
+		return "ipv4"
+	}
+
+	return "ipv6"
+}
+
+// Split the ID of an imported dual-stack object (<family>:<oid>, the family defaulting to ipv4)
+func ipfamilyimportid(id string) (string, string, error) {
+	if !strings.Contains(id, ":") {
+		return "ipv4", id, nil
+	}
+
+	parts := strings.SplitN(id, ":", 2)
+
+	if (parts[0] != "ipv4" && parts[0] != "ipv6") || parts[1] == "" {
+		return "", "", fmt.Errorf("SOLIDServer - Unsupported import ID: %s, expected <ipv4|ipv6>:<oid>\n", id)
+	}
+
+	return parts[0], parts[1], nil
+}
+
+// Set the family at plan time when an address is requested and replace the object if its family changes
+func resourceipfamilydiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" {
+		if d.Get("family").(string) == "" && d.NewValueKnown("request_ip") {
+			if family := ipfamilyofaddress(d.Get("request_ip").(string)); family != "" {
+				return d.SetNew("family", family)
+			}
+		}
+
+		return nil
+	}
+
+	if old, new := d.GetChange("family"); d.HasChange("family") && old.(string) != "" && new.(string) != "" {
+		return d.ForceNew("family")
+	}
+
+	return nil
+}
+
+// Validate an address, prefix or range (ex: 10.0.0.10-10.0.0.20) of any family
+func validateiprangeanyfamily() schema.SchemaValidateFunc {
+	return func(i interface{}, k string) ([]string, []error) {
+		if _, errs := validateiprange(false)(i, k); len(errs) == 0 {
+			return nil, nil
+		}
+
+		return validateiprange(true)(i, k)
+	}
+}
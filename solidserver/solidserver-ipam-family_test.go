@@ -0,0 +1,132 @@
+package solidserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestIpFamilyOfAddress(t *testing.T) {
+
+	testCases := map[string]string{
+		"":                 "",
+		"web01":            "",
+		"10.0.0.1":         "ipv4",
+		"2001:db8::1":      "ipv6",
+		"::ffff:10.0.0.1":  "ipv4",
+		"2001:db8:0:0::a0": "ipv6",
+	}
+
+	for address, family := range testCases {
+		t.Run(address, func(t *testing.T) {
+			if res := ipfamilyofaddress(address); res != family {
+				t.Errorf("unexpected family: %q (expected: %q)", res, family)
+			}
+		})
+	}
+}
+
+func TestIpFamilyImportId(t *testing.T) {
+
+	type testCase struct {
+		ID     string
+		Family string
+		OID    string
+		Error  bool
+	}
+
+	testCases := map[string]testCase{
+		"default": {
+			ID:     "42",
+			Family: "ipv4",
+			OID:    "42",
+		},
+		"ipv4": {
+			ID:     "ipv4:42",
+			Family: "ipv4",
+			OID:    "42",
+		},
+		"ipv6": {
+			ID:     "ipv6:42",
+			Family: "ipv6",
+			OID:    "42",
+		},
+		"unknown_family": {
+			ID:    "ipx:42",
+			Error: true,
+		},
+		"missing_oid": {
+			ID:    "ipv6:",
+			Error: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			family, oid, err := ipfamilyimportid(tc.ID)
+
+			if (err != nil) != tc.Error {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if family != tc.Family || oid != tc.OID {
+				t.Errorf("unexpected result: %s, %s (expected: %s, %s)", family, oid, tc.Family, tc.OID)
+			}
+		})
+	}
+}
+
+func TestSubnetPairEdit(t *testing.T) {
+	var edited url.Values
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/ip6_subnet6_add":
+			edited = r.URL.Query()
+			w.Write([]byte(`[{"ret_oid":"42"}]`))
+		default:
+			w.Write([]byte(`[{"member_version":"8.1.1"}]`))
+		}
+	}))
+	defer server.Close()
+
+	s, diags := NewSOLIDserver(context.Background(), strings.TrimPrefix(server.URL, "https://"), false, "ipmadmin", "admin", false, "", 5, "", "")
+
+	if diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	d := schema.TestResourceDataRaw(t, resourcesubnet().Schema, map[string]interface{}{
+		"family":           "ipv4",
+		"space":            "prod",
+		"block":            "block-a",
+		"prefix_size":      24,
+		"name":             "web",
+		"class_parameters": map[string]interface{}{"env": "prod"},
+		"dual_stack": []interface{}{
+			map[string]interface{}{"block": "block6-a", "prefix_size": 64},
+		},
+	})
+
+	dual := d.Get("dual_stack").([]interface{})[0].(map[string]interface{})
+	dual["id"] = "42"
+	d.Set("dual_stack", []interface{}{dual})
+
+	if err := resourcesubnetpairedit(context.Background(), d, s); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if edited.Get("subnet6_id") != "42" || edited.Get("subnet6_name") != "web" || edited.Get("subnet6_class_parameters") != "env=prod" || edited.Get("is_terminal") != "1" {
+		t.Errorf("unexpected update parameters: %v", edited)
+	}
+
+	// The paired subnet is never resized
+	if _, resized := edited["subnet6_prefix"]; resized {
+		t.Errorf("unexpected resize of the paired subnet: %v", edited)
+	}
+}
@@ -0,0 +1,212 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"net"
+	"net/url"
+	"regexp"
+)
+
+// Subset of schema.ResourceData and schema.ResourceDiff used to detect IP address moves
+type ipAddressChanges interface {
+	Get(key string) interface{}
+	HasChange(key string) bool
+	HasChanges(keys ...string) bool
+}
+
+// Return true if an existing IP address must be moved to a new subnet, pool or address
+// Clearing request_ip keeps the current address
+func ipaddressmoverequired(d ipAddressChanges) bool {
+	if d.HasChanges("space", "subnet", "pool") {
+		return true
+	}
+
+	if !d.HasChange("request_ip") || d.Get("request_ip").(string) == "" {
+		return false
+	}
+
+	return !net.ParseIP(d.Get("request_ip").(string)).Equal(net.ParseIP(d.Get("address").(string)))
+}
+
+// Report the address of a moved IP address as known when requested, or as computed otherwise
+// Thus a move is planned as an in place update instead of a replacement
+func resourceipaddressmovediff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() == "" || !ipaddressmoverequired(d) {
+		return nil
+	}
+
+	if d.NewValueKnown("request_ip") && d.Get("request_ip").(string) != "" {
+		return d.SetNew("address", d.Get("request_ip").(string))
+	}
+
+	return d.SetNewComputed("address")
+}
+
+// Return the meta-data of an IP address not managed through the configuration
+// They are inherited by the new IP address when moving it: device, MAC address and class parameters
+func ipaddressinherited(d *schema.ResourceData, addressID string, ipv6 bool, meta interface{}) (url.Values, error) {
+	s := meta.(*SOLIDserver)
+
+	service, idKey, macKey, classParamsKey := "ip_address_info", "ip_id", "mac_addr", "ip_class_parameters"
+
+	if ipv6 {
+		service, idKey, macKey, classParamsKey = "ip6_address6_info", "ip6_id", "ip6_mac_addr", "ip6_class_parameters"
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add(idKey, addressID)
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/"+service, &parameters)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	// Checking the answer
+	if resp.StatusCode != 200 || len(buf) == 0 {
+		return nil, fmt.Errorf("SOLIDServer - Unable to find IP address (oid): %s\n", addressID)
+	}
+
+	attributes := restobjectattributes(buf[0])
+	inherited := url.Values{}
+
+	if d.Get("device").(string) == "" && attributes["hostdev_id"] != "" && attributes["hostdev_id"] != "0" {
+		inherited.Add("hostdev_id", attributes["hostdev_id"])
+	}
+
+	if macIgnore, _ := regexp.MatchString("^EIP:", attributes[macKey]); d.Get("mac").(string) == "" && attributes[macKey] != "" && !macIgnore {
+		inherited.Add("mac_addr", attributes[macKey])
+	}
+
+	// Merging the class parameters, the configured ones take precedence
+	classParameters, _ := url.ParseQuery(attributes[classParamsKey])
+
+	for k, v := range urlfromclassparams(d.Get("class_parameters")) {
+		classParameters[k] = v
+	}
+
+	inherited.Add(classParamsKey, classParameters.Encode())
+
+	return inherited, nil
+}
+
+// Copy the aliases of an IP address to another one
+func ipaddressmovealiases(fromID string, toID string, ipv6 bool, meta interface{}) error {
+	s := meta.(*SOLIDserver)
+
+	prefix := "ip_"
+
+	if ipv6 {
+		prefix = "ip6_"
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add(prefix+"id", fromID)
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/"+prefix+"alias_list", &parameters)
+
+	if err != nil {
+		return err
+	}
+
+	var aliases [](map[string]interface{})
+	json.Unmarshal([]byte(body), &aliases)
+
+	// No alias
+	if resp.StatusCode == 204 {
+		return nil
+	}
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("SOLIDServer - Unable to list the aliases of IP address (oid): %s\n", fromID)
+	}
+
+	for _, alias := range aliases {
+		attributes := restobjectattributes(alias)
+
+		// Building parameters
+		parameters := url.Values{}
+		parameters.Add(prefix+"id", toID)
+		parameters.Add(prefix+"name", attributes["alias_name"])
+		parameters.Add(prefix+"name_type", attributes[prefix+"name_type"])
+
+		// Sending the creation request
+		resp, body, err := s.Request("post", "rest/"+prefix+"alias_add", &parameters)
+
+		if err != nil {
+			return err
+		}
+
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		// Checking the answer
+		if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+			if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
+				tflog.Debug(s.Ctx, fmt.Sprintf("Moved IP alias: %s to IP address (oid): %s (new oid: %s)\n", attributes["alias_name"], toID, oid))
+				continue
+			}
+		}
+
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return fmt.Errorf("SOLIDServer - Unable to move IP alias: %s to IP address (oid): %s (%s)\n", attributes["alias_name"], toID, errMsg)
+			}
+		}
+
+		return fmt.Errorf("SOLIDServer - Unable to move IP alias: %s to IP address (oid): %s\n", attributes["alias_name"], toID)
+	}
+
+	return nil
+}
+
+// Delete an IP address from its oid
+func ipaddressdeletebyid(addressID string, ipv6 bool, meta interface{}) error {
+	s := meta.(*SOLIDserver)
+
+	service, idKey := "ip_delete", "ip_id"
+
+	if ipv6 {
+		service, idKey = "ip6_address6_delete", "ip6_id"
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add(idKey, addressID)
+
+	// Sending the deletion request
+	resp, body, err := s.Request("delete", "rest/"+service, &parameters)
+
+	if err != nil {
+		return err
+	}
+
+	// Checking the answer
+	if resp.StatusCode != 200 && resp.StatusCode != 204 {
+		var buf [](map[string]interface{})
+		json.Unmarshal([]byte(body), &buf)
+
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return fmt.Errorf("SOLIDServer - Unable to delete IP address (oid): %s (%s)\n", addressID, errMsg)
+			}
+		}
+
+		return fmt.Errorf("SOLIDServer - Unable to delete IP address (oid): %s\n", addressID)
+	}
+
+	tflog.Debug(s.Ctx, fmt.Sprintf("Deleted IP address's oid: %s\n", addressID))
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package solidserver
+
+import (
+	"testing"
+)
+
+type ipAddressChangesMock struct {
+	Old map[string]interface{}
+	New map[string]interface{}
+}
+
+func (m ipAddressChangesMock) Get(key string) interface{} {
+	if v, exist := m.New[key]; exist {
+		return v
+	}
+
+	return m.Old[key]
+}
+
+func (m ipAddressChangesMock) HasChange(key string) bool {
+	return m.Get(key) != m.Old[key]
+}
+
+func (m ipAddressChangesMock) HasChanges(keys ...string) bool {
+	for _, key := range keys {
+		if m.HasChange(key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestIpAddressMoveRequired(t *testing.T) {
+
+	type testCase struct {
+		New  map[string]interface{}
+		Move bool
+	}
+
+	current := map[string]interface{}{
+		"space":      "prod",
+		"subnet":     "web",
+		"pool":       "",
+		"request_ip": "",
+		"address":    "10.0.0.10",
+		"name":       "web01",
+	}
+
+	testCases := map[string]testCase{
+		"no_change": {
+			New: map[string]interface{}{},
+		},
+		"name_change": {
+			New: map[string]interface{}{"name": "web02"},
+		},
+		"subnet_change": {
+			New:  map[string]interface{}{"subnet": "app"},
+			Move: true,
+		},
+		"pool_change": {
+			New:  map[string]interface{}{"pool": "servers"},
+			Move: true,
+		},
+		"space_change": {
+			New:  map[string]interface{}{"space": "dev"},
+			Move: true,
+		},
+		"new_address": {
+			New:  map[string]interface{}{"request_ip": "10.0.0.20"},
+			Move: true,
+		},
+		"current_address": {
+			New: map[string]interface{}{"request_ip": "10.0.0.10"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if move := ipaddressmoverequired(ipAddressChangesMock{Old: current, New: tc.New}); move != tc.Move {
+				t.Errorf("unexpected move: %v (expected: %v)", move, tc.Move)
+			}
+		})
+	}
+}
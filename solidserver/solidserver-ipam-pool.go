@@ -0,0 +1,282 @@
+package solidserver
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"math/big"
+	"net"
+	"net/url"
+)
+
+// Convert an IPv4 or IPv6 address string into a Big Integer
+// Return nil in case of failure or family mismatch
+func iptobig(ip string, ipv6 bool) *big.Int {
+	parsed := net.ParseIP(ip)
+
+	if parsed == nil || (parsed.To4() == nil) != ipv6 {
+		return nil
+	}
+
+	if ipv6 {
+		return hexiptobig(ip6tohexip6(shortip6tolongip6(ip)))
+	}
+
+	return hexiptobig(iptohexip(ip))
+}
+
+// Return the range of addresses of a pool defined by a CIDR, a start and end addresses or a start address and a size
+func ippoolrange(cidr string, start string, end string, size int, ipv6 bool) (*big.Int, *big.Int, error) {
+	bits := 32
+
+	if ipv6 {
+		bits = 128
+	}
+
+	if len(cidr) > 0 {
+		if len(start) > 0 || len(end) > 0 || size > 0 {
+			return nil, nil, fmt.Errorf("SOLIDServer - IP pool: %s can't define start, end or size along with a CIDR\n", cidr)
+		}
+
+		address, network, err := net.ParseCIDR(cidr)
+
+		if err != nil || (address.To4() == nil) != ipv6 {
+			return nil, nil, fmt.Errorf("SOLIDServer - Invalid IP pool CIDR: %s\n", cidr)
+		}
+
+		ones, _ := network.Mask.Size()
+		first := iptobig(network.IP.String(), ipv6)
+		last := new(big.Int).Sub(new(big.Int).Add(first, new(big.Int).Lsh(big.NewInt(1), uint(bits-ones))), big.NewInt(1))
+
+		return first, last, nil
+	}
+
+	first := iptobig(start, ipv6)
+
+	if first == nil {
+		return nil, nil, fmt.Errorf("SOLIDServer - Invalid IP pool start address: %s\n", start)
+	}
+
+	if len(end) > 0 && size > 0 {
+		return nil, nil, fmt.Errorf("SOLIDServer - IP pool starting at: %s can't define both an end address and a size\n", start)
+	}
+
+	var last *big.Int
+
+	if len(end) > 0 {
+		if last = iptobig(end, ipv6); last == nil {
+			return nil, nil, fmt.Errorf("SOLIDServer - Invalid IP pool end address: %s\n", end)
+		}
+	} else if size > 0 {
+		last = new(big.Int).Add(first, big.NewInt(int64(size-1)))
+	} else {
+		return nil, nil, fmt.Errorf("SOLIDServer - IP pool starting at: %s requires either an end address or a size\n", start)
+	}
+
+	if last.Cmp(first) < 0 || last.BitLen() > bits {
+		return nil, nil, fmt.Errorf("SOLIDServer - Invalid IP pool range: %s-%s\n", start, ipfrombig(last, ipv6))
+	}
+
+	return first, last, nil
+}
+
+// Check that a pool sits inside its subnet and does not overlap any sibling pool
+func ippoolcheck(start *big.Int, end *big.Int, subnetStart *big.Int, subnetEnd *big.Int, siblings []ipRange, ipv6 bool) error {
+	if start.Cmp(subnetStart) < 0 || end.Cmp(subnetEnd) > 0 {
+		return fmt.Errorf("SOLIDServer - IP pool %s-%s is out of its subnet %s-%s\n", ipfrombig(start, ipv6), ipfrombig(end, ipv6), ipfrombig(subnetStart, ipv6), ipfrombig(subnetEnd, ipv6))
+	}
+
+	for _, sibling := range siblings {
+		if start.Cmp(sibling.End) <= 0 && sibling.Start.Cmp(end) <= 0 {
+			return fmt.Errorf("SOLIDServer - IP pool %s-%s overlaps the IP pool %s-%s\n", ipfrombig(start, ipv6), ipfrombig(end, ipv6), ipfrombig(sibling.Start, ipv6), ipfrombig(sibling.End, ipv6))
+		}
+	}
+
+	return nil
+}
+
+// Return the number of addresses of a pool, or 0 if it does not fit an integer
+func ippoolsize(start *big.Int, end *big.Int) int {
+	size := new(big.Int).Add(new(big.Int).Sub(end, start), big.NewInt(1))
+
+	if !size.IsInt64() {
+		return 0
+	}
+
+	return int(size.Int64())
+}
+
+// Return the ranges of the pools of a subnet, except the given one
+func ippoolsiblings(subnetID string, poolID string, ipv6 bool, meta interface{}) ([]ipRange, error) {
+	service, idKey, subnetKey, startKey, endKey := "ip_pool_list", "pool_id", "subnet_id", "start_ip_addr", "end_ip_addr"
+
+	if ipv6 {
+		service, idKey, subnetKey, startKey, endKey = "ip6_pool6_list", "pool6_id", "subnet6_id", "start_ip6_addr", "end_ip6_addr"
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("WHERE", subnetKey+"='"+subnetID+"'")
+
+	// Sending the read request(s)
+	buf, err := solidserverlist(service, parameters, 0, meta)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := []ipRange{}
+
+	for _, entry := range buf {
+		attributes := restobjectattributes(entry)
+
+		if attributes[idKey] == poolID {
+			continue
+		}
+
+		start, end := hexiptobig(attributes[startKey]), hexiptobig(attributes[endKey])
+
+		if start != nil && end != nil {
+			res = append(res, ipRange{Start: start, End: end})
+		}
+	}
+
+	return res, nil
+}
+
+// Return the pool range inputs from a configuration, along with whether they are all known
+func ippoolrangeinputs(config cty.Value) (string, string, string, int, bool) {
+	if config.IsNull() || !config.IsWhollyKnown() {
+		return "", "", "", 0, false
+	}
+
+	res := map[string]string{}
+
+	for _, k := range []string{"cidr", "start", "end"} {
+		if v := config.GetAttr(k); !v.IsNull() {
+			res[k] = v.AsString()
+		}
+	}
+
+	size := 0
+
+	if v := config.GetAttr("size"); !v.IsNull() {
+		bf := v.AsBigFloat()
+		s, _ := bf.Int64()
+		size = int(s)
+	}
+
+	return res["cidr"], res["start"], res["end"], size, true
+}
+
+// Compute at plan time the range of a pool from its CIDR, start, end or size,
+// and check that it sits inside its subnet without overlapping sibling pools
+func resourceippooldiff(ipv6 bool) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		config := d.GetRawConfig()
+		cidr, startIP, endIP, size, known := ippoolrangeinputs(config)
+
+		if !known {
+			for _, k := range []string{"start", "end", "size"} {
+				if !config.IsKnown() || config.IsNull() || config.GetAttr(k).IsNull() {
+					d.SetNewComputed(k)
+				}
+			}
+
+			return nil
+		}
+
+		start, end, err := ippoolrange(cidr, startIP, endIP, size, ipv6)
+
+		if err != nil {
+			return err
+		}
+
+		// Computing the attributes not set within the configuration
+		if config.GetAttr("start").IsNull() {
+			d.SetNew("start", ipfrombig(start, ipv6))
+		}
+
+		if config.GetAttr("end").IsNull() {
+			d.SetNew("end", ipfrombig(end, ipv6))
+		}
+
+		if config.GetAttr("size").IsNull() {
+			d.SetNew("size", ippoolsize(start, end))
+		}
+
+		// Checking the range only when it changes
+		if d.Id() != "" && !d.HasChanges("start", "end", "size", "cidr") {
+			return nil
+		}
+
+		if meta == nil || !d.NewValueKnown("space") || !d.NewValueKnown("subnet") {
+			return nil
+		}
+
+		siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+		if siteErr != nil || len(siteID) == 0 {
+			tflog.Debug(ctx, fmt.Sprintf("Skipping the IP pool range check, unable to find IP space: %s\n", d.Get("space").(string)))
+			return nil
+		}
+
+		var subnetInfo map[string]interface{}
+		var subnetErr error
+
+		if ipv6 {
+			subnetInfo, subnetErr = ip6subnetinfobyname(siteID, d.Get("subnet").(string), true, meta)
+		} else {
+			subnetInfo, subnetErr = ipsubnetinfobyname(siteID, d.Get("subnet").(string), true, meta)
+		}
+
+		if subnetErr != nil {
+			tflog.Debug(ctx, fmt.Sprintf("Skipping the IP pool range check, unable to find IP subnet: %s\n", d.Get("subnet").(string)))
+			return nil
+		}
+
+		subnetStartHex, _ := subnetInfo["start_hex_addr"].(string)
+		subnetEndHex, _ := subnetInfo["end_hex_addr"].(string)
+		subnetStart, subnetEnd := hexiptobig(subnetStartHex), hexiptobig(subnetEndHex)
+
+		if subnetStart == nil || subnetEnd == nil {
+			return nil
+		}
+
+		siblings, siblingsErr := ippoolsiblings(subnetInfo["id"].(string), d.Id(), ipv6, meta)
+
+		if siblingsErr != nil {
+			return siblingsErr
+		}
+
+		return ippoolcheck(start, end, subnetStart, subnetEnd, siblings, ipv6)
+	}
+}
+
+// Set the range attributes of a pool from its rest/ip_pool_info or rest/ip6_pool6_info entry
+func ippoolsetrange(d *schema.ResourceData, entry map[string]interface{}, ipv6 bool) {
+	attributes := restobjectattributes(entry)
+	start, end := hexiptobig(attributes["start_ip_addr"]), hexiptobig(attributes["end_ip_addr"])
+
+	if ipv6 {
+		start, end = hexiptobig(attributes["start_ip6_addr"]), hexiptobig(attributes["end_ip6_addr"])
+
+		if startHex, startExist := attributes["pool6_start_ip6_addr"]; startExist {
+			start = hexiptobig(startHex)
+		}
+
+		if endHex, endExist := attributes["pool6_end_ip6_addr"]; endExist {
+			end = hexiptobig(endHex)
+		}
+	}
+
+	if start == nil || end == nil {
+		return
+	}
+
+	d.Set("start", ipfrombig(start, ipv6))
+	d.Set("end", ipfrombig(end, ipv6))
+	d.Set("size", ippoolsize(start, end))
+}
@@ -0,0 +1,166 @@
+package solidserver
+
+import (
+	"github.com/hashicorp/go-cty/cty"
+	"math/big"
+	"testing"
+)
+
+func TestIpPoolRange(t *testing.T) {
+
+	type testCase struct {
+		CIDR     string
+		Start    string
+		End      string
+		Size     int
+		IPv6     bool
+		Expected [2]string
+		Error    bool
+	}
+
+	testCases := map[string]testCase{
+		"start_size": {
+			Start:    "10.0.0.10",
+			Size:     20,
+			Expected: [2]string{"10.0.0.10", "10.0.0.29"},
+		},
+		"start_end": {
+			Start:    "10.0.0.10",
+			End:      "10.0.0.99",
+			Expected: [2]string{"10.0.0.10", "10.0.0.99"},
+		},
+		"cidr": {
+			CIDR:     "10.0.0.64/26",
+			Expected: [2]string{"10.0.0.64", "10.0.0.127"},
+		},
+		"cidr_unaligned": {
+			CIDR:     "10.0.0.70/26",
+			Expected: [2]string{"10.0.0.64", "10.0.0.127"},
+		},
+		"ipv6_cidr": {
+			CIDR:     "2001:db8::/120",
+			IPv6:     true,
+			Expected: [2]string{"2001:0db8:0000:0000:0000:0000:0000:0000", "2001:0db8:0000:0000:0000:0000:0000:00ff"},
+		},
+		"ipv6_start_end": {
+			Start:    "2001:db8::100",
+			End:      "2001:db8::1ff",
+			IPv6:     true,
+			Expected: [2]string{"2001:0db8:0000:0000:0000:0000:0000:0100", "2001:0db8:0000:0000:0000:0000:0000:01ff"},
+		},
+		"reversed": {
+			Start: "10.0.0.10",
+			End:   "10.0.0.9",
+			Error: true,
+		},
+		"overflow": {
+			Start: "255.255.255.250",
+			Size:  10,
+			Error: true,
+		},
+		"end_and_size": {
+			Start: "10.0.0.10",
+			End:   "10.0.0.99",
+			Size:  20,
+			Error: true,
+		},
+		"cidr_and_start": {
+			CIDR:  "10.0.0.64/26",
+			Start: "10.0.0.64",
+			Error: true,
+		},
+		"start_only": {
+			Start: "10.0.0.10",
+			Error: true,
+		},
+		"family_mismatch": {
+			CIDR:  "2001:db8::/120",
+			Error: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			start, end, err := ippoolrange(tc.CIDR, tc.Start, tc.End, tc.Size, tc.IPv6)
+
+			if (err != nil) != tc.Error {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if res := [2]string{ipfrombig(start, tc.IPv6), ipfrombig(end, tc.IPv6)}; res != tc.Expected {
+				t.Errorf("unexpected range: %v (expected: %v)", res, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestIpPoolCheck(t *testing.T) {
+
+	type testCase struct {
+		Start string
+		End   string
+		Error bool
+	}
+
+	subnetStart, subnetEnd := hexiptobig("0a000000"), hexiptobig("0a0000ff")
+	siblings := []ipRange{{Start: hexiptobig("0a000040"), End: hexiptobig("0a00007f")}}
+
+	testCases := map[string]testCase{
+		"free": {
+			Start: "0a000080",
+			End:   "0a0000ff",
+		},
+		"overlap": {
+			Start: "0a000070",
+			End:   "0a00008f",
+			Error: true,
+		},
+		"outside": {
+			Start: "0a0000f0",
+			End:   "0a00010f",
+			Error: true,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			if err := ippoolcheck(hexiptobig(tc.Start), hexiptobig(tc.End), subnetStart, subnetEnd, siblings, false); (err != nil) != tc.Error {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestIpPoolRangeInputs(t *testing.T) {
+	config := cty.ObjectVal(map[string]cty.Value{
+		"cidr":  cty.NullVal(cty.String),
+		"start": cty.StringVal("10.0.0.10"),
+		"end":   cty.NullVal(cty.String),
+		"size":  cty.NumberIntVal(20),
+	})
+
+	cidr, start, end, size, known := ippoolrangeinputs(config)
+
+	if !known || cidr != "" || start != "10.0.0.10" || end != "" || size != 20 {
+		t.Errorf("unexpected inputs: %q, %q, %q, %d, %t", cidr, start, end, size, known)
+	}
+
+	config = cty.ObjectVal(map[string]cty.Value{
+		"cidr":  cty.NullVal(cty.String),
+		"start": cty.UnknownVal(cty.String),
+		"end":   cty.NullVal(cty.String),
+		"size":  cty.NumberIntVal(20),
+	})
+
+	if _, _, _, _, known = ippoolrangeinputs(config); known {
+		t.Errorf("unexpected known inputs")
+	}
+
+	if size := ippoolsize(big.NewInt(0), new(big.Int).Lsh(big.NewInt(1), 64)); size != 0 {
+		t.Errorf("unexpected size: %d", size)
+	}
+}
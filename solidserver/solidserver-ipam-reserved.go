@@ -0,0 +1,442 @@
+package solidserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"math/big"
+	"net/url"
+)
+
+// Class parameter of the reserved addresses, holding their role within the subnet
+const ipReservedRoleParameter = "reserved_role"
+
+// Schema of the reserved addresses of an IPv4 or IPv6 subnet
+func ipsubnetreservedschema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Description: "The addresses to reserve within the subnet (ex: VRRP/HSRP router addresses, DHCP relays), created along with it.",
+		Optional:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"offset": {
+					Type:        schema.TypeInt,
+					Description: "The offset of the address from the subnet address, or from its last address when negative (ex: -1 for the last address).",
+					Optional:    true,
+					Default:     0,
+				},
+				"ip": {
+					Type:        schema.TypeString,
+					Description: "The explicit address to reserve, instead of an offset.",
+					Optional:    true,
+					Default:     "",
+				},
+				"name": {
+					Type:        schema.TypeString,
+					Description: "The name of the reserved address.",
+					Required:    true,
+				},
+				"role": {
+					Type:         schema.TypeString,
+					Description:  "The role of the reserved address, supported values: gateway, vrrp, hsrp, router, dhcp-relay, broadcast and reserved (Default: reserved).",
+					ValidateFunc: validation.StringInSlice([]string{"gateway", "vrrp", "hsrp", "router", "dhcp-relay", "broadcast", "reserved"}, false),
+					Optional:     true,
+					Default:      "reserved",
+				},
+				"address": {
+					Type:        schema.TypeString,
+					Description: "The provisionned reserved address.",
+					Computed:    true,
+				},
+				"id": {
+					Type:        schema.TypeString,
+					Description: "The ID of the reserved address.",
+					Computed:    true,
+				},
+			},
+		},
+	}
+}
+
+// Return the first and last addresses of an IPv4 or IPv6 subnet
+func ipsubnetbounds(address string, prefixSize int, ipv6 bool) (*big.Int, *big.Int) {
+	bits, start := 32, iptobig(address, ipv6)
+
+	if ipv6 {
+		bits = 128
+	}
+
+	if start == nil || prefixSize < 0 || prefixSize > bits {
+		return nil, nil
+	}
+
+	size := new(big.Int).Lsh(big.NewInt(1), uint(bits-prefixSize))
+	end := new(big.Int).Sub(new(big.Int).Add(start, size), big.NewInt(1))
+
+	return start, end
+}
+
+// Compute a reserved address of a subnet from its offset or explicit IP address
+func ipsubnetreservedaddress(start *big.Int, end *big.Int, offset int, ip string, ipv6 bool) (string, error) {
+	var address *big.Int
+
+	if len(ip) > 0 {
+		if offset != 0 {
+			return "", fmt.Errorf("SOLIDServer - Reserved address: %s can't define both an offset and an IP address\n", ip)
+		}
+
+		if address = iptobig(ip, ipv6); address == nil {
+			return "", fmt.Errorf("SOLIDServer - Invalid reserved address: %s\n", ip)
+		}
+	} else if offset > 0 {
+		address = new(big.Int).Add(start, big.NewInt(int64(offset)))
+	} else if offset < 0 {
+		address = new(big.Int).Add(end, big.NewInt(int64(offset+1)))
+	} else {
+		return "", fmt.Errorf("SOLIDServer - Reserved address requires either an offset or an IP address\n")
+	}
+
+	if address == nil || address.Cmp(start) < 0 || address.Cmp(end) > 0 {
+		return "", fmt.Errorf("SOLIDServer - Reserved address (offset: %d, ip: %s) is out of the subnet %s-%s\n", offset, ip, ipfrombig(start, ipv6), ipfrombig(end, ipv6))
+	}
+
+	return ipfrombig(address, ipv6), nil
+}
+
+// Register a reserved address within a space
+// Return the oid of the address
+func ipaddressreserve(ctx context.Context, siteID string, address string, name string, role string, ipv6 bool, meta interface{}) (string, error) {
+	s := meta.(*SOLIDserver)
+
+	service, prefix := "ip_add", "ip"
+
+	if ipv6 {
+		service, prefix = "ip6_address6_add", "ip6"
+	}
+
+	classParameters := url.Values{}
+	classParameters.Add(ipReservedRoleParameter, role)
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add("site_id", siteID)
+	parameters.Add("add_flag", "new_only")
+	parameters.Add("hostaddr", address)
+	parameters.Add(prefix+"_name", name)
+	parameters.Add(prefix+"_class_parameters", classParameters.Encode())
+
+	// Sending the creation request
+	resp, body, err := s.Request("post", "rest/"+service, &parameters)
+
+	if err != nil {
+		return "", err
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	// Checking the answer
+	if (resp.StatusCode == 200 || resp.StatusCode == 201) && len(buf) > 0 {
+		if oid, oidExist := buf[0]["ret_oid"].(string); oidExist {
+			tflog.Debug(ctx, fmt.Sprintf("Reserved IP address: %s (oid): %s\n", address, oid))
+			return oid, nil
+		}
+	}
+
+	if len(buf) > 0 {
+		if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+			return "", fmt.Errorf("SOLIDServer - Unable to reserve IP address: %s (%s)\n", address, errMsg)
+		}
+	}
+
+	return "", fmt.Errorf("SOLIDServer - Unable to reserve IP address: %s\n", address)
+}
+
+// Create the reserved addresses of a subnet and record them within the state
+// The addresses created so far are released in case of failure
+func ipsubnetreservedapply(ctx context.Context, d *schema.ResourceData, siteID string, ipv6 bool, meta interface{}) diag.Diagnostics {
+	reserved := d.Get("reserved_addresses").([]interface{})
+
+	if len(reserved) == 0 {
+		return nil
+	}
+
+	start, end := ipsubnetbounds(d.Get("address").(string), d.Get("prefix_size").(int), ipv6)
+
+	if start == nil {
+		return diag.Errorf("Unable to compute the reserved addresses of subnet: %s\n", d.Get("name").(string))
+	}
+
+	res := []interface{}{}
+	created := []string{}
+
+	for _, r := range reserved {
+		entry := r.(map[string]interface{})
+
+		address, err := ipsubnetreservedaddress(start, end, entry["offset"].(int), entry["ip"].(string), ipv6)
+
+		if err == nil {
+			var oid string
+
+			if oid, err = ipaddressreserve(ctx, siteID, address, entry["name"].(string), entry["role"].(string), ipv6, meta); err == nil {
+				created = append(created, oid)
+				res = append(res, map[string]interface{}{
+					"offset":  entry["offset"].(int),
+					"ip":      entry["ip"].(string),
+					"name":    entry["name"].(string),
+					"role":    entry["role"].(string),
+					"address": address,
+					"id":      oid,
+				})
+				continue
+			}
+		}
+
+		// Releasing the addresses reserved so far
+		for _, oid := range created {
+			if delErr := ipaddressdeletebyid(oid, ipv6, meta); delErr != nil {
+				tflog.Debug(ctx, fmt.Sprintf("Unable to release reserved IP address (oid): %s\n", oid))
+			}
+		}
+
+		d.Set("reserved_addresses", []interface{}{})
+
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	d.Set("reserved_addresses", res)
+
+	return nil
+}
+
+// Return the name, role and address of a reserved address from its oid
+// Return nil if the address no longer exists
+func ipaddressreservedinfo(addressID string, ipv6 bool, meta interface{}) (map[string]string, error) {
+	s := meta.(*SOLIDserver)
+
+	service, idKey, nameKey, addrKey, classParamsKey := "ip_address_info", "ip_id", "name", "ip_addr", "ip_class_parameters"
+
+	if ipv6 {
+		service, idKey, nameKey, addrKey, classParamsKey = "ip6_address6_info", "ip6_id", "ip6_name", "ip6_addr", "ip6_class_parameters"
+	}
+
+	// Building parameters
+	parameters := url.Values{}
+	parameters.Add(idKey, addressID)
+
+	// Sending the read request
+	resp, body, err := s.Request("get", "rest/"+service, &parameters)
+
+	if err != nil {
+		return nil, err
+	}
+
+	var buf [](map[string]interface{})
+	json.Unmarshal([]byte(body), &buf)
+
+	// Checking the answer
+	if resp.StatusCode != 200 && resp.StatusCode != 204 && resp.StatusCode != 404 {
+		if len(buf) > 0 {
+			if errMsg, errExist := buf[0]["errmsg"].(string); errExist {
+				return nil, fmt.Errorf("SOLIDServer - Unable to read reserved IP address (oid): %s (%s)\n", addressID, errMsg)
+			}
+		}
+
+		return nil, fmt.Errorf("SOLIDServer - Unable to read reserved IP address (oid): %s\n", addressID)
+	}
+
+	// The address does not exist anymore
+	if resp.StatusCode != 200 || len(buf) == 0 {
+		return nil, nil
+	}
+
+	attributes := restobjectattributes(buf[0])
+	classParameters, _ := url.ParseQuery(attributes[classParamsKey])
+
+	address := hexiptoip(attributes[addrKey])
+
+	if ipv6 {
+		address = hexip6toip6(attributes[addrKey])
+	}
+
+	return map[string]string{
+		"name":    attributes[nameKey],
+		"role":    classParameters.Get(ipReservedRoleParameter),
+		"address": address,
+	}, nil
+}
+
+// Release reserved addresses of a subnet
+// The addresses already deleted are ignored
+func ipsubnetreservedclear(ctx context.Context, reserved []interface{}, ipv6 bool, meta interface{}) error {
+	for _, r := range reserved {
+		entry := r.(map[string]interface{})
+
+		if oid, _ := entry["id"].(string); len(oid) > 0 {
+			if err := ipaddressdeletebyid(oid, ipv6, meta); err != nil {
+				if info, infoErr := ipaddressreservedinfo(oid, ipv6, meta); infoErr != nil || info != nil {
+					return err
+				}
+
+				tflog.Debug(ctx, fmt.Sprintf("Reserved IP address (oid): %s already released\n", oid))
+				continue
+			}
+
+			tflog.Debug(ctx, fmt.Sprintf("Released reserved IP address (oid): %s\n", oid))
+		}
+	}
+
+	return nil
+}
+
+// Match the desired reserved addresses with the previous ones by address
+// The ID of a previous address is kept when its name and role are unchanged, the other previous addresses are returned to be released
+func ipsubnetreservedmatch(previous []interface{}, desired []map[string]interface{}) []interface{} {
+	kept := map[string]bool{}
+	released := []interface{}{}
+
+	for _, entry := range desired {
+		entry["id"] = ""
+
+		for _, p := range previous {
+			old := p.(map[string]interface{})
+			oid, _ := old["id"].(string)
+
+			if len(oid) > 0 && !kept[oid] && old["address"] == entry["address"] && old["name"] == entry["name"] && old["role"] == entry["role"] {
+				entry["id"] = oid
+				kept[oid] = true
+				break
+			}
+		}
+	}
+
+	for _, p := range previous {
+		if oid, _ := p.(map[string]interface{})["id"].(string); len(oid) > 0 && !kept[oid] {
+			released = append(released, p)
+		}
+	}
+
+	return released
+}
+
+// Update the reserved addresses of a subnet when they change or when the subnet is resized
+// Only the addresses whose address, name or role changed are recreated
+func ipsubnetreservedupdate(ctx context.Context, d *schema.ResourceData, ipv6 bool, meta interface{}) diag.Diagnostics {
+	if !d.HasChanges("reserved_addresses", "prefix_size") {
+		return nil
+	}
+
+	siteID, siteErr := ipsiteidbyname(d.Get("space").(string), meta)
+
+	if siteErr != nil {
+		// Reporting a failure
+		return diag.FromErr(siteErr)
+	}
+
+	previous, _ := d.GetChange("reserved_addresses")
+	reserved := d.Get("reserved_addresses").([]interface{})
+	desired := []map[string]interface{}{}
+
+	if len(reserved) > 0 {
+		start, end := ipsubnetbounds(d.Get("address").(string), d.Get("prefix_size").(int), ipv6)
+
+		if start == nil {
+			return diag.Errorf("Unable to compute the reserved addresses of subnet: %s\n", d.Get("name").(string))
+		}
+
+		for _, r := range reserved {
+			entry := r.(map[string]interface{})
+
+			address, err := ipsubnetreservedaddress(start, end, entry["offset"].(int), entry["ip"].(string), ipv6)
+
+			if err != nil {
+				// Reporting a failure
+				return diag.FromErr(err)
+			}
+
+			desired = append(desired, map[string]interface{}{
+				"offset":  entry["offset"].(int),
+				"ip":      entry["ip"].(string),
+				"name":    entry["name"].(string),
+				"role":    entry["role"].(string),
+				"address": address,
+			})
+		}
+	}
+
+	released := ipsubnetreservedmatch(previous.([]interface{}), desired)
+
+	if err := ipsubnetreservedclear(ctx, released, ipv6, meta); err != nil {
+		// Reporting a failure
+		return diag.FromErr(err)
+	}
+
+	var reserveErr error
+
+	for _, entry := range desired {
+		if entry["id"].(string) == "" {
+			if entry["id"], reserveErr = ipaddressreserve(ctx, siteID, entry["address"].(string), entry["name"].(string), entry["role"].(string), ipv6, meta); reserveErr != nil {
+				break
+			}
+		}
+	}
+
+	// Keeping track of the reserved addresses existing so far
+	res := []interface{}{}
+
+	for _, entry := range desired {
+		if entry["id"].(string) != "" {
+			res = append(res, entry)
+		}
+	}
+
+	d.Set("reserved_addresses", res)
+
+	if reserveErr != nil {
+		// Reporting a failure
+		return diag.FromErr(reserveErr)
+	}
+
+	return nil
+}
+
+// Refresh the reserved addresses of a subnet from their oid
+// The addresses no longer existing are removed from the state
+func ipsubnetreservedread(ctx context.Context, d *schema.ResourceData, ipv6 bool, meta interface{}) error {
+	res := []interface{}{}
+
+	for _, r := range d.Get("reserved_addresses").([]interface{}) {
+		entry := r.(map[string]interface{})
+		oid, _ := entry["id"].(string)
+
+		if len(oid) == 0 {
+			continue
+		}
+
+		info, err := ipaddressreservedinfo(oid, ipv6, meta)
+
+		if err != nil {
+			return err
+		}
+
+		if info == nil {
+			tflog.Debug(ctx, fmt.Sprintf("Unable to find reserved IP address (oid): %s\n", oid))
+			continue
+		}
+
+		entry["name"] = info["name"]
+		entry["role"] = info["role"]
+		entry["address"] = info["address"]
+
+		res = append(res, entry)
+	}
+
+	d.Set("reserved_addresses", res)
+
+	return nil
+}
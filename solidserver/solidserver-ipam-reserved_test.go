@@ -0,0 +1,186 @@
+package solidserver
+
+import (
+	"testing"
+)
+
+func TestIpSubnetReservedAddress(t *testing.T) {
+
+	type testCase struct {
+		Address    string
+		PrefixSize int
+		IPv6       bool
+		Offset     int
+		IP         string
+		Expected   string
+		Error      bool
+	}
+
+	testCases := map[string]testCase{
+		"ipv4_offset": {
+			Address:    "10.0.0.0",
+			PrefixSize: 24,
+			Offset:     2,
+			Expected:   "10.0.0.2",
+		},
+		"ipv4_last": {
+			Address:    "10.0.0.0",
+			PrefixSize: 24,
+			Offset:     -1,
+			Expected:   "10.0.0.255",
+		},
+		"ipv4_before_last": {
+			Address:    "10.0.0.0",
+			PrefixSize: 24,
+			Offset:     -2,
+			Expected:   "10.0.0.254",
+		},
+		"ipv4_ip": {
+			Address:    "10.0.0.0",
+			PrefixSize: 24,
+			IP:         "10.0.0.10",
+			Expected:   "10.0.0.10",
+		},
+		"ipv4_ip_outside": {
+			Address:    "10.0.0.0",
+			PrefixSize: 24,
+			IP:         "10.0.1.10",
+			Error:      true,
+		},
+		"ipv4_offset_outside": {
+			Address:    "10.0.0.0",
+			PrefixSize: 24,
+			Offset:     256,
+			Error:      true,
+		},
+		"ipv4_ipv6_ip": {
+			Address:    "10.0.0.0",
+			PrefixSize: 24,
+			IP:         "2001:db8::1",
+			Error:      true,
+		},
+		"both": {
+			Address:    "10.0.0.0",
+			PrefixSize: 24,
+			Offset:     1,
+			IP:         "10.0.0.1",
+			Error:      true,
+		},
+		"none": {
+			Address:    "10.0.0.0",
+			PrefixSize: 24,
+			Error:      true,
+		},
+		"ipv6_offset": {
+			Address:    "2001:db8::",
+			PrefixSize: 64,
+			IPv6:       true,
+			Offset:     1,
+			Expected:   "2001:0db8:0000:0000:0000:0000:0000:0001",
+		},
+		"ipv6_last": {
+			Address:    "2001:db8::",
+			PrefixSize: 64,
+			IPv6:       true,
+			Offset:     -1,
+			Expected:   "2001:0db8:0000:0000:ffff:ffff:ffff:ffff",
+		},
+		"ipv6_last_of_space": {
+			Address:    "ffff:ffff:ffff:ffff::",
+			PrefixSize: 64,
+			IPv6:       true,
+			Offset:     -3,
+			Expected:   "ffff:ffff:ffff:ffff:ffff:ffff:ffff:fffd",
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			start, end := ipsubnetbounds(tc.Address, tc.PrefixSize, tc.IPv6)
+
+			if start == nil || end == nil {
+				t.Fatalf("unable to compute the bounds of %s/%d", tc.Address, tc.PrefixSize)
+			}
+
+			address, err := ipsubnetreservedaddress(start, end, tc.Offset, tc.IP, tc.IPv6)
+
+			if (err != nil) != tc.Error {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if address != tc.Expected {
+				t.Errorf("unexpected address: %q (expected: %q)", address, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestIpSubnetReservedMatch(t *testing.T) {
+	previous := []interface{}{
+		map[string]interface{}{"name": "gw", "role": "gateway", "address": "10.0.0.1", "id": "1"},
+		map[string]interface{}{"name": "vrrp-a", "role": "vrrp", "address": "10.0.0.2", "id": "2"},
+		map[string]interface{}{"name": "relay", "role": "dhcp-relay", "address": "10.0.0.254", "id": "3"},
+	}
+
+	type testCase struct {
+		Desired  []map[string]interface{}
+		IDs      []string
+		Released []string
+	}
+
+	testCases := map[string]testCase{
+		"unchanged": {
+			Desired: []map[string]interface{}{
+				{"name": "gw", "role": "gateway", "address": "10.0.0.1"},
+				{"name": "vrrp-a", "role": "vrrp", "address": "10.0.0.2"},
+				{"name": "relay", "role": "dhcp-relay", "address": "10.0.0.254"},
+			},
+			IDs:      []string{"1", "2", "3"},
+			Released: []string{},
+		},
+		"reordered_and_removed": {
+			Desired: []map[string]interface{}{
+				{"name": "relay", "role": "dhcp-relay", "address": "10.0.0.254"},
+				{"name": "gw", "role": "gateway", "address": "10.0.0.1"},
+			},
+			IDs:      []string{"3", "1"},
+			Released: []string{"2"},
+		},
+		"renamed_and_moved": {
+			Desired: []map[string]interface{}{
+				{"name": "gw", "role": "gateway", "address": "10.0.0.1"},
+				{"name": "vrrp-b", "role": "vrrp", "address": "10.0.0.2"},
+				{"name": "relay", "role": "dhcp-relay", "address": "10.0.0.126"},
+			},
+			IDs:      []string{"1", "", ""},
+			Released: []string{"2", "3"},
+		},
+		"cleared": {
+			Desired:  []map[string]interface{}{},
+			IDs:      []string{},
+			Released: []string{"1", "2", "3"},
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			released := ipsubnetreservedmatch(previous, tc.Desired)
+
+			for i, entry := range tc.Desired {
+				if entry["id"] != tc.IDs[i] {
+					t.Errorf("unexpected ID for %s: %q (expected: %q)", entry["name"], entry["id"], tc.IDs[i])
+				}
+			}
+
+			if len(released) != len(tc.Released) {
+				t.Fatalf("unexpected released addresses: %v (expected IDs: %v)", released, tc.Released)
+			}
+
+			for i, r := range released {
+				if id := r.(map[string]interface{})["id"]; id != tc.Released[i] {
+					t.Errorf("unexpected released ID: %q (expected: %q)", id, tc.Released[i])
+				}
+			}
+		})
+	}
+}